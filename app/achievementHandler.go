@@ -0,0 +1,125 @@
+package app
+
+import (
+	"api-steam/dto"
+	"api-steam/services"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AchievementHandler struct {
+	Services services.AchievementService
+	Log      *slog.Logger
+}
+
+// GetAchievements - HTTP GET isteği ile oyunun başarımlarını açılma yüzdeleriyle döner
+func (h AchievementHandler) GetAchievements(c echo.Context) error {
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	achievements, err := h.Services.AchievementList(c.Request().Context(), gameID)
+	if err != nil {
+		return h.achievementError(c, err)
+	}
+	return c.JSON(http.StatusOK, achievements)
+}
+
+// CreateAchievement - HTTP POST isteği ile oyuna başarım tanımı ekler
+func (h AchievementHandler) CreateAchievement(c echo.Context) error {
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	var req dto.AchievementRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	achievement, err := h.Services.AchievementCreate(c.Request().Context(), gameID, req)
+	if err != nil {
+		return h.achievementError(c, err)
+	}
+	return c.JSON(http.StatusCreated, achievement)
+}
+
+// UpdateAchievement - HTTP PUT isteği ile başarım tanımını günceller
+func (h AchievementHandler) UpdateAchievement(c echo.Context) error {
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	var req dto.AchievementRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	achievement, err := h.Services.AchievementUpdate(c.Request().Context(), gameID, c.Param("key"), req)
+	if err != nil {
+		return h.achievementError(c, err)
+	}
+	return c.JSON(http.StatusOK, achievement)
+}
+
+// DeleteAchievement - HTTP DELETE isteği ile başarım tanımını ve açılma kayıtlarını siler
+func (h AchievementHandler) DeleteAchievement(c echo.Context) error {
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"state": false, "error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	if err := h.Services.AchievementDelete(c.Request().Context(), gameID, c.Param("key")); err != nil {
+		return h.achievementError(c, err)
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"state": true, "message": "Başarım silindi"})
+}
+
+// UnlockAchievement - HTTP POST isteği ile istemcinin bildirdiği başarım açılışını kaydeder; tekrar gönderimde 200 döner
+func (h AchievementHandler) UnlockAchievement(c echo.Context) error {
+	var req dto.AchievementUnlockRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	unlock, created, err := h.Services.AchievementUnlock(c.Request().Context(), req)
+	if err != nil {
+		return h.achievementError(c, err)
+	}
+	if !created {
+		return c.JSON(http.StatusOK, map[string]interface{}{"state": true, "message": "Başarım zaten açılmış"})
+	}
+	return c.JSON(http.StatusCreated, unlock)
+}
+
+// GetMyAchievements - HTTP GET isteği ile giriş yapmış kullanıcının oyundaki başarım ilerlemesini döner
+func (h AchievementHandler) GetMyAchievements(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{"error": "Bu işlem yalnızca kullanıcı hesapları içindir"})
+	}
+	gameID, err := primitive.ObjectIDFromHex(c.Param("gameId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	progress, err := h.Services.AchievementProgress(c.Request().Context(), userID, gameID)
+	if err != nil {
+		return h.achievementError(c, err)
+	}
+	return c.JSON(http.StatusOK, progress)
+}
+
+// achievementError servis hatalarını HTTP durum kodlarına çevirir
+func (h AchievementHandler) achievementError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidAchievement), errors.Is(err, services.ErrInvalidAchievementUser):
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+	case errors.Is(err, services.ErrAchievementNotOwned):
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{"error": err.Error()})
+	case errors.Is(err, services.ErrGameNotFound), errors.Is(err, services.ErrAchievementNotFound):
+		return c.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+	case errors.Is(err, services.ErrAchievementExists):
+		return c.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+	}
+	h.Log.ErrorContext(c.Request().Context(), "başarım işlemi başarısız", "error", err)
+	return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Başarım işlenirken hata oluştu: " + err.Error()})
+}
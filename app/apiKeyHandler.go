@@ -0,0 +1,67 @@
+package app
+
+import (
+	"api-steam/auth"
+	"api-steam/dto"
+	"api-steam/services"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type APIKeyHandler struct {
+	Services services.APIKeyService
+	Log      *slog.Logger
+}
+
+// CreateAPIKey - HTTP POST isteği ile yeni bir API anahtarı üretir, anahtar yalnızca bu yanıtta gösterilir
+func (h APIKeyHandler) CreateAPIKey(c echo.Context) error {
+	var req dto.CreateAPIKeyRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Anahtar için bir isim (name) gereklidir"})
+	}
+	principal, _ := auth.FromEcho(c)
+	result, err := h.Services.APIKeyCreate(c.Request().Context(), req, principal.Subject)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "API anahtarı oluşturulurken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusCreated, result)
+}
+
+// ListAPIKeys - HTTP GET isteği ile API anahtarlarını listeler
+func (h APIKeyHandler) ListAPIKeys(c echo.Context) error {
+	result, err := h.Services.APIKeyList(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "API anahtarları listelenirken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// RevokeAPIKey - HTTP DELETE isteği ile API anahtarını iptal eder
+func (h APIKeyHandler) RevokeAPIKey(c echo.Context) error {
+	objectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	result, err := h.Services.APIKeyRevoke(c.Request().Context(), objectID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"state": false, "error": "API anahtarı iptal edilirken hata oluştu: " + err.Error()})
+	}
+	if !result {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{"state": false, "error": "API anahtarı bulunamadı"})
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"state": true, "message": "API anahtarı iptal edildi"})
+}
+
+// Me - HTTP GET isteği ile kimliği doğrulanmış çağıranın bilgilerini döner
+func (h APIKeyHandler) Me(c echo.Context) error {
+	principal, _ := auth.FromEcho(c)
+	return c.JSON(http.StatusOK, principal)
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"api-steam/repository"
+	"api-steam/services"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AuditHandler struct {
+	Services services.AuditService
+	Log      *slog.Logger
+}
+
+// GetAuditLog - HTTP GET isteği ile denetim kayıtlarını döner (?resource=&resource_id=&actor_id=&action=&limit=)
+func (h AuditHandler) GetAuditLog(c echo.Context) error {
+	query := repository.AuditQuery{
+		Resource:   c.QueryParam("resource"),
+		ResourceID: c.QueryParam("resource_id"),
+		ActorID:    c.QueryParam("actor_id"),
+		Action:     c.QueryParam("action"),
+	}
+	if v := c.QueryParam("limit"); v != "" {
+		query.Limit, _ = strconv.ParseInt(v, 10, 64)
+	}
+	entries, err := h.Services.AuditList(c.Request().Context(), query)
+	if err != nil {
+		h.Log.ErrorContext(c.Request().Context(), "denetim kayıtları alınamadı", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Denetim kayıtları alınırken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, entries)
+}
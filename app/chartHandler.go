@@ -0,0 +1,56 @@
+package app
+
+import (
+	"api-steam/services"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ChartHandler struct {
+	Services services.ChartService
+	Log      *slog.Logger
+}
+
+// GetCharts - HTTP GET isteği ile mevcut grafiklerin adlarını ve hesaplanma zamanlarını döner
+func (h ChartHandler) GetCharts(c echo.Context) error {
+	charts, err := h.Services.ChartList(c.Request().Context())
+	if errors.Is(err, services.ErrChartNotReady) {
+		return c.JSON(http.StatusServiceUnavailable, map[string]interface{}{"error": err.Error()})
+	}
+	if err != nil {
+		h.Log.ErrorContext(c.Request().Context(), "grafikler listelenemedi", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Grafikler getirilirken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, charts)
+}
+
+// GetChart - HTTP GET isteği ile grafiği son hesaplanmış haliyle ve hesaplanma zamanıyla döner (?limit=)
+func (h ChartHandler) GetChart(c echo.Context) error {
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	chart, err := h.Services.ChartGet(c.Request().Context(), c.Param("name"), limit)
+	switch {
+	case errors.Is(err, services.ErrUnknownChart):
+		return c.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+	case errors.Is(err, services.ErrChartNotReady):
+		return c.JSON(http.StatusServiceUnavailable, map[string]interface{}{"error": err.Error()})
+	case err != nil:
+		h.Log.ErrorContext(c.Request().Context(), "grafik getirilemedi", "chart", c.Param("name"), "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Grafik getirilirken hata oluştu: " + err.Error()})
+	}
+	c.Response().Header().Set("Last-Modified", chart.GeneratedAt.UTC().Format(http.TimeFormat))
+	return c.JSON(http.StatusOK, chart)
+}
+
+// RefreshCharts - HTTP POST isteği ile grafikleri zamanlanmış işi beklemeden yeniden hesaplar
+func (h ChartHandler) RefreshCharts(c echo.Context) error {
+	charts, err := h.Services.ChartRefresh(c.Request().Context())
+	if err != nil {
+		h.Log.ErrorContext(c.Request().Context(), "grafikler hesaplanamadı", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Grafikler hesaplanırken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"charts": charts})
+}
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"api-steam/models"
+	"api-steam/repository"
+	"api-steam/services"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ContentFilter liste, arama ve oyun detayı route'larında gösterilecek en yüksek yaş derecesini isteğin context'ine ekler.
+// ?max_pegi= / ?max_esrb= verilmişse onlar, verilmemişse giriş yapmış kullanıcının içerik ayarları kullanılır;
+// ikisi birlikte verilirse daha kısıtlayıcı olanı geçerlidir. Geçersiz değerler 400 döner.
+func ContentFilter(users services.UserService, logger *slog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+			settings, err := services.ParseContentSettings(c.QueryParam("max_pegi"), c.QueryParam("max_esrb"))
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+			}
+			if settings == (models.ContentSettings{}) {
+				if userID, ok := currentUserID(c); ok {
+					user, err := users.UserGetByID(ctx, userID)
+					if err != nil && !errors.Is(err, repository.ErrNotFound) {
+						// sınırı bilinmeyen kullanıcıya filtresiz liste dönmektense istek başarısız olur
+						logger.ErrorContext(ctx, "içerik ayarları okunamadı", "user_id", userID, "error", err)
+						return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "İçerik ayarları okunurken hata oluştu"})
+					}
+					if user.Content != nil {
+						settings = *user.Content
+					}
+				}
+			}
+			c.SetRequest(c.Request().WithContext(services.WithContentLimit(ctx, settings)))
+			return next(c)
+		}
+	}
+}
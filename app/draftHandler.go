@@ -0,0 +1,177 @@
+package app
+
+import (
+	"api-steam/dto"
+	"api-steam/models"
+	"api-steam/services"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type DraftHandler struct {
+	Services services.DraftService
+	Log      *slog.Logger
+}
+
+// CreateDraft - HTTP POST isteği ile canlı oyunun düzenlenebilir bir taslağını oluşturur
+func (h DraftHandler) CreateDraft(c echo.Context) error {
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	draft, err := h.Services.DraftCreate(c.Request().Context(), gameID)
+	if err != nil {
+		return h.draftError(c, err)
+	}
+	return c.JSON(http.StatusCreated, draft)
+}
+
+// GetDraft - HTTP GET isteği ile taslağı yayınlandığında görüneceği haliyle (önizleme) döner
+func (h DraftHandler) GetDraft(c echo.Context) error {
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	draft, err := h.Services.DraftGet(c.Request().Context(), gameID)
+	if err != nil {
+		return h.draftError(c, err)
+	}
+	return c.JSON(http.StatusOK, draft)
+}
+
+// UpdateDraft - HTTP PUT isteği ile taslağın oyun içeriğini tamamen değiştirir; onay ve zamanlama düşer
+func (h DraftHandler) UpdateDraft(c echo.Context) error {
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	var game models.Game
+	if err := c.Bind(&game); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	draft, err := h.Services.DraftUpdate(c.Request().Context(), gameID, game)
+	if err != nil {
+		return h.draftError(c, err)
+	}
+	return c.JSON(http.StatusOK, draft)
+}
+
+// SubmitDraft - HTTP POST isteği ile taslağı incelemeye gönderir
+func (h DraftHandler) SubmitDraft(c echo.Context) error {
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	draft, err := h.Services.DraftSubmit(c.Request().Context(), gameID)
+	if err != nil {
+		return h.draftError(c, err)
+	}
+	return c.JSON(http.StatusOK, draft)
+}
+
+// ApproveDraft - HTTP POST isteği ile incelemedeki taslağı onaylar; onaylayan düzenleyen kullanıcıdan farklı olmalıdır
+func (h DraftHandler) ApproveDraft(c echo.Context) error {
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	draft, err := h.Services.DraftApprove(c.Request().Context(), gameID)
+	if err != nil {
+		return h.draftError(c, err)
+	}
+	return c.JSON(http.StatusOK, draft)
+}
+
+// RejectDraft - HTTP POST isteği ile incelemedeki taslağı gerekçesiyle düzenlemeye geri gönderir
+func (h DraftHandler) RejectDraft(c echo.Context) error {
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	var req dto.DraftRejectRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	draft, err := h.Services.DraftReject(c.Request().Context(), gameID, req.Reason)
+	if err != nil {
+		return h.draftError(c, err)
+	}
+	return c.JSON(http.StatusOK, draft)
+}
+
+// PublishDraft - HTTP POST isteği ile onaylanmış taslağı hemen yayınlar ya da publish_at zamanına zamanlar
+func (h DraftHandler) PublishDraft(c echo.Context) error {
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	var req dto.DraftPublishRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	result, err := h.Services.DraftPublish(c.Request().Context(), gameID, req)
+	if err != nil {
+		return h.draftError(c, err)
+	}
+	if !result.Published {
+		return c.JSON(http.StatusAccepted, result)
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// DiscardDraft - HTTP DELETE isteği ile taslağı siler
+func (h DraftHandler) DiscardDraft(c echo.Context) error {
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	if err := h.Services.DraftDiscard(c.Request().Context(), gameID); err != nil {
+		return h.draftError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListDrafts - HTTP GET isteği ile taslakları listeler; ?state=in_review inceleme kuyruğunu döner
+func (h DraftHandler) ListDrafts(c echo.Context) error {
+	drafts, err := h.Services.DraftList(c.Request().Context(), c.QueryParam("state"))
+	if err != nil {
+		return h.draftError(c, err)
+	}
+	return c.JSON(http.StatusOK, drafts)
+}
+
+// GetRevisions - HTTP GET isteği ile oyunun yayın geçmişini en yeniden başlayarak döner
+func (h DraftHandler) GetRevisions(c echo.Context) error {
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	revisions, err := h.Services.DraftRevisions(c.Request().Context(), gameID)
+	if err != nil {
+		return h.draftError(c, err)
+	}
+	return c.JSON(http.StatusOK, revisions)
+}
+
+// draftError servis hatalarını HTTP durum kodlarına çevirir
+func (h DraftHandler) draftError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, services.ErrReasonRequired), errors.Is(err, services.ErrInvalidGameRelation), errors.Is(err, services.ErrInvalidAgeRating):
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+	case errors.Is(err, services.ErrStatusRequirements):
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{"error": err.Error()})
+	case errors.Is(err, services.ErrSelfApproval):
+		return c.JSON(http.StatusForbidden, map[string]interface{}{"error": err.Error()})
+	case errors.Is(err, services.ErrDraftNotFound), errors.Is(err, services.ErrGameNotFound):
+		return c.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+	case errors.Is(err, services.ErrDraftExists), errors.Is(err, services.ErrInvalidDraftState),
+		errors.Is(err, services.ErrDraftConflict), errors.Is(err, services.ErrDraftStale):
+		return c.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+	}
+	h.Log.ErrorContext(c.Request().Context(), "taslak işlemi başarısız", "error", err)
+	return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Taslak işlenirken hata oluştu: " + err.Error()})
+}
@@ -0,0 +1,31 @@
+package app
+
+import (
+	"api-steam/services"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type HealthHandler struct {
+	Services services.HealthService
+}
+
+// Healthz - süreç ayakta olduğu sürece 200 döner (liveness)
+func (h HealthHandler) Healthz(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]interface{}{"status": "ok"})
+}
+
+// Readyz - bağımlılıklar hazırsa 200, değilse 503 döner (readiness)
+func (h HealthHandler) Readyz(c echo.Context) error {
+	result := h.Services.Readiness(c.Request().Context())
+	if !result.Ready {
+		return c.JSON(http.StatusServiceUnavailable, result)
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// Status - operatörler için sürüm, uptime, veritabanı gecikmesi ve havuz istatistiklerini döner
+func (h HealthHandler) Status(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.Services.Status(c.Request().Context()))
+}
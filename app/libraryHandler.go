@@ -0,0 +1,146 @@
+package app
+
+import (
+	"api-steam/dto"
+	"api-steam/repository"
+	"api-steam/services"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type LibraryHandler struct {
+	Services services.LibraryService
+	Log      *slog.Logger
+}
+
+// GetMyLibrary - HTTP GET isteği ile giriş yapmış kullanıcının kütüphanesini döner
+// (?source=purchase|gift|key&from=&to=&q=&sort=acquired|title|price&limit=&offset=)
+func (h LibraryHandler) GetMyLibrary(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{"error": "Bu işlem yalnızca kullanıcı hesapları içindir"})
+	}
+	return h.list(c, userID)
+}
+
+// GetMyOwnership - HTTP GET isteği ile giriş yapmış kullanıcının oyuna sahip olup olmadığını döner
+func (h LibraryHandler) GetMyOwnership(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{"error": "Bu işlem yalnızca kullanıcı hesapları içindir"})
+	}
+	gameID, err := primitive.ObjectIDFromHex(c.Param("gameId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	entry, err := h.Services.LibraryOwnership(c.Request().Context(), userID, gameID)
+	if errors.Is(err, services.ErrNotOwned) {
+		return c.JSON(http.StatusOK, map[string]interface{}{"owned": false})
+	}
+	if err != nil {
+		return h.libraryError(c, err)
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"owned": true, "entry": entry})
+}
+
+// GetUserLibrary - HTTP GET isteği ile verilen kullanıcının kütüphanesini döner (destek ekibi için)
+func (h LibraryHandler) GetUserLibrary(c echo.Context) error {
+	userID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	return h.list(c, userID)
+}
+
+// GrantGame - HTTP POST isteği ile kullanıcıya oyun sahipliği kaydeder (satın alma, hediye veya anahtar)
+func (h LibraryHandler) GrantGame(c echo.Context) error {
+	userID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	var req dto.LibraryGrantRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	entry, err := h.Services.LibraryGrant(c.Request().Context(), userID, req)
+	if err != nil {
+		return h.libraryError(c, err)
+	}
+	return c.JSON(http.StatusCreated, entry)
+}
+
+// RevokeGame - HTTP DELETE isteği ile kullanıcının oyun sahipliğini kaldırır (ör. iade); gövdede gerekçe zorunludur
+func (h LibraryHandler) RevokeGame(c echo.Context) error {
+	userID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	gameID, err := primitive.ObjectIDFromHex(c.Param("gameId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	var req dto.LibraryRevokeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	entry, err := h.Services.LibraryRevoke(c.Request().Context(), userID, gameID, req)
+	if err != nil {
+		return h.libraryError(c, err)
+	}
+	return c.JSON(http.StatusOK, entry)
+}
+
+// list sorgu parametrelerini okuyup kullanıcının kütüphanesini döner
+func (h LibraryHandler) list(c echo.Context, userID primitive.ObjectID) error {
+	query := repository.LibraryQuery{Source: c.QueryParam("source"), Title: c.QueryParam("q"), Sort: c.QueryParam("sort")}
+	var err error
+	if query.AcquiredFrom, err = parseDateParam(c.QueryParam("from")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz from tarihi: " + err.Error()})
+	}
+	if query.AcquiredTo, err = parseDateParam(c.QueryParam("to")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz to tarihi: " + err.Error()})
+	}
+	if len(c.QueryParam("to")) == len(time.DateOnly) {
+		query.AcquiredTo = query.AcquiredTo.Add(24*time.Hour - time.Nanosecond) // yalnızca gün verildiyse o gün dahildir
+	}
+	query.Limit, _ = strconv.ParseInt(c.QueryParam("limit"), 10, 64)
+	if query.Skip, err = strconv.ParseInt(c.QueryParam("offset"), 10, 64); err != nil || query.Skip < 0 {
+		query.Skip = 0
+	}
+	entries, err := h.Services.LibraryList(c.Request().Context(), userID, query)
+	if err != nil {
+		return h.libraryError(c, err)
+	}
+	return c.JSON(http.StatusOK, entries)
+}
+
+// libraryError servis hatalarını HTTP durum kodlarına çevirir
+func (h LibraryHandler) libraryError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidLibraryEntry), errors.Is(err, services.ErrReasonRequired):
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+	case errors.Is(err, services.ErrGameNotFound), errors.Is(err, services.ErrUserNotFound), errors.Is(err, services.ErrNotOwned):
+		return c.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+	case errors.Is(err, services.ErrAlreadyOwned):
+		return c.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+	}
+	h.Log.ErrorContext(c.Request().Context(), "kütüphane işlemi başarısız", "error", err)
+	return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Kütüphane işlenirken hata oluştu: " + err.Error()})
+}
+
+// parseDateParam sorgu parametresini RFC3339 veya YYYY-MM-DD olarak okur; boşsa sıfır zaman döner
+func parseDateParam(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
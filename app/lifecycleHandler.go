@@ -0,0 +1,63 @@
+package app
+
+import (
+	"api-steam/dto"
+	"api-steam/services"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type LifecycleHandler struct {
+	Services services.LifecycleService
+	Log      *slog.Logger
+}
+
+// GetStatus - HTTP GET isteği ile oyunun mevcut durumunu, durum geçmişini ve geçilebilecek durumları eksik alanlarıyla döner
+func (h LifecycleHandler) GetStatus(c echo.Context) error {
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	options, err := h.Services.LifecycleOptions(c.Request().Context(), gameID)
+	if err != nil {
+		return h.lifecycleError(c, err)
+	}
+	return c.JSON(http.StatusOK, options)
+}
+
+// TransitionStatus - HTTP POST isteği ile oyunu yaşam döngüsünde başka bir duruma geçirir ve güncel oyunu döner
+func (h LifecycleHandler) TransitionStatus(c echo.Context) error {
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	var req dto.StatusTransitionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	game, err := h.Services.LifecycleTransition(c.Request().Context(), gameID, req)
+	if err != nil {
+		return h.lifecycleError(c, err)
+	}
+	return c.JSON(http.StatusOK, game)
+}
+
+// lifecycleError servis hatalarını HTTP durum kodlarına çevirir
+func (h LifecycleHandler) lifecycleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidStatus), errors.Is(err, services.ErrReasonRequired):
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+	case errors.Is(err, services.ErrStatusRequirements):
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{"error": err.Error()})
+	case errors.Is(err, services.ErrGameNotFound):
+		return c.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidStatusTransition), errors.Is(err, services.ErrStatusConflict):
+		return c.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+	}
+	h.Log.ErrorContext(c.Request().Context(), "durum geçişi başarısız", "error", err)
+	return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Oyun durumu değiştirilirken hata oluştu: " + err.Error()})
+}
@@ -0,0 +1,23 @@
+package app
+
+import (
+	"api-steam/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Localize mağaza route'larında isteğin dilini seçip context'e ekler: desteklenen bir ?lang= verilmişse o, değilse
+// Accept-Language, o da uymuyorsa varsayılan dil. Seçilen dil Content-Language başlığıyla döner; yanıt dile göre
+// değiştiği için Vary: Accept-Language eklenir. Bu middleware'in olmadığı route'larda (editör uç noktaları) oyunlar
+// varsayılan dildeki alanları ve çeviri listesiyle döner.
+func Localize(locales services.Locales) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			locale := locales.Negotiate(c.QueryParam("lang"), c.Request().Header.Get("Accept-Language"))
+			c.Response().Header().Set("Content-Language", locale)
+			c.Response().Header().Add(echo.HeaderVary, "Accept-Language")
+			c.SetRequest(c.Request().WithContext(services.WithLocale(c.Request().Context(), locale)))
+			return next(c)
+		}
+	}
+}
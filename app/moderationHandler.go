@@ -0,0 +1,104 @@
+package app
+
+import (
+	"api-steam/dto"
+	"api-steam/services"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ModerationHandler struct {
+	Services services.ModerationService
+	Log      *slog.Logger
+}
+
+// GetQueue - HTTP GET isteği ile moderasyon kuyruğunu döner (?status=pending|hidden|rejected&limit=&offset=)
+func (h ModerationHandler) GetQueue(c echo.Context) error {
+	status := c.QueryParam("status")
+	var limit, offset int64
+	if v := c.QueryParam("limit"); v != "" {
+		limit, _ = strconv.ParseInt(v, 10, 64)
+	}
+	if v := c.QueryParam("offset"); v != "" {
+		offset, _ = strconv.ParseInt(v, 10, 64)
+	}
+	reviews, err := h.Services.ModerationQueue(c.Request().Context(), status, offset, limit)
+	if err != nil {
+		return h.moderationError(c, err)
+	}
+	return c.JSON(http.StatusOK, reviews)
+}
+
+// GetReports - HTTP GET isteği ile yoruma yapılan şikayetleri döner
+func (h ModerationHandler) GetReports(c echo.Context) error {
+	reviewID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	reports, err := h.Services.ModerationReports(c.Request().Context(), reviewID)
+	if err != nil {
+		return h.moderationError(c, err)
+	}
+	return c.JSON(http.StatusOK, reports)
+}
+
+// Decide - HTTP POST isteği ile yorum hakkında moderatör kararını (approve, reject, hide) uygular
+func (h ModerationHandler) Decide(action string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		reviewID, err := primitive.ObjectIDFromHex(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+		}
+		var req dto.ModerationDecisionRequest
+		if c.Request().ContentLength != 0 {
+			if err := c.Bind(&req); err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+			}
+		}
+		review, err := h.Services.ModerationDecide(c.Request().Context(), reviewID, action, req.Reason)
+		if err != nil {
+			return h.moderationError(c, err)
+		}
+		return c.JSON(http.StatusOK, review)
+	}
+}
+
+// ReportReview - HTTP POST isteği ile kullanıcının bir yorumu şikayet etmesini sağlar
+func (h ModerationHandler) ReportReview(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{"error": "Bu işlem yalnızca kullanıcı hesapları içindir"})
+	}
+	reviewID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	var req dto.ReviewReportRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	if err := h.Services.ModerationReport(c.Request().Context(), userID, reviewID, req); err != nil {
+		return h.moderationError(c, err)
+	}
+	return c.JSON(http.StatusAccepted, map[string]interface{}{"state": true})
+}
+
+// moderationError servis hatalarını HTTP durum kodlarına çevirir
+func (h ModerationHandler) moderationError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, services.ErrUnknownModerationAction), errors.Is(err, services.ErrReasonRequired),
+		errors.Is(err, services.ErrInvalidReportReason), errors.Is(err, services.ErrInvalidReviewStatus), errors.Is(err, services.ErrOwnReview):
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+	case errors.Is(err, services.ErrReviewNotFound):
+		return c.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+	case errors.Is(err, services.ErrAlreadyReported):
+		return c.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+	}
+	h.Log.ErrorContext(c.Request().Context(), "moderasyon işlemi başarısız", "error", err)
+	return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Moderasyon işlemi sırasında hata oluştu: " + err.Error()})
+}
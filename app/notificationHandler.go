@@ -0,0 +1,83 @@
+package app
+
+import (
+	"api-steam/dto"
+	"api-steam/services"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type NotificationHandler struct {
+	Services services.NotificationService
+	Log      *slog.Logger
+}
+
+// GetNotifications - HTTP GET isteği ile gelen kutusunu döner; ?unread=true yalnızca okunmamışları getirir
+func (h NotificationHandler) GetNotifications(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{"error": "Bu işlem yalnızca kullanıcı hesapları içindir"})
+	}
+	notifications, err := h.Services.NotificationList(c.Request().Context(), userID, c.QueryParam("unread") == "true")
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Bildirimler getirilirken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, notifications)
+}
+
+// MarkNotificationRead - HTTP POST isteği ile bildirimi okundu olarak işaretler
+func (h NotificationHandler) MarkNotificationRead(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{"error": "Bu işlem yalnızca kullanıcı hesapları içindir"})
+	}
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"state": false, "error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	err = h.Services.NotificationMarkRead(c.Request().Context(), userID, id)
+	if errors.Is(err, services.ErrNotificationNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{"state": false, "error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"state": false, "error": "Bildirim güncellenirken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"state": true})
+}
+
+// GetNotificationSettings - HTTP GET isteği ile bildirim kanallarını döner
+func (h NotificationHandler) GetNotificationSettings(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{"error": "Bu işlem yalnızca kullanıcı hesapları içindir"})
+	}
+	settings, err := h.Services.NotificationGetSettings(c.Request().Context(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Bildirim ayarları getirilirken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, settings)
+}
+
+// UpdateNotificationSettings - HTTP PUT isteği ile bildirim kanallarını değiştirir
+func (h NotificationHandler) UpdateNotificationSettings(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{"error": "Bu işlem yalnızca kullanıcı hesapları içindir"})
+	}
+	var req dto.NotificationSettingsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	settings, err := h.Services.NotificationUpdateSettings(c.Request().Context(), userID, req)
+	if errors.Is(err, services.ErrUnknownChannel) || errors.Is(err, services.ErrInvalidWebhookURL) || errors.Is(err, services.ErrBlockedWebhookURL) {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Bildirim ayarları güncellenirken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, settings)
+}
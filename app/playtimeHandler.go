@@ -0,0 +1,85 @@
+package app
+
+import (
+	"api-steam/dto"
+	"api-steam/services"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PlaytimeHandler struct {
+	Services services.PlaytimeService
+	Log      *slog.Logger
+}
+
+// IngestSessions - HTTP POST isteği ile istemcilerden gelen oynama oturumlarını toplu olarak kaydeder
+func (h PlaytimeHandler) IngestSessions(c echo.Context) error {
+	var req dto.PlaySessionBatchRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	result, err := h.Services.PlaytimeIngest(c.Request().Context(), req)
+	if errors.Is(err, services.ErrEmptySessionBatch) || errors.Is(err, services.ErrSessionBatchTooLarge) {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+	}
+	if err != nil {
+		h.Log.ErrorContext(c.Request().Context(), "oynama oturumları kaydedilemedi", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Oturumlar kaydedilirken hata oluştu: " + err.Error(), "result": result})
+	}
+	return c.JSON(http.StatusAccepted, result)
+}
+
+// GetMyPlaytime - HTTP GET isteği ile kullanıcının oyun başına oynama sürelerini döner (?sort=recent|total&limit=&offset=)
+func (h PlaytimeHandler) GetMyPlaytime(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{"error": "Bu işlem yalnızca kullanıcı hesapları içindir"})
+	}
+	limit, _ := strconv.ParseInt(c.QueryParam("limit"), 10, 64)
+	skip, err := strconv.ParseInt(c.QueryParam("offset"), 10, 64)
+	if err != nil || skip < 0 {
+		skip = 0
+	}
+	stats, err := h.Services.PlaytimeUserStats(c.Request().Context(), userID, c.QueryParam("sort"), skip, limit)
+	if err != nil {
+		h.Log.ErrorContext(c.Request().Context(), "oynama süreleri getirilemedi", "user_id", userID, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Oynama süreleri getirilirken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, stats)
+}
+
+// GetMyGamePlaytime - HTTP GET isteği ile kullanıcının bir oyundaki toplam süresini ve son oturumlarını döner
+func (h PlaytimeHandler) GetMyGamePlaytime(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{"error": "Bu işlem yalnızca kullanıcı hesapları içindir"})
+	}
+	gameID, err := primitive.ObjectIDFromHex(c.Param("gameId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	stats, sessions, err := h.Services.PlaytimeUserGame(c.Request().Context(), userID, gameID)
+	if errors.Is(err, services.ErrNoPlaytime) {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+	}
+	if err != nil {
+		h.Log.ErrorContext(c.Request().Context(), "oynama süresi getirilemedi", "user_id", userID, "game_id", gameID, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Oynama süresi getirilirken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"stats": stats, "recent_sessions": sessions})
+}
+
+// RecomputePlaytime - HTTP POST isteği ile oyunların oynama sürelerini zamanlanmış işi beklemeden yeniden hesaplar
+func (h PlaytimeHandler) RecomputePlaytime(c echo.Context) error {
+	games, err := h.Services.PlaytimeRecompute(c.Request().Context())
+	if err != nil {
+		h.Log.ErrorContext(c.Request().Context(), "oynama süreleri hesaplanamadı", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Oynama süreleri hesaplanırken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"games": games})
+}
@@ -1,17 +1,24 @@
 package app
 
 import (
+	"api-steam/auth"
+	"api-steam/dto"
 	"api-steam/models"
 	"api-steam/services"
+	"errors"
+	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type ProductHandler struct {
-	Services services.ProductService
+	Services     services.ProductService
+	Achievements services.AchievementService // Oyun detayında başarımlar ve açılma yüzdeleri için
+	Log          *slog.Logger
 }
 
 // CreateProduct - HTTP POST isteği ile yeni bir oyun oluşturur
@@ -20,7 +27,7 @@ func (h ProductHandler) CreateProduct(c echo.Context) error {
 	if err := c.Bind(&game); err != nil { //c.Bind ile Http nin boudy ksımındaki json esneisi go nesnesine dönüştürürüz
 		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()}) //c.JSON =Htttp yantını json formatına dönüştürür htt.StatusBadRequest ile 400 hata kodnunu döneriz map[string] ile inerface{} herhanig bşr nesne demek eror etiketi ile err.error kodunu eşleriz
 	}
-	result, err := h.Services.ProductInsert(game)
+	result, err := h.Services.ProductInsert(c.Request().Context(), game)
 	if err != nil || !result.Status {
 		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Oyun eklenirken hata oluştu: " + err.Error()})
 	}
@@ -36,7 +43,7 @@ func (h ProductHandler) CreateManyProducts(c echo.Context) error {
 	if len(games) == 0 {
 		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "En az bir oyun göndermelisiniz"})
 	}
-	result, err := h.Services.ProductInsertMany(games)
+	result, err := h.Services.ProductInsertMany(c.Request().Context(), games)
 	if err != nil || !result.Status {
 		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Toplu oyun eklenirken hata oluştu: " + err.Error()})
 	}
@@ -45,22 +52,33 @@ func (h ProductHandler) CreateManyProducts(c echo.Context) error {
 
 // GetAllProduct - HTTP GET isteği ile tüm oyunları listeleyerek döndürür
 func (h ProductHandler) GetAllProduct(c echo.Context) error {
-	result, err := h.Services.ProductGetAll()
+	result, err := h.Services.ProductGetAll(c.Request().Context())
 	if err != nil {
+		h.Log.ErrorContext(c.Request().Context(), "oyunlar listelenemedi", "error", err)
 		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Oyunlar listelenirken hata oluştu: " + err.Error()})
 	}
 	return c.JSON(http.StatusOK, result) //json tipinde []models.Game dizisini ve StatusOK Http kodunu json tipinde döneriz
 }
 
-// DeleteProduct - HTTP DELETE isteği ile belirtilen ID'ye sahip oyunu siler
+// DeleteProduct - HTTP DELETE isteği ile belirtilen ID'ye sahip oyunu siler (?dependents=cascade|detach bağlı DLC ve sürümler için)
 func (h ProductHandler) DeleteProduct(c echo.Context) error {
 	query := c.Param("id")
 	cnv, err := primitive.ObjectIDFromHex(query)
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"}) //400 hata kodunu Json tipinde öner eror etiketiyle eror mesajını eşlerüiz
 	}
-	result, err := h.Services.ProductDelete(cnv)
-	if err != nil || result == false {
+	result, err := h.Services.ProductDelete(c.Request().Context(), cnv, c.QueryParam("dependents"))
+	if errors.Is(err, services.ErrInvalidDeletePolicy) {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"state": false, "error": err.Error()})
+	}
+	if errors.Is(err, services.ErrGameHasDependents) {
+		return c.JSON(http.StatusConflict, map[string]interface{}{"state": false, "error": err.Error()})
+	}
+	if err != nil {
+		h.Log.ErrorContext(c.Request().Context(), "oyun silinemedi", "id", cnv, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"state": false, "error": "Oyun silinirken hata oluştu; oyun silinmedi, işlem tekrarlanabilir"})
+	}
+	if result == false {
 		return c.JSON(http.StatusBadRequest, map[string]interface{}{"state": false, "error": "Oyun silinirken hata oluştu veya oyun bulunamadı"}) //işlem gerçekleşemediği için json tipinde StatusBadRequest hata kodnu ve state i false olarak döneriz
 	}
 	return c.JSON(http.StatusOK, map[string]interface{}{"state": true, "message": "Oyun başarıyla silindi"}) //200 işlem başarılı kodunu döneriz  state :true ile true mesajı döneriz
@@ -77,8 +95,15 @@ func (h ProductHandler) UpdateProduct(c echo.Context) error {
 	if err := c.Bind(&updatedGame); err != nil { //c.Bind http den gelen boudy yi gyani game nesnesinin json tipini &updategame in referansına atayabilirzse  err bil döner dmnemezse err hata mesajı döner
 		return c.JSON(http.StatusBadRequest, map[string]interface{}{"state": false, "error": "Geçersiz istek formatı: " + err.Error()}) //err  hata kodunu Json tipinde döner işlem gerçekleşmediği için statei false yaparız
 	}
-	result, err := h.Services.ProductUptade(objectID, updatedGame)
+	result, err := h.Services.ProductUptade(c.Request().Context(), objectID, updatedGame)
+	if errors.Is(err, services.ErrInvalidGameRelation) || errors.Is(err, services.ErrInvalidAgeRating) || errors.Is(err, services.ErrStatusChangeViaTransition) {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"state": false, "error": err.Error()})
+	}
+	if errors.Is(err, services.ErrStatusRequirements) {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{"state": false, "error": err.Error()})
+	}
 	if err != nil || result == false {
+		h.Log.WarnContext(c.Request().Context(), "oyun güncellenemedi", "id", id, "error", err)
 		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"state": false, "error": "Oyun güncellenirken hata oluştu veya oyun bulunamadı"})
 	}
 	return c.JSON(http.StatusOK, map[string]interface{}{"state": true, "message": "Oyun başarıyla güncellendi"})
@@ -97,8 +122,15 @@ func (h ProductHandler) PatchProduct(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]interface{}{"state": false, "error": "İstek gövdesi ayrıştırılamadı: " + err.Error()})
 	}
 	// Servis katmanını çağır
-	result, err := h.Services.ProductPatch(objectID, updates)
+	result, err := h.Services.ProductPatch(c.Request().Context(), objectID, updates)
+	if errors.Is(err, services.ErrInvalidGameRelation) || errors.Is(err, services.ErrInvalidReleaseDate) || errors.Is(err, services.ErrInvalidAgeRating) || errors.Is(err, services.ErrInvalidRating) || errors.Is(err, services.ErrInvalidFieldType) || errors.Is(err, services.ErrStatusChangeViaTransition) {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"state": false, "error": err.Error()})
+	}
+	if errors.Is(err, services.ErrStatusRequirements) {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{"state": false, "error": err.Error()})
+	}
 	if err != nil {
+		h.Log.ErrorContext(c.Request().Context(), "oyun kısmi güncellenemedi", "id", id, "error", err)
 		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"state": false, "error": "Güncelleme sırasında hata oluştu: " + err.Error()}) //400 hata kodunu Json tipinde öner eror etiketiyle eror mesajını eşlerüiz
 	}
 	if !result {
@@ -107,20 +139,56 @@ func (h ProductHandler) PatchProduct(c echo.Context) error {
 	return c.JSON(http.StatusOK, map[string]interface{}{"state": true, "message": "Oyun alanları başarıyla güncellendi"})
 }
 
-// GetByID - HTTP GET isteği ile belirtilen ID'ye sahip oyunu getirir
+// GetByID - HTTP GET isteği ile belirtilen ID'ye sahip oyunu getirir (?include=dlcs,editions ile ilişkili öğeler gömülür)
 func (h ProductHandler) GetByID(c echo.Context) error {
 	id := c.Param("id")                            //url deki id veri tipini alır
 	objectID, err := primitive.ObjectIDFromHex(id) //alınan id strngini mongodbid tiine dönüştürür
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"}) //400 hata kodunu Json tipinde öner eror mesajı eror mesajını eşlerüiz
 	}
-	result, err := h.Services.ProductGetByID(objectID)
-	if err != nil {
+	result, err := h.Services.ProductGetByID(c.Request().Context(), objectID)
+	if err != nil || !readable(c, result.Status) || !services.GameAllowed(c.Request().Context(), result) {
 		return c.JSON(http.StatusNotFound, map[string]interface{}{"error": "Belirtilen ID'ye sahip oyun bulunamadı"}) //400 hata kodunu Json tipinde öner eror etiketiyle eror mesajını eşlerüiz
 	}
+	if h.Achievements != nil {
+		// başarımlar alınamazsa oyun detayı yine de döner
+		if result.Achievements, err = h.Achievements.AchievementList(c.Request().Context(), objectID); err != nil {
+			h.Log.WarnContext(c.Request().Context(), "oyunun başarımları getirilemedi", "id", objectID, "error", err)
+		}
+	}
+	include := map[string]bool{}
+	for _, part := range strings.Split(c.QueryParam("include"), ",") {
+		include[strings.TrimSpace(part)] = true
+	}
+	if include["dlcs"] || include["editions"] {
+		dlcs, editions, err := h.Services.ProductRelated(c.Request().Context(), result)
+		if err != nil {
+			h.Log.ErrorContext(c.Request().Context(), "ilişkili oyunlar getirilemedi", "id", objectID, "error", err)
+			return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "İlişkili oyunlar getirilirken hata oluştu: " + err.Error()})
+		}
+		if include["dlcs"] {
+			result.DLCs = dlcs
+		}
+		if include["editions"] {
+			result.Editions = editions
+		}
+	}
 	return c.JSON(http.StatusOK, result) //
 }
 
+// readable oyun detayının çağırana gösterilip gösterilmeyeceğini döndürür: kaldırılmış oyunlar kimseye gösterilmez, satıştan
+// kalkmış oyunların sayfası sahipleri için açık kalır, taslak ve incelemedeki oyunları yalnızca game:update yetkisi olanlar görür
+func readable(c echo.Context, status string) bool {
+	switch {
+	case status == models.GameStatusRemoved:
+		return false
+	case models.IsStorefrontVisible(status), status == models.GameStatusDelisted:
+		return true
+	}
+	principal, ok := auth.FromEcho(c)
+	return ok && principal.Can(auth.PermGameUpdate)
+}
+
 // GetGamesSorted - HTTP GET isteği ile oyunları belirtilen alana ve sıralama yönüne göre sıralar
 func (h ProductHandler) GetGamesSorted(c echo.Context) error {
 	query := c.QueryParam("field") //QueryParam() sorgu parametresine verilen değeri almak için kulanılr  field a verilen değeri alır bunu artandan azalana yada azalandan artana sıralamak için kulanırız
@@ -137,8 +205,9 @@ func (h ProductHandler) GetGamesSorted(c echo.Context) error {
 	} else {
 		order = -1 //azalandan artana -1
 	}
-	games, err := h.Services.ProductGetSorted(query, order)
+	games, err := h.Services.ProductGetSorted(c.Request().Context(), query, order)
 	if err != nil {
+		h.Log.ErrorContext(c.Request().Context(), "oyunlar sıralanamadı", "field", query, "error", err)
 		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Oyunlar sıralanırken hata oluştu: " + err.Error()})
 	}
 	return c.JSON(http.StatusOK, games)
@@ -150,20 +219,36 @@ func (h ProductHandler) GetGamesByExactName(c echo.Context) error {
 	if name == "" {
 		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "İsim parametresi gereklidir: ?name=<oyun adı> formatında gönderilmelidir"})
 	}
-	result, err := h.Services.ProductGetByExactName(name)
+	result, err := h.Services.ProductGetByExactName(c.Request().Context(), name)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Oyunlar isimle aranırken hata oluştu: " + err.Error()})
 	}
 	return c.JSON(http.StatusOK, result)
 }
 
-// GetGamesByPartialName - HTTP GET isteği ile kısmi isim eşleşmesine göre oyunları arar
+// GetGamesByPartialName - HTTP GET isteği ile kısmi isim eşleşmesine göre oyunları arar. Bilgisayar bilgisi verilirse
+// (?os=&cpu=&gpu=&memory_gb=&storage_gb=&directx=) yalnızca minimum gereksinimleri karşılanan oyunlar döner; bu durumda
+// isim isteğe bağlıdır.
 func (h ProductHandler) GetGamesByPartialName(c echo.Context) error {
 	name := c.QueryParam("name") //url deki name etiketine  verilen değeri çekme için kulanılır
+	var spec dto.HardwareSpecRequest
+	if err := c.Bind(&spec); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	if !spec.Empty() {
+		result, err := h.Services.ProductGetBySpec(c.Request().Context(), name, spec)
+		switch {
+		case errors.Is(err, services.ErrInvalidHardwareSpec), errors.Is(err, services.ErrUnknownHardware):
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		case err != nil:
+			return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Oyunlar sistem gereksinimlerine göre aranırken hata oluştu: " + err.Error()})
+		}
+		return c.JSON(http.StatusOK, result)
+	}
 	if name == "" {
 		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "İsim parametresi gereklidir: ?name=<oyun adının bir parçası> formatında gönderilmelidir"})
 	}
-	result, err := h.Services.ProductGetByPartialName(name)
+	result, err := h.Services.ProductGetByPartialName(c.Request().Context(), name)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Oyunlar kısmi isimle aranırken hata oluştu: " + err.Error()})
 	}
@@ -209,7 +294,7 @@ func (h ProductHandler) GetGamesByPriceRange(c echo.Context) error {
 	}
 	//yapay zeka
 
-	result, err := h.Services.ProductGetByPriceRange(minPrice, maxPrice)
+	result, err := h.Services.ProductGetByPriceRange(c.Request().Context(), minPrice, maxPrice)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
 			"error": "Fiyat aralığına göre oyunlar getirilirken hata oluştu: " + err.Error(),
@@ -0,0 +1,34 @@
+package app
+
+import (
+	"api-steam/services"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type RecommendationHandler struct {
+	Services services.RecommendationService
+	Log      *slog.Logger
+}
+
+// GetMyRecommendations - HTTP GET isteği ile giriş yapmış kullanıcıya kişisel oyun önerilerini açıklamalarıyla döner (?limit=)
+func (h RecommendationHandler) GetMyRecommendations(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{"error": "Bu işlem yalnızca kullanıcı hesapları içindir"})
+	}
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	recommendations, err := h.Services.RecommendForUser(c.Request().Context(), userID, limit)
+	if errors.Is(err, services.ErrUserNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+	}
+	if err != nil {
+		h.Log.ErrorContext(c.Request().Context(), "öneriler hesaplanamadı", "user_id", userID, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Öneriler hesaplanırken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, recommendations)
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"api-steam/dto"
+	"api-steam/services"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ReleaseHandler struct {
+	Services services.ReleaseService
+	Log      *slog.Logger
+}
+
+// GetCalendar - HTTP GET isteği ile çıkışları hafta ya da aya göre gruplanmış takvim olarak döner (?from=&to=&group=week|month&platform=)
+func (h ReleaseHandler) GetCalendar(c echo.Context) error {
+	query := dto.ReleaseCalendarQuery{GroupBy: c.QueryParam("group"), Platform: c.QueryParam("platform")}
+	var err error
+	if query.From, err = parseDateParam(c.QueryParam("from")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz from tarihi: " + err.Error()})
+	}
+	if query.To, err = parseDateParam(c.QueryParam("to")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz to tarihi: " + err.Error()})
+	}
+	if len(c.QueryParam("to")) == len(time.DateOnly) {
+		query.To = query.To.Add(24 * time.Hour) // yalnızca gün verildiyse o gün dahildir
+	}
+	calendar, err := h.Services.ReleaseCalendar(c.Request().Context(), query)
+	if errors.Is(err, services.ErrInvalidReleaseRange) || errors.Is(err, services.ErrInvalidReleaseGrouping) {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+	}
+	if err != nil {
+		h.Log.ErrorContext(c.Request().Context(), "çıkış takvimi getirilemedi", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Çıkış takvimi getirilirken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, calendar)
+}
+
+// GetComingSoon - HTTP GET isteği ile yakında çıkacak oyunları en yakın tarihten başlayarak döner (?platform=&limit=)
+func (h ReleaseHandler) GetComingSoon(c echo.Context) error {
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	entries, err := h.Services.ReleaseComingSoon(c.Request().Context(), c.QueryParam("platform"), limit)
+	if err != nil {
+		h.Log.ErrorContext(c.Request().Context(), "yakında çıkacak oyunlar getirilemedi", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Yakında çıkacak oyunlar getirilirken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, entries)
+}
+
+// GetCalendarFeed - HTTP GET isteği ile takvim uygulamalarının abone olabileceği iCalendar (.ics) akışını döner (?platform=)
+func (h ReleaseHandler) GetCalendarFeed(c echo.Context) error {
+	feed, err := h.Services.ReleaseICS(c.Request().Context(), c.QueryParam("platform"))
+	if err != nil {
+		h.Log.ErrorContext(c.Request().Context(), "takvim akışı oluşturulamadı", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Takvim akışı oluşturulurken hata oluştu: " + err.Error()})
+	}
+	c.Response().Header().Set("Content-Disposition", `inline; filename="releases.ics"`)
+	return c.Blob(http.StatusOK, "text/calendar; charset=utf-8", feed)
+}
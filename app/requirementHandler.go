@@ -0,0 +1,43 @@
+package app
+
+import (
+	"api-steam/dto"
+	"api-steam/services"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type RequirementHandler struct {
+	Services services.RequirementService
+	Log      *slog.Logger
+}
+
+// CanIRun - HTTP POST isteği ile gönderilen bilgisayarın oyunun sistem gereksinimlerini karşılayıp karşılamadığını döner
+// (below_minimum, meets_minimum, meets_recommended); karşılanmayan bileşenler failing listesinde yer alır
+func (h RequirementHandler) CanIRun(c echo.Context) error {
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	var req dto.HardwareSpecRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	result, err := h.Services.RequirementsCheck(c.Request().Context(), gameID, req)
+	switch {
+	case errors.Is(err, services.ErrInvalidHardwareSpec):
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+	case errors.Is(err, services.ErrGameNotFound):
+		return c.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+	case errors.Is(err, services.ErrRequirementsUnknown):
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{"error": err.Error()})
+	case err != nil:
+		h.Log.ErrorContext(c.Request().Context(), "sistem gereksinimleri karşılaştırılamadı", "game_id", gameID, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Sistem gereksinimleri karşılaştırılırken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,160 @@
+package app
+
+import (
+	"api-steam/dto"
+	"api-steam/repository"
+	"api-steam/services"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ReviewHandler struct {
+	Services services.ReviewService
+	Log      *slog.Logger
+}
+
+// GetReviews - HTTP GET isteği ile oyunun yayınlanmış yorumlarını döner (?sort=helpful|recent&verified=true&limit=&offset=)
+func (h ReviewHandler) GetReviews(c echo.Context) error {
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	query := repository.ReviewQuery{Sort: c.QueryParam("sort")}
+	query.Verified, _ = strconv.ParseBool(c.QueryParam("verified"))
+	query.Limit, _ = strconv.ParseInt(c.QueryParam("limit"), 10, 64)
+	if query.Skip, err = strconv.ParseInt(c.QueryParam("offset"), 10, 64); err != nil || query.Skip < 0 {
+		query.Skip = 0
+	}
+	reviews, err := h.Services.ReviewList(c.Request().Context(), gameID, query)
+	if err != nil {
+		h.Log.ErrorContext(c.Request().Context(), "yorumlar listelenemedi", "game_id", gameID, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Yorumlar getirilirken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, reviews)
+}
+
+// CreateReview - HTTP POST isteği ile oyuna yorum yazar
+func (h ReviewHandler) CreateReview(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{"error": "Bu işlem yalnızca kullanıcı hesapları içindir"})
+	}
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	var req dto.ReviewRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	review, err := h.Services.ReviewCreate(c.Request().Context(), userID, gameID, req)
+	if err != nil {
+		return h.reviewError(c, err)
+	}
+	return c.JSON(http.StatusCreated, review)
+}
+
+// UpdateMyReview - HTTP PUT isteği ile kullanıcının oyuna yazdığı yorumu düzenler
+func (h ReviewHandler) UpdateMyReview(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{"error": "Bu işlem yalnızca kullanıcı hesapları içindir"})
+	}
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	var req dto.ReviewRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	review, err := h.Services.ReviewUpdateOwn(c.Request().Context(), userID, gameID, req)
+	if err != nil {
+		return h.reviewError(c, err)
+	}
+	return c.JSON(http.StatusOK, review)
+}
+
+// DeleteMyReview - HTTP DELETE isteği ile kullanıcının oyuna yazdığı yorumu siler
+func (h ReviewHandler) DeleteMyReview(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{"error": "Bu işlem yalnızca kullanıcı hesapları içindir"})
+	}
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"state": false, "error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	if err := h.Services.ReviewDeleteOwn(c.Request().Context(), userID, gameID); err != nil {
+		return h.reviewError(c, err)
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"state": true, "message": "Yorum silindi"})
+}
+
+// VoteReview - HTTP POST isteği ile yoruma faydalı/faydalı değil oyu verir
+func (h ReviewHandler) VoteReview(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{"error": "Bu işlem yalnızca kullanıcı hesapları içindir"})
+	}
+	reviewID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"state": false, "error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	var req dto.ReviewVoteRequest
+	if err := c.Bind(&req); err != nil || req.Helpful == nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"state": false, "error": "helpful alanı gereklidir"})
+	}
+	if err := h.Services.ReviewVote(c.Request().Context(), userID, reviewID, *req.Helpful); err != nil {
+		return h.reviewError(c, err)
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"state": true})
+}
+
+// UnvoteReview - HTTP DELETE isteği ile yoruma verilen oyu geri alır
+func (h ReviewHandler) UnvoteReview(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{"error": "Bu işlem yalnızca kullanıcı hesapları içindir"})
+	}
+	reviewID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"state": false, "error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	if err := h.Services.ReviewUnvote(c.Request().Context(), userID, reviewID); err != nil {
+		return h.reviewError(c, err)
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"state": true})
+}
+
+// RecomputeRating - HTTP POST isteği ile oyunun puanlarını yorumlardan baştan hesaplar
+func (h ReviewHandler) RecomputeRating(c echo.Context) error {
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	totals, err := h.Services.ReviewRecomputeRating(c.Request().Context(), gameID)
+	if err != nil {
+		return h.reviewError(c, err)
+	}
+	return c.JSON(http.StatusOK, totals)
+}
+
+// reviewError servis hatalarını HTTP durum kodlarına çevirir
+func (h ReviewHandler) reviewError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidReview), errors.Is(err, services.ErrInvalidReviewStatus), errors.Is(err, services.ErrOwnReview):
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+	case errors.Is(err, services.ErrGameNotFound), errors.Is(err, services.ErrReviewNotFound):
+		return c.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+	case errors.Is(err, services.ErrAlreadyReviewed):
+		return c.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+	}
+	h.Log.ErrorContext(c.Request().Context(), "yorum işlemi başarısız", "error", err)
+	return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Yorum işlenirken hata oluştu: " + err.Error()})
+}
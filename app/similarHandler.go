@@ -0,0 +1,69 @@
+package app
+
+import (
+	"api-steam/dto"
+	"api-steam/services"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SimilarHandler struct {
+	Services services.SimilarService
+	Log      *slog.Logger
+}
+
+// GetSimilar - HTTP GET isteği ile oyunun benzer oyunlarını puanlarıyla döner (?limit=)
+func (h SimilarHandler) GetSimilar(c echo.Context) error {
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	similar, err := h.Services.SimilarList(c.Request().Context(), gameID, limit)
+	if errors.Is(err, services.ErrGameNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+	}
+	if err != nil {
+		h.Log.ErrorContext(c.Request().Context(), "benzer oyunlar getirilemedi", "game_id", gameID, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Benzer oyunlar getirilirken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, similar)
+}
+
+// CurateSimilar - HTTP PUT isteği ile benzer oyun listesinde sabitlenen ve çıkarılan oyunları değiştirir
+func (h SimilarHandler) CurateSimilar(c echo.Context) error {
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	var req dto.SimilarCurationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	similar, err := h.Services.SimilarCurate(c.Request().Context(), gameID, req)
+	switch {
+	case errors.Is(err, services.ErrInvalidSimilarCuration):
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+	case errors.Is(err, services.ErrGameNotFound):
+		return c.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+	case err != nil:
+		h.Log.ErrorContext(c.Request().Context(), "benzer oyun listesi düzenlenemedi", "game_id", gameID, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Benzer oyun listesi kaydedilirken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, similar)
+}
+
+// RecomputeSimilar - HTTP POST isteği ile benzer oyunları zamanlanmış işi beklemeden yeniden hesaplar
+func (h SimilarHandler) RecomputeSimilar(c echo.Context) error {
+	games, err := h.Services.SimilarRecompute(c.Request().Context())
+	if err != nil {
+		h.Log.ErrorContext(c.Request().Context(), "benzer oyunlar hesaplanamadı", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Benzer oyunlar hesaplanırken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"games": games})
+}
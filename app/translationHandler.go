@@ -0,0 +1,79 @@
+package app
+
+import (
+	"api-steam/dto"
+	"api-steam/services"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type TranslationHandler struct {
+	Services services.TranslationService
+	Log      *slog.Logger
+}
+
+// GetTranslations - HTTP GET isteği ile oyunun varsayılan dildeki metinlerini, çevirilerini ve eksik çevirilerini döner
+func (h TranslationHandler) GetTranslations(c echo.Context) error {
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	translations, err := h.Services.TranslationList(c.Request().Context(), gameID)
+	if err != nil {
+		return h.translationError(c, err)
+	}
+	return c.JSON(http.StatusOK, translations)
+}
+
+// PutTranslation - HTTP PUT isteği ile oyunun bir dildeki çevirisini ekler ya da değiştirir
+func (h TranslationHandler) PutTranslation(c echo.Context) error {
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	var req dto.TranslationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	translation, err := h.Services.TranslationSet(c.Request().Context(), gameID, c.Param("locale"), req)
+	if err != nil {
+		return h.translationError(c, err)
+	}
+	return c.JSON(http.StatusOK, translation)
+}
+
+// DeleteTranslation - HTTP DELETE isteği ile oyunun bir dildeki çevirisini siler
+func (h TranslationHandler) DeleteTranslation(c echo.Context) error {
+	gameID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	if err := h.Services.TranslationDelete(c.Request().Context(), gameID, c.Param("locale")); err != nil {
+		return h.translationError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetMissingTranslations - HTTP GET isteği ile oyunların çevrilmemiş alanlarını listeler (?locale= ile tek dil)
+func (h TranslationHandler) GetMissingTranslations(c echo.Context) error {
+	report, err := h.Services.TranslationMissing(c.Request().Context(), c.QueryParam("locale"))
+	if err != nil {
+		return h.translationError(c, err)
+	}
+	return c.JSON(http.StatusOK, report)
+}
+
+func (h TranslationHandler) translationError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, services.ErrUnsupportedLocale), errors.Is(err, services.ErrDefaultLocaleTranslation), errors.Is(err, services.ErrEmptyTranslation):
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+	case errors.Is(err, services.ErrGameNotFound), errors.Is(err, services.ErrTranslationNotFound):
+		return c.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+	}
+	h.Log.ErrorContext(c.Request().Context(), "çeviri işlemi başarısız", "error", err)
+	return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Çeviri işlemi sırasında hata oluştu: " + err.Error()})
+}
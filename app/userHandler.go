@@ -0,0 +1,152 @@
+package app
+
+import (
+	"api-steam/auth"
+	"api-steam/dto"
+	"api-steam/services"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type UserHandler struct {
+	Services services.UserService
+	Log      *slog.Logger
+}
+
+// Register - HTTP POST isteği ile yeni bir kullanıcı hesabı açar
+func (h UserHandler) Register(c echo.Context) error {
+	var req dto.RegisterRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	user, err := h.Services.UserRegister(c.Request().Context(), req)
+	switch {
+	case errors.Is(err, services.ErrInvalidEmail), errors.Is(err, services.ErrWeakPassword):
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+	case errors.Is(err, services.ErrEmailTaken):
+		return c.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Kayıt sırasında hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusCreated, user)
+}
+
+// Login - HTTP POST isteği ile e-posta ve şifreyi doğrulayıp token çifti döner
+func (h UserHandler) Login(c echo.Context) error {
+	var req dto.LoginRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	result, err := h.Services.UserLogin(c.Request().Context(), req)
+	if errors.Is(err, services.ErrInvalidCredentials) {
+		return c.JSON(http.StatusUnauthorized, map[string]interface{}{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Giriş sırasında hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// Refresh - HTTP POST isteği ile yenileme token'ını yeni bir token çiftiyle değiştirir
+func (h UserHandler) Refresh(c echo.Context) error {
+	var req dto.RefreshRequest
+	if err := c.Bind(&req); err != nil || req.RefreshToken == "" {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "refresh_token alanı gereklidir"})
+	}
+	result, err := h.Services.UserRefresh(c.Request().Context(), req.RefreshToken)
+	if errors.Is(err, services.ErrInvalidToken) {
+		return c.JSON(http.StatusUnauthorized, map[string]interface{}{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Oturum yenilenirken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// Logout - HTTP POST isteği ile yenileme token'ını iptal eder
+func (h UserHandler) Logout(c echo.Context) error {
+	var req dto.RefreshRequest
+	if err := c.Bind(&req); err != nil || req.RefreshToken == "" {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "refresh_token alanı gereklidir"})
+	}
+	if err := h.Services.UserLogout(c.Request().Context(), req.RefreshToken); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"state": false, "error": "Çıkış sırasında hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"state": true, "message": "Oturum kapatıldı"})
+}
+
+// ForgotPassword - HTTP POST isteği ile şifre sıfırlama bağlantısı gönderir; e-postanın kayıtlı olup olmadığını belli etmez
+func (h UserHandler) ForgotPassword(c echo.Context) error {
+	var req dto.ForgotPasswordRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	if err := h.Services.UserForgotPassword(c.Request().Context(), req.Email); err != nil {
+		h.Log.ErrorContext(c.Request().Context(), "şifre sıfırlama isteği işlenemedi", "error", err)
+	}
+	return c.JSON(http.StatusAccepted, map[string]interface{}{"state": true, "message": "E-posta kayıtlıysa sıfırlama bağlantısı gönderildi"})
+}
+
+// ResetPassword - HTTP POST isteği ile tek kullanımlık token'ı kullanarak yeni şifre belirler
+func (h UserHandler) ResetPassword(c echo.Context) error {
+	var req dto.ResetPasswordRequest
+	if err := c.Bind(&req); err != nil || req.Token == "" {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "token ve password alanları gereklidir"})
+	}
+	err := h.Services.UserResetPassword(c.Request().Context(), req)
+	switch {
+	case errors.Is(err, services.ErrWeakPassword), errors.Is(err, services.ErrInvalidToken):
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"state": false, "error": err.Error()})
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"state": false, "error": "Şifre sıfırlanırken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"state": true, "message": "Şifre güncellendi, lütfen tekrar giriş yapın"})
+}
+
+// Profile - HTTP GET isteği ile giriş yapmış kullanıcının profilini döner
+func (h UserHandler) Profile(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{"error": "Bu işlem yalnızca kullanıcı hesapları içindir"})
+	}
+	user, err := h.Services.UserGetByID(c.Request().Context(), userID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{"error": "Kullanıcı bulunamadı"})
+	}
+	return c.JSON(http.StatusOK, user)
+}
+
+// UpdateContentSettings - HTTP PUT isteği ile kullanıcının yaş derecesi sınırlarını (PEGI/ESRB) değiştirir
+func (h UserHandler) UpdateContentSettings(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{"error": "Bu işlem yalnızca kullanıcı hesapları içindir"})
+	}
+	var req dto.ContentSettingsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	settings, err := h.Services.UserUpdateContentSettings(c.Request().Context(), userID, req)
+	if errors.Is(err, services.ErrInvalidAgeRating) {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+	}
+	if err != nil {
+		h.Log.ErrorContext(c.Request().Context(), "içerik ayarları güncellenemedi", "user_id", userID, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "İçerik ayarları kaydedilirken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, settings)
+}
+
+// currentUserID isteği yapan kullanıcının ID'sini döndürür; API anahtarı ile gelen isteklerde ok false döner
+func currentUserID(c echo.Context) (primitive.ObjectID, bool) {
+	principal, ok := auth.FromEcho(c)
+	if !ok || principal.Kind != auth.KindUser {
+		return primitive.NilObjectID, false
+	}
+	id, err := primitive.ObjectIDFromHex(principal.Subject)
+	return id, err == nil
+}
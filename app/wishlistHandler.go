@@ -0,0 +1,98 @@
+package app
+
+import (
+	"api-steam/dto"
+	"api-steam/services"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type WishlistHandler struct {
+	Services services.WishlistService
+	Log      *slog.Logger
+}
+
+// GetWishlist - HTTP GET isteği ile giriş yapmış kullanıcının istek listesini döner
+func (h WishlistHandler) GetWishlist(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{"error": "Bu işlem yalnızca kullanıcı hesapları içindir"})
+	}
+	items, err := h.Services.WishlistList(c.Request().Context(), userID)
+	if err != nil {
+		h.Log.ErrorContext(c.Request().Context(), "istek listesi getirilemedi", "user_id", userID, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "İstek listesi getirilirken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, items)
+}
+
+// AddToWishlist - HTTP POST isteği ile istek listesine oyun ekler
+func (h WishlistHandler) AddToWishlist(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{"error": "Bu işlem yalnızca kullanıcı hesapları içindir"})
+	}
+	var req dto.WishlistAddRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	item, err := h.Services.WishlistAdd(c.Request().Context(), userID, req)
+	switch {
+	case errors.Is(err, services.ErrGameNotFound):
+		return c.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+	case errors.Is(err, services.ErrAlreadyWishlisted):
+		return c.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+	case err != nil:
+		h.Log.ErrorContext(c.Request().Context(), "istek listesine eklenemedi", "user_id", userID, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "İstek listesine eklenirken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusCreated, item)
+}
+
+// UpdateWishlistItem - HTTP PATCH isteği ile listedeki oyunun bildirim tercihlerini günceller
+func (h WishlistHandler) UpdateWishlistItem(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{"error": "Bu işlem yalnızca kullanıcı hesapları içindir"})
+	}
+	gameID, err := primitive.ObjectIDFromHex(c.Param("gameId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	var req dto.WishlistPreferencesRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+	}
+	item, err := h.Services.WishlistUpdate(c.Request().Context(), userID, gameID, req)
+	if errors.Is(err, services.ErrWishlistItemNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Tercihler güncellenirken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, item)
+}
+
+// RemoveFromWishlist - HTTP DELETE isteği ile oyunu istek listesinden çıkarır
+func (h WishlistHandler) RemoveFromWishlist(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{"error": "Bu işlem yalnızca kullanıcı hesapları içindir"})
+	}
+	gameID, err := primitive.ObjectIDFromHex(c.Param("gameId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"state": false, "error": "Geçersiz ID formatı: ID bir MongoDB ObjectID olmalıdır"})
+	}
+	err = h.Services.WishlistRemove(c.Request().Context(), userID, gameID)
+	if errors.Is(err, services.ErrWishlistItemNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{"state": false, "error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"state": false, "error": "Listeden çıkarılırken hata oluştu: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"state": true, "message": "Oyun istek listesinden çıkarıldı"})
+}
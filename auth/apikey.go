@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"api-steam/models"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// apiKeyTag API anahtarlarını JWT'lerden ayırt etmek için kullanılan ön ektir
+const apiKeyTag = "ghk"
+
+// ErrInvalidCredentials kimlik bilgileri doğrulanamadığında döner
+var ErrInvalidCredentials = errors.New("geçersiz kimlik bilgileri")
+
+// APIKeyStore API anahtarlarının okunduğu depodur (repository.APIKeyRepository bunu sağlar)
+type APIKeyStore interface {
+	GetByPrefix(ctx context.Context, prefix string) (models.APIKey, error)
+	TouchLastUsed(ctx context.Context, id primitive.ObjectID) error
+}
+
+// GenerateAPIKey "ghk_<prefix>_<secret>" biçiminde yeni bir anahtar üretir; anahtar yalnızca bir kez gösterilir, saklanan özettir
+func GenerateAPIKey() (key, prefix, hash string, err error) {
+	prefixBytes := make([]byte, 6)
+	secretBytes := make([]byte, 32)
+	if _, err = rand.Read(prefixBytes); err != nil {
+		return "", "", "", err
+	}
+	if _, err = rand.Read(secretBytes); err != nil {
+		return "", "", "", err
+	}
+	prefix = hex.EncodeToString(prefixBytes)
+	key = apiKeyTag + "_" + prefix + "_" + base64.RawURLEncoding.EncodeToString(secretBytes)
+	return key, prefix, HashSecret(key), nil
+}
+
+// HashSecret anahtarın saklanacak SHA-256 özetini döndürür
+func HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsAPIKey değerin bir API anahtarı biçiminde olup olmadığını döndürür
+func IsAPIKey(value string) bool {
+	return strings.HasPrefix(value, apiKeyTag+"_")
+}
+
+// VerifyAPIKey anahtarı depodaki özetle karşılaştırır ve çağıranı döndürür
+func VerifyAPIKey(ctx context.Context, store APIKeyStore, key string) (Principal, error) {
+	parts := strings.SplitN(key, "_", 3)
+	if len(parts) != 3 || parts[0] != apiKeyTag {
+		return Principal{}, ErrInvalidCredentials
+	}
+	stored, err := store.GetByPrefix(ctx, parts[1])
+	if err != nil {
+		return Principal{}, ErrInvalidCredentials
+	}
+	if stored.Revoked || subtle.ConstantTimeCompare([]byte(stored.Hash), []byte(HashSecret(key))) != 1 {
+		return Principal{}, ErrInvalidCredentials
+	}
+	_ = store.TouchLastUsed(ctx, stored.ID)
+	return Principal{Subject: stored.ID.Hex(), Kind: KindService, Name: stored.Name, Roles: stored.Roles}, nil
+}
@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims API'nin kabul ettiği JWT alanlarıdır
+type Claims struct {
+	Name  string   `json:"name,omitempty"`
+	Roles []string `json:"roles,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// JWTVerifier HS256 (paylaşılan sır) ve RS256 (yerel JWKS dosyasındaki açık anahtarlar) ile imzalanmış token'ları doğrular
+type JWTVerifier struct {
+	hmacSecret []byte
+	rsaKeys    map[string]*rsa.PublicKey // kid -> açık anahtar
+	issuer     string
+	audience   string
+}
+
+// NewJWTVerifier verilen ayarlarla bir doğrulayıcı oluşturur; jwksFile boşsa RS256 kapalıdır
+func NewJWTVerifier(hmacSecret, jwksFile, issuer, audience string) (*JWTVerifier, error) {
+	v := &JWTVerifier{hmacSecret: []byte(hmacSecret), issuer: issuer, audience: audience}
+	if jwksFile != "" {
+		keys, err := loadJWKS(jwksFile)
+		if err != nil {
+			return nil, fmt.Errorf("JWKS dosyası okunamadı: %w", err)
+		}
+		v.rsaKeys = keys
+	}
+	return v, nil
+}
+
+// Enabled en az bir imzalama yöntemi yapılandırılmışsa true döner
+func (v *JWTVerifier) Enabled() bool {
+	return len(v.hmacSecret) > 0 || len(v.rsaKeys) > 0
+}
+
+// Verify token'ın imzasını ve süresini doğrular, içindeki çağıranı döndürür
+func (v *JWTVerifier) Verify(token string) (Principal, error) {
+	opts := []jwt.ParserOption{jwt.WithValidMethods([]string{"HS256", "RS256"}), jwt.WithExpirationRequired()}
+	if v.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(v.issuer))
+	}
+	if v.audience != "" {
+		opts = append(opts, jwt.WithAudience(v.audience))
+	}
+	var claims Claims
+	_, err := jwt.ParseWithClaims(token, &claims, v.keyFunc, opts...)
+	if err != nil {
+		return Principal{}, err
+	}
+	if claims.Subject == "" {
+		return Principal{}, errors.New("token'da sub alanı yok")
+	}
+	return Principal{Subject: claims.Subject, Kind: KindUser, Name: claims.Name, Roles: claims.Roles}, nil
+}
+
+// keyFunc token başlığındaki algoritmaya ve kid değerine göre doğrulama anahtarını seçer
+func (v *JWTVerifier) keyFunc(token *jwt.Token) (interface{}, error) {
+	switch token.Method.Alg() {
+	case "HS256":
+		if len(v.hmacSecret) == 0 {
+			return nil, errors.New("HS256 yapılandırılmamış")
+		}
+		return v.hmacSecret, nil
+	case "RS256":
+		kid, _ := token.Header["kid"].(string)
+		key, ok := v.rsaKeys[kid]
+		if !ok {
+			return nil, fmt.Errorf("bilinmeyen anahtar kimliği: %q", kid)
+		}
+		return key, nil
+	}
+	return nil, fmt.Errorf("desteklenmeyen algoritma: %s", token.Method.Alg())
+}
+
+// loadJWKS yerel JWKS dosyasındaki RSA açık anahtarlarını kid'e göre okur
+func loadJWKS(path string) (map[string]*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("%s anahtarının n değeri geçersiz: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("%s anahtarının e değeri geçersiz: %w", k.Kid, err)
+		}
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	return keys, nil
+}
+
+// JWTSigner API'nin kendi kullanıcıları için HS256 erişim token'ı üretir
+type JWTSigner struct {
+	secret   []byte
+	issuer   string
+	audience string
+	ttl      time.Duration
+}
+
+// NewJWTSigner verilen sır ve süreyle bir imzalayıcı oluşturur
+func NewJWTSigner(secret, issuer, audience string, ttl time.Duration) *JWTSigner {
+	return &JWTSigner{secret: []byte(secret), issuer: issuer, audience: audience, ttl: ttl}
+}
+
+// Sign çağıran için süreli bir erişim token'ı üretir
+func (s *JWTSigner) Sign(p Principal) (string, time.Time, error) {
+	if len(s.secret) == 0 {
+		return "", time.Time{}, errors.New("JWT_HS256_SECRET tanımlı değil, token üretilemez")
+	}
+	now := time.Now()
+	expiresAt := now.Add(s.ttl)
+	claims := Claims{
+		Name:  p.Name,
+		Roles: p.Roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   p.Subject,
+			Issuer:    s.issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	if s.audience != "" {
+		claims.Audience = jwt.ClaimStrings{s.audience}
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+	return token, expiresAt, err
+}
@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderAPIKey servislerin API anahtarını gönderebileceği başlıktır
+const HeaderAPIKey = "X-API-Key"
+
+// Authenticator gelen isteklerin kimlik bilgilerini doğrular
+type Authenticator struct {
+	JWT     *JWTVerifier
+	APIKeys APIKeyStore
+	Log     *slog.Logger
+}
+
+// NewAuthenticator JWT doğrulayıcı ve API anahtarı deposuyla bir Authenticator oluşturur
+func NewAuthenticator(verifier *JWTVerifier, keys APIKeyStore, logger *slog.Logger) *Authenticator {
+	return &Authenticator{JWT: verifier, APIKeys: keys, Log: logger}
+}
+
+// Middleware Authorization: Bearer <jwt|api-key> veya X-API-Key başlığını doğrular ve çağıranı isteğin context'ine ekler.
+// Kimlik bilgisi gönderilmeyen istekler anonim olarak devam eder; geçersiz kimlik bilgisi 401 döner.
+func (a *Authenticator) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			credential := c.Request().Header.Get(HeaderAPIKey)
+			if credential == "" {
+				if header := c.Request().Header.Get(echo.HeaderAuthorization); header != "" {
+					scheme, value, ok := strings.Cut(header, " ")
+					if !ok || !strings.EqualFold(scheme, "Bearer") {
+						return c.JSON(http.StatusUnauthorized, map[string]interface{}{"error": "Authorization başlığı 'Bearer <token>' biçiminde olmalıdır"})
+					}
+					credential = strings.TrimSpace(value)
+				}
+			}
+			if credential == "" {
+				return next(c)
+			}
+
+			ctx := c.Request().Context()
+			var principal Principal
+			var err error
+			if IsAPIKey(credential) {
+				principal, err = VerifyAPIKey(ctx, a.APIKeys, credential)
+			} else if a.JWT.Enabled() {
+				principal, err = a.JWT.Verify(credential)
+			} else {
+				err = ErrInvalidCredentials
+			}
+			if err != nil {
+				a.Log.WarnContext(ctx, "kimlik doğrulanamadı", "remote_ip", c.RealIP(), "error", err)
+				return c.JSON(http.StatusUnauthorized, map[string]interface{}{"error": "Geçersiz veya süresi dolmuş kimlik bilgisi"})
+			}
+			c.SetRequest(c.Request().WithContext(WithPrincipal(ctx, principal)))
+			return next(c)
+		}
+	}
+}
+
+// RequireAuth kimliği doğrulanmamış istekleri 401 ile reddeder
+func RequireAuth() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if _, ok := FromEcho(c); !ok {
+				return unauthorized(c)
+			}
+			return next(c)
+		}
+	}
+}
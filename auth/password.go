@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// passwordCost bcrypt iş faktörüdür
+const passwordCost = 12
+
+// dummyHash bilinmeyen e-postalarla girişte de aynı sürede yanıt vermek için karşılaştırılan özettir
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("gamer-hub-dummy-password"), passwordCost)
+
+// HashPassword şifrenin bcrypt özetini üretir
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), passwordCost)
+	return string(hash), err
+}
+
+// CheckPassword şifrenin özetle eşleşip eşleşmediğini döndürür; hash boşsa sahte bir karşılaştırma yapar
+func CheckPassword(hash, password string) bool {
+	if hash == "" {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// NewOpaqueToken yenileme ve şifre sıfırlama için rastgele bir token ve saklanacak özetini üretir
+func NewOpaqueToken() (token, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, HashSecret(token), nil
+}
@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Principal kimliği doğrulanmış çağıranı temsil eder
+type Principal struct {
+	Subject string   `json:"subject"`         // Kullanıcı ID'si veya API anahtarı ID'si
+	Kind    string   `json:"kind"`            // "user" (JWT) veya "service" (API anahtarı)
+	Name    string   `json:"name,omitempty"`  // Görünen ad
+	Roles   []string `json:"roles,omitempty"` // Roller
+}
+
+const (
+	KindUser    = "user"
+	KindService = "service"
+)
+
+// HasRole çağıranın verilen role sahip olup olmadığını döndürür
+func (p Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+type principalKey struct{}
+
+// WithPrincipal çağıranı context'e ekler
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext context'teki çağıranı döndürür; anonim isteklerde ok false döner
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// FromEcho isteğin çağıranını döndürür
+func FromEcho(c echo.Context) (Principal, bool) {
+	return FromContext(c.Request().Context())
+}
@@ -0,0 +1,276 @@
+package auth
+
+import (
+	"api-steam/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Permission ProductHandler ve yönetim uç noktalarındaki bir işlemi temsil eder
+type Permission string
+
+const (
+	PermGameRead          Permission = "game:read"          // Oyunları okuma
+	PermGameCreate        Permission = "game:create"        // Yeni oyun ekleme
+	PermGameUpdate        Permission = "game:update"        // Fiyat dışındaki alanları güncelleme (PUT/PATCH)
+	PermGamePrice         Permission = "game:price"         // Fiyat alanlarını güncelleme
+	PermGameDelete        Permission = "game:delete"        // Oyun silme
+	PermGameBulk          Permission = "game:bulk"          // Toplu oyun ekleme
+	PermGamePublish       Permission = "game:publish"       // Başkasının taslağını onaylama ve yayınlama
+	PermAPIKeyManage      Permission = "apikey:manage"      // API anahtarlarını yönetme
+	PermReviewModerate    Permission = "review:moderate"    // Yorumların moderasyon durumunu değiştirme
+	PermAuditRead         Permission = "audit:read"         // Denetim kayıtlarını okuma
+	PermLibraryManage     Permission = "library:manage"     // Kullanıcılara oyun sahipliği kaydetme ve kaldırma
+	PermPlaytimeIngest    Permission = "playtime:ingest"    // İstemcilerden oynama oturumu gönderme
+	PermAchievementUnlock Permission = "achievement:unlock" // İstemcilerden başarım açılışı gönderme
+)
+
+const (
+	RoleViewer         = "viewer"
+	RoleEditor         = "editor"
+	RolePricingManager = "pricing-manager"
+	RoleModerator      = "moderator"
+	RoleGameClient     = "game-client" // Oynama oturumlarını ve başarımları gönderen launcher/oyun istemcileri (API anahtarı)
+	RoleAdmin          = "admin"
+)
+
+// rolePermissions rollerin izinlerini tek yerde tanımlar; yeni bir kural buraya eklenir
+var rolePermissions = map[string][]Permission{
+	RoleViewer:         {PermGameRead},
+	RoleEditor:         {PermGameRead, PermGameCreate, PermGameUpdate, PermGamePublish},
+	RolePricingManager: {PermGameRead, PermGamePrice},
+	RoleModerator:      {PermGameRead, PermReviewModerate},
+	RoleGameClient:     {PermGameRead, PermPlaytimeIngest, PermAchievementUnlock},
+	RoleAdmin:          {PermGameRead, PermGameCreate, PermGameUpdate, PermGamePrice, PermGameDelete, PermGameBulk, PermGamePublish, PermAPIKeyManage, PermReviewModerate, PermAuditRead, PermLibraryManage, PermPlaytimeIngest, PermAchievementUnlock},
+}
+
+const (
+	// priceField fiyat izni gerektiren üst düzey alandır (PATCH'te "price" veya "price.amount" gibi)
+	priceField = "price"
+	// bundleItemsField ve kindField paket fiyatını belirleyen alanlardır; paket fiyatı içindeki oyunlardan hesaplanır
+	bundleItemsField = "bundle_items"
+	kindField        = "kind"
+)
+
+// Can çağıranın rollerinden herhangi biri verilen izni içeriyorsa true döner
+func (p Principal) Can(perm Permission) bool {
+	for _, role := range p.Roles {
+		for _, granted := range rolePermissions[role] {
+			if granted == perm {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PriceLookup oyunun mevcut fiyatını getirir; PUT isteklerinde fiyatın değişip değişmediğini anlamak için kullanılır.
+// Oyun yoksa found=false ve nil hata döner.
+type PriceLookup func(ctx context.Context, id primitive.ObjectID) (price models.Price, found bool, err error)
+
+// Authorizer rol tabanlı izinleri middleware olarak uygular ve reddedilen istekleri loglar
+type Authorizer struct {
+	Log *slog.Logger
+}
+
+// NewAuthorizer bir Authorizer oluşturur
+func NewAuthorizer(logger *slog.Logger) *Authorizer {
+	return &Authorizer{Log: logger}
+}
+
+// Require çağıranın verilen izinlerin hepsine sahip olmasını şart koşar
+func (a *Authorizer) Require(perms ...Permission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal, ok := FromEcho(c)
+			if !ok {
+				return unauthorized(c)
+			}
+			for _, perm := range perms {
+				if !principal.Can(perm) {
+					return a.deny(c, principal, perm, "")
+				}
+			}
+			return next(c)
+		}
+	}
+}
+
+// CreateGame POST /api/game için game:create ister; fiyat içeren gövde ve fiyatı içindeki oyunlardan hesaplanan paketler
+// ayrıca game:price ister
+func (a *Authorizer) CreateGame() echo.MiddlewareFunc {
+	return a.withBody(PermGameCreate, func(c echo.Context, principal Principal, body []byte) error {
+		var game models.Game
+		if err := json.Unmarshal(body, &game); err != nil {
+			return invalidBody(c, err)
+		}
+		hasPrice := game.Price.Amount != 0 || game.Price.Currency != "" || game.Price.Discount != 0 || game.Price.OnSale ||
+			!game.Price.SaleEndDate.IsZero()
+		if hasPrice && !principal.Can(PermGamePrice) {
+			return a.deny(c, principal, PermGamePrice, "price")
+		}
+		if game.Kind == models.GameKindBundle && !principal.Can(PermGamePrice) {
+			return a.deny(c, principal, PermGamePrice, "kind")
+		}
+		return nil
+	})
+}
+
+// ReplaceGame PUT /api/game/:id için game:update ister; fiyat mevcut değerden farklıysa ayrıca game:price ister. Paketin
+// fiyatı gövdedeki bundle_items'tan yeniden hesaplandığından paket gövdesi her zaman game:price ister; editörler paketin
+// diğer alanlarını PATCH ile değiştirebilir.
+func (a *Authorizer) ReplaceGame(lookup PriceLookup) echo.MiddlewareFunc {
+	return a.withBody(PermGameUpdate, func(c echo.Context, principal Principal, body []byte) error {
+		if principal.Can(PermGamePrice) {
+			return nil
+		}
+		id, err := primitive.ObjectIDFromHex(c.Param("id"))
+		if err != nil {
+			return nil // geçersiz ID'yi handler bildirir
+		}
+		var game models.Game
+		if err := json.Unmarshal(body, &game); err != nil {
+			return invalidBody(c, err)
+		}
+		if game.Kind == models.GameKindBundle {
+			return a.deny(c, principal, PermGamePrice, "kind")
+		}
+		current, found, err := lookup(c.Request().Context(), id)
+		if err != nil {
+			// fiyatın değişip değişmediği bilinmeden istek geçirilmez
+			a.Log.ErrorContext(c.Request().Context(), "oyunun mevcut fiyatı okunamadı", "id", id, "error", err)
+			return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Oyunun mevcut fiyatı okunamadı"})
+		}
+		if !found {
+			return nil // bulunamayan oyunu handler bildirir
+		}
+		if !samePrice(current, game.Price) {
+			return a.deny(c, principal, PermGamePrice, "price")
+		}
+		return nil
+	})
+}
+
+// PatchGame PATCH /api/game/:id için alan bazında izin kontrolü yapar:
+// fiyat alanları game:price, diğer alanlar game:update ister. Boş gövde game:update ister; böylece hiçbir istek izin
+// kontrolü yapılmadan geçmez ve yalnızca fiyat yöneticisi olanlar fiyat alanlarını güncelleyebilir. bundle_items ve
+// kind=bundle paket fiyatını yeniden hesaplattığından game:update ile birlikte game:price da ister.
+func (a *Authorizer) PatchGame() echo.MiddlewareFunc {
+	return a.withBody("", func(c echo.Context, principal Principal, body []byte) error {
+		var updates map[string]interface{}
+		if err := json.Unmarshal(body, &updates); err != nil {
+			return invalidBody(c, err)
+		}
+		if len(updates) == 0 && !principal.Can(PermGameUpdate) {
+			return a.deny(c, principal, PermGameUpdate, "")
+		}
+		for field, value := range updates {
+			for _, perm := range patchPermissions(field, value) {
+				if !principal.Can(perm) {
+					return a.deny(c, principal, perm, field)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// patchPermissions PATCH gövdesindeki bir alanın gerektirdiği izinleri döndürür
+func patchPermissions(field string, value interface{}) []Permission {
+	switch {
+	case field == priceField || strings.HasPrefix(field, priceField+"."):
+		return []Permission{PermGamePrice}
+	case field == bundleItemsField, field == kindField && value == models.GameKindBundle:
+		return []Permission{PermGameUpdate, PermGamePrice}
+	}
+	return []Permission{PermGameUpdate}
+}
+
+// TransitionGame POST /api/game/:id/status için game:update ister; oyunu removed durumuna geçirmek silme sayıldığından
+// ayrıca game:delete ister
+func (a *Authorizer) TransitionGame() echo.MiddlewareFunc {
+	return a.withBody(PermGameUpdate, func(c echo.Context, principal Principal, body []byte) error {
+		var req struct {
+			Status string `json:"status"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil {
+			return invalidBody(c, err)
+		}
+		if req.Status == models.GameStatusRemoved && !principal.Can(PermGameDelete) {
+			return a.deny(c, principal, PermGameDelete, "status")
+		}
+		return nil
+	})
+}
+
+// withBody isteğin gövdesini okuyup check'e verir ve handler'ın tekrar okuyabilmesi için geri koyar. Alan bazındaki
+// kontroller JSON gövdeye göre yapıldığından başka içerik türleri (ör. form) 415 ile reddedilir; aksi halde handler'ın
+// Bind'ı formu okurken kontroller boş gövde görürdü.
+func (a *Authorizer) withBody(perm Permission, check func(c echo.Context, principal Principal, body []byte) error) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal, ok := FromEcho(c)
+			if !ok {
+				return unauthorized(c)
+			}
+			if perm != "" && !principal.Can(perm) {
+				return a.deny(c, principal, perm, "")
+			}
+			if mediaType, _, err := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType)); err != nil || mediaType != echo.MIMEApplicationJSON {
+				return c.JSON(http.StatusUnsupportedMediaType, map[string]interface{}{"error": "İstek gövdesi application/json olmalıdır"})
+			}
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "İstek gövdesi okunamadı: " + err.Error()})
+			}
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+			if err := check(c, principal, body); err != nil || c.Response().Committed {
+				return err
+			}
+			return next(c)
+		}
+	}
+}
+
+// deny isteği 403 ile reddeder ve loglar
+func (a *Authorizer) deny(c echo.Context, principal Principal, perm Permission, field string) error {
+	a.Log.WarnContext(c.Request().Context(), "yetkisiz işlem reddedildi",
+		"subject", principal.Subject,
+		"kind", principal.Kind,
+		"roles", principal.Roles,
+		"permission", perm,
+		"field", field,
+		"method", c.Request().Method,
+		"route", c.Path(),
+	)
+	message := "Bu işlem için yetkiniz yok: " + string(perm)
+	if field != "" {
+		message += " (" + field + " alanı)"
+	}
+	return c.JSON(http.StatusForbidden, map[string]interface{}{"error": message})
+}
+
+// invalidBody JSON olarak okunamayan gövdeyi 400 ile reddeder
+func invalidBody(c echo.Context, err error) error {
+	return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Geçersiz istek formatı: " + err.Error()})
+}
+
+func unauthorized(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer realm="gamer-hub-api"`)
+	return c.JSON(http.StatusUnauthorized, map[string]interface{}{"error": "Bu işlem için kimlik doğrulaması gereklidir"})
+}
+
+// samePrice iki fiyatın aynı olup olmadığını döndürür (tarih karşılaştırması saat diliminden bağımsızdır)
+func samePrice(a, b models.Price) bool {
+	return a.Amount == b.Amount && a.Currency == b.Currency && a.Discount == b.Discount &&
+		a.OnSale == b.OnSale && a.SaleEndDate.Equal(b.SaleEndDate)
+}
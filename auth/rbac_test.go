@@ -0,0 +1,174 @@
+package auth
+
+import (
+	"api-steam/models"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// runBodyCheck middleware'i verilen rol, içerik türü ve gövdeyle çalıştırır; handler'a ulaşılırsa 200 döner
+func runBodyCheck(t *testing.T, mw echo.MiddlewareFunc, roles []string, contentType, body string) int {
+	t.Helper()
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPatch, "/api/game/"+primitive.NewObjectID().Hex(), strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set(echo.HeaderContentType, contentType)
+	}
+	if roles != nil {
+		req = req.WithContext(WithPrincipal(req.Context(), Principal{Subject: "u1", Kind: KindUser, Roles: roles}))
+	}
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strings.TrimPrefix(req.URL.Path, "/api/game/"))
+	handler := mw(func(c echo.Context) error {
+		// handler gövdeyi yeniden okuyabilmeli
+		if got, _ := io.ReadAll(c.Request().Body); string(got) != body {
+			t.Errorf("handler gövdesi = %q, beklenen %q", got, body)
+		}
+		return c.NoContent(http.StatusOK)
+	})
+	if err := handler(c); err != nil {
+		t.Fatalf("middleware hata döndü: %v", err)
+	}
+	return rec.Code
+}
+
+func newTestAuthorizer() *Authorizer {
+	return NewAuthorizer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestPatchGame(t *testing.T) {
+	viewer, editor, pricing := []string{RoleViewer}, []string{RoleEditor}, []string{RolePricingManager}
+	tests := []struct {
+		name        string
+		roles       []string
+		contentType string
+		body        string
+		want        int
+	}{
+		{"anonim", nil, echo.MIMEApplicationJSON, `{"title":"x"}`, http.StatusUnauthorized},
+		{"form gövdesi reddedilir", viewer, echo.MIMEApplicationForm, "price.amount=0&title=pwned", http.StatusUnsupportedMediaType},
+		{"içerik türü yok", editor, "", `{"title":"x"}`, http.StatusUnsupportedMediaType},
+		{"okunamayan JSON", editor, echo.MIMEApplicationJSON, `{"title":`, http.StatusBadRequest},
+		{"dizi gövde", editor, echo.MIMEApplicationJSON, `[]`, http.StatusBadRequest},
+		{"izleyici boş gövde", viewer, echo.MIMEApplicationJSON, `{}`, http.StatusForbidden},
+		{"izleyici başlık", viewer, echo.MIMEApplicationJSON, `{"title":"pwned"}`, http.StatusForbidden},
+		{"editör başlık", editor, echo.MIMEApplicationJSON, `{"title":"x"}`, http.StatusOK},
+		{"editör charset ile", editor, echo.MIMEApplicationJSONCharsetUTF8, `{"title":"x"}`, http.StatusOK},
+		{"editör boş gövde", editor, echo.MIMEApplicationJSON, `{}`, http.StatusOK},
+		{"editör fiyat", editor, echo.MIMEApplicationJSON, `{"price.amount":0}`, http.StatusForbidden},
+		{"editör iç içe fiyat", editor, echo.MIMEApplicationJSON, `{"price":{"amount":0}}`, http.StatusForbidden},
+		{"fiyat yöneticisi fiyat", pricing, echo.MIMEApplicationJSON, `{"price.amount":9.99}`, http.StatusOK},
+		{"fiyat yöneticisi başlık", pricing, echo.MIMEApplicationJSON, `{"price.amount":9.99,"title":"x"}`, http.StatusForbidden},
+		{"fiyat yöneticisi boş gövde", pricing, echo.MIMEApplicationJSON, `{}`, http.StatusForbidden},
+		{"editör paket içeriği", editor, echo.MIMEApplicationJSON, `{"bundle_items":["a","b"]}`, http.StatusForbidden},
+		{"editör pakete çevirme", editor, echo.MIMEApplicationJSON, `{"kind":"bundle"}`, http.StatusForbidden},
+		{"editör DLC'ye çevirme", editor, echo.MIMEApplicationJSON, `{"kind":"dlc"}`, http.StatusOK},
+		{"fiyat yöneticisi paket içeriği", pricing, echo.MIMEApplicationJSON, `{"bundle_items":["a","b"]}`, http.StatusForbidden},
+		{"editör ve fiyat yöneticisi paket içeriği", []string{RoleEditor, RolePricingManager}, echo.MIMEApplicationJSON, `{"kind":"bundle","bundle_items":["a","b"]}`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runBodyCheck(t, newTestAuthorizer().PatchGame(), tt.roles, tt.contentType, tt.body); got != tt.want {
+				t.Errorf("durum kodu = %d, beklenen %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateGame(t *testing.T) {
+	tests := []struct {
+		name        string
+		roles       []string
+		contentType string
+		body        string
+		want        int
+	}{
+		{"izleyici", []string{RoleViewer}, echo.MIMEApplicationJSON, `{"title":"x"}`, http.StatusForbidden},
+		{"editör fiyatsız", []string{RoleEditor}, echo.MIMEApplicationJSON, `{"title":"x"}`, http.StatusOK},
+		{"editör fiyatlı", []string{RoleEditor}, echo.MIMEApplicationJSON, `{"title":"x","price":{"amount":10}}`, http.StatusForbidden},
+		{"editör para birimi", []string{RoleEditor}, echo.MIMEApplicationJSON, `{"title":"x","price":{"currency":"USD"}}`, http.StatusForbidden},
+		{"editör paket", []string{RoleEditor}, echo.MIMEApplicationJSON, `{"title":"x","kind":"bundle","bundle_items":["64b000000000000000000001","64b000000000000000000002"]}`, http.StatusForbidden},
+		{"editör DLC", []string{RoleEditor}, echo.MIMEApplicationJSON, `{"title":"x","kind":"dlc"}`, http.StatusOK},
+		{"editör form ile fiyatlı", []string{RoleEditor}, echo.MIMEApplicationForm, "title=x&price.amount=10", http.StatusUnsupportedMediaType},
+		{"editör okunamayan JSON", []string{RoleEditor}, echo.MIMEApplicationJSON, `{"price":"x"}`, http.StatusBadRequest},
+		{"yönetici fiyatlı", []string{RoleAdmin}, echo.MIMEApplicationJSON, `{"title":"x","price":{"amount":10}}`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runBodyCheck(t, newTestAuthorizer().CreateGame(), tt.roles, tt.contentType, tt.body); got != tt.want {
+				t.Errorf("durum kodu = %d, beklenen %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplaceGame(t *testing.T) {
+	current := models.Price{Amount: 10, Currency: "TRY"}
+	found := func(context.Context, primitive.ObjectID) (models.Price, bool, error) { return current, true, nil }
+	missing := func(context.Context, primitive.ObjectID) (models.Price, bool, error) {
+		return models.Price{}, false, nil
+	}
+	failing := func(context.Context, primitive.ObjectID) (models.Price, bool, error) {
+		return models.Price{}, false, errors.New("bağlantı koptu")
+	}
+	tests := []struct {
+		name        string
+		roles       []string
+		lookup      PriceLookup
+		contentType string
+		body        string
+		want        int
+	}{
+		{"editör aynı fiyat", []string{RoleEditor}, found, echo.MIMEApplicationJSON, `{"title":"x","price":{"amount":10,"currency":"TRY"}}`, http.StatusOK},
+		{"editör farklı fiyat", []string{RoleEditor}, found, echo.MIMEApplicationJSON, `{"title":"x","price":{"amount":0,"currency":"TRY"}}`, http.StatusForbidden},
+		{"editör form", []string{RoleEditor}, found, echo.MIMEApplicationForm, "title=x&price.amount=0", http.StatusUnsupportedMediaType},
+		{"editör okunamayan JSON", []string{RoleEditor}, found, echo.MIMEApplicationJSON, `not json`, http.StatusBadRequest},
+		{"editör paket", []string{RoleEditor}, found, echo.MIMEApplicationJSON, `{"title":"x","kind":"bundle","price":{"amount":10,"currency":"TRY"}}`, http.StatusForbidden},
+		{"editör fiyat okunamadı", []string{RoleEditor}, failing, echo.MIMEApplicationJSON, `{"title":"x"}`, http.StatusInternalServerError},
+		{"editör oyun yok", []string{RoleEditor}, missing, echo.MIMEApplicationJSON, `{"title":"x"}`, http.StatusOK},
+		{"fiyat yöneticisi", []string{RolePricingManager}, found, echo.MIMEApplicationJSON, `{"title":"x"}`, http.StatusForbidden},
+		{"yönetici farklı fiyat", []string{RoleAdmin}, found, echo.MIMEApplicationJSON, `{"price":{"amount":0}}`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runBodyCheck(t, newTestAuthorizer().ReplaceGame(tt.lookup), tt.roles, tt.contentType, tt.body); got != tt.want {
+				t.Errorf("durum kodu = %d, beklenen %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransitionGame(t *testing.T) {
+	tests := []struct {
+		name        string
+		roles       []string
+		contentType string
+		body        string
+		want        int
+	}{
+		{"editör aktif", []string{RoleEditor}, echo.MIMEApplicationJSON, `{"status":"active"}`, http.StatusOK},
+		{"editör kaldırma", []string{RoleEditor}, echo.MIMEApplicationJSON, `{"status":"removed","reason":"x"}`, http.StatusForbidden},
+		{"editör form ile kaldırma", []string{RoleEditor}, echo.MIMEApplicationForm, "status=removed&reason=x", http.StatusUnsupportedMediaType},
+		{"editör okunamayan JSON", []string{RoleEditor}, echo.MIMEApplicationJSON, `{"status":1}`, http.StatusBadRequest},
+		{"yönetici kaldırma", []string{RoleAdmin}, echo.MIMEApplicationJSON, `{"status":"removed","reason":"x"}`, http.StatusOK},
+		{"izleyici", []string{RoleViewer}, echo.MIMEApplicationJSON, `{"status":"active"}`, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runBodyCheck(t, newTestAuthorizer().TransitionGame(), tt.roles, tt.contentType, tt.body); got != tt.want {
+				t.Errorf("durum kodu = %d, beklenen %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,29 +1,352 @@
 package configs
 
 import (
-	"fmt"
-	"log"
+	"log/slog"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
-func EnvMongoURI() string {
-	err := godotenv.Load()
-	/*çalıştığı dizindeki .env dosyasını arar içindeki ortam dğişkenlerini mevcut ortamda kulanılablir hale getirir*/
-	/* .env dosyası
-	   uygulamanın yapılandırma ayarlarını ve hassas bilgilerinin saklandığı dosyadır
-	*/
+var loadEnvOnce sync.Once
+
+// loadEnv .env dosyasını uygulama ömrü boyunca yalnızca bir kez yükler
+func loadEnv() {
+	loadEnvOnce.Do(func() {
+		err := godotenv.Load()
+		/*çalıştığı dizindeki .env dosyasını arar içindeki ortam dğişkenlerini mevcut ortamda kulanılablir hale getirir*/
+		/* .env dosyası
+		   uygulamanın yapılandırma ayarlarını ve hassas bilgilerinin saklandığı dosyadır
+		*/
+		if err != nil {
+			slog.Warn(".env dosyası bulunamadı, ortam değişkenleri kullanılıyor")
+		}
+	})
+}
+
+// getEnv ortam değişkenini okur, tanımlı değilse varsayılan değeri döndürür
+func getEnv(key, fallback string) string {
+	loadEnv()
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// getEnvDuration ortam değişkenini süre olarak okur (ör. "15s", "1m"), geçersizse varsayılanı döndürür
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value := getEnv(key, "")
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
 	if err != nil {
-		log.Println("Warning: .env file not found, using environment variables")
+		slog.Warn("geçersiz süre, varsayılan kullanılıyor", "key", key, "value", value, "default", fallback.String())
+		return fallback
 	}
+	return d
+}
+
+// getEnvInterval periyodik işlerin aralığını okur; time.NewTicker sıfır ya da negatif süreyle panik verdiği için
+// pozitif olmayan değerler de varsayılanla değiştirilir
+func getEnvInterval(key string, fallback time.Duration) time.Duration {
+	d := getEnvDuration(key, fallback)
+	if d <= 0 {
+		slog.Warn("aralık pozitif olmalıdır, varsayılan kullanılıyor", "key", key, "value", d.String(), "default", fallback.String())
+		return fallback
+	}
+	return d
+}
 
-	mongoURI := os.Getenv("MONGOURI")
+func EnvMongoURI() string {
+	mongoURI := getEnv("MONGOURI", "")
 	if mongoURI == "" {
-		log.Println("MONGOURI environment variable is not set, using default connection string")
+		slog.Warn("MONGOURI tanımlı değil, varsayılan bağlantı adresi kullanılıyor")
 		mongoURI = "mongodb://localhost:27017/GameApi"
 	}
 
-	fmt.Println(mongoURI)
 	return mongoURI
 }
+
+// EnvServerAddr sunucunun dinleyeceği adresi döndürür (varsayılan :8080)
+func EnvServerAddr() string {
+	return getEnv("SERVER_ADDR", ":8080")
+}
+
+// EnvShutdownTimeout kapanışta devam eden isteklerin tamamlanması için beklenecek süreyi döndürür
+func EnvShutdownTimeout() time.Duration {
+	return getEnvDuration("SHUTDOWN_TIMEOUT", 15*time.Second)
+}
+
+// EnvReadinessTimeout /readyz kontrolünde MongoDB ping'i için beklenecek en uzun süreyi döndürür
+func EnvReadinessTimeout() time.Duration {
+	return getEnvDuration("READINESS_TIMEOUT", 2*time.Second)
+}
+
+// EnvMetricsRefreshInterval katalog iş metriklerinin (durum, indirim sayıları) yenilenme aralığını döndürür
+func EnvMetricsRefreshInterval() time.Duration {
+	return getEnvInterval("METRICS_REFRESH_INTERVAL", 30*time.Second)
+}
+
+// EnvTracesExporter trace'lerin nereye gönderileceğini döndürür: otlp, stdout veya none
+func EnvTracesExporter() string {
+	return getEnv("OTEL_TRACES_EXPORTER", "otlp")
+}
+
+// EnvLogLevel varsayılan log seviyesini döndürür: debug, info, warn veya error
+func EnvLogLevel() string {
+	return getEnv("LOG_LEVEL", "info")
+}
+
+// EnvLogLevels paket bazında log seviyelerini döndürür; LOG_LEVELS="repository=debug,services=warn" biçimindedir
+func EnvLogLevels() map[string]string {
+	levels := map[string]string{}
+	for _, pair := range strings.Split(getEnv("LOG_LEVELS", ""), ",") {
+		name, level, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if ok && name != "" {
+			levels[strings.TrimSpace(name)] = strings.TrimSpace(level)
+		}
+	}
+	return levels
+}
+
+// EnvLogFormat log çıktısının biçimini döndürür: json (varsayılan) veya text
+func EnvLogFormat() string {
+	return getEnv("LOG_FORMAT", "json")
+}
+
+// EnvJWTSecret HS256 token'larını doğrulamak için paylaşılan sırrı döndürür (boşsa HS256 kapalıdır)
+func EnvJWTSecret() string {
+	return getEnv("JWT_HS256_SECRET", "")
+}
+
+// EnvJWKSFile RS256 token'ları için açık anahtarları içeren yerel JWKS dosyasının yolunu döndürür
+func EnvJWKSFile() string {
+	return getEnv("JWT_JWKS_FILE", "")
+}
+
+// EnvJWTIssuer token'larda beklenen iss değerini döndürür (boşsa kontrol edilmez)
+func EnvJWTIssuer() string {
+	return getEnv("JWT_ISSUER", "")
+}
+
+// EnvJWTAudience token'larda beklenen aud değerini döndürür (boşsa kontrol edilmez)
+func EnvJWTAudience() string {
+	return getEnv("JWT_AUDIENCE", "")
+}
+
+// EnvAccessTokenTTL erişim token'larının geçerlilik süresini döndürür
+func EnvAccessTokenTTL() time.Duration {
+	return getEnvDuration("ACCESS_TOKEN_TTL", 15*time.Minute)
+}
+
+// EnvRefreshTokenTTL yenileme token'larının geçerlilik süresini döndürür
+func EnvRefreshTokenTTL() time.Duration {
+	return getEnvDuration("REFRESH_TOKEN_TTL", 30*24*time.Hour)
+}
+
+// EnvPasswordResetTTL şifre sıfırlama bağlantısının geçerlilik süresini döndürür
+func EnvPasswordResetTTL() time.Duration {
+	return getEnvDuration("PASSWORD_RESET_TTL", time.Hour)
+}
+
+// EnvPublicBaseURL e-postalardaki bağlantılarda kullanılan storefront adresini döndürür
+func EnvPublicBaseURL() string {
+	return getEnv("PUBLIC_BASE_URL", "http://localhost:3000")
+}
+
+// EnvMailDriver e-posta sürücüsünü döndürür: smtp, file veya log
+func EnvMailDriver() string {
+	return getEnv("MAIL_DRIVER", "log")
+}
+
+// EnvMailFrom gönderen adresini döndürür
+func EnvMailFrom() string {
+	return getEnv("MAIL_FROM", "no-reply@gamerhub.local")
+}
+
+// EnvSMTPAddr SMTP sunucusunun host:port adresini döndürür
+func EnvSMTPAddr() string {
+	return getEnv("SMTP_ADDR", "localhost:1025")
+}
+
+// EnvSMTPUser SMTP kullanıcı adını döndürür (boşsa kimlik doğrulama yapılmaz)
+func EnvSMTPUser() string {
+	return getEnv("SMTP_USER", "")
+}
+
+// EnvSMTPPassword SMTP şifresini döndürür
+func EnvSMTPPassword() string {
+	return getEnv("SMTP_PASSWORD", "")
+}
+
+// EnvMailDir file sürücüsünde e-postaların yazılacağı klasörü döndürür
+func EnvMailDir() string {
+	return getEnv("MAIL_DIR", "./tmp/mail")
+}
+
+// EnvRateLimitBackend istek sınırı kovalarının nerede tutulacağını döndürür: memory (tek instance) veya mongo (çoklu instance)
+func EnvRateLimitBackend() string {
+	return getEnv("RATE_LIMIT_BACKEND", "memory")
+}
+
+// EnvTrustedProxies X-Forwarded-For başlığına güvenilecek ters proxy'lerin virgülle ayrılmış IP ya da CIDR listesini
+// döndürür; boşsa istemci IP'si doğrudan bağlantının adresidir ve başlıklar yok sayılır
+func EnvTrustedProxies() []string {
+	var proxies []string
+	for _, p := range strings.Split(getEnv("TRUSTED_PROXIES", ""), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			proxies = append(proxies, p)
+		}
+	}
+	return proxies
+}
+
+// EnvRateLimitRead okuma (GET) route'ları için istemci başına bütçeyi "<limit>/<süre>" biçiminde döndürür
+func EnvRateLimitRead() string {
+	return getEnv("RATE_LIMIT_READ", "300/1m")
+}
+
+// EnvRateLimitWrite yazma (POST/PUT/PATCH/DELETE) route'ları için istemci başına bütçeyi döndürür
+func EnvRateLimitWrite() string {
+	return getEnv("RATE_LIMIT_WRITE", "60/1m")
+}
+
+// EnvRateLimitBulk toplu işlem route'ları için istemci başına bütçeyi döndürür
+func EnvRateLimitBulk() string {
+	return getEnv("RATE_LIMIT_BULK", "5/1m")
+}
+
+// EnvWebhookSecret webhook bildirimlerini imzalamak için kullanılan sırrı döndürür (boşsa imza başlığı eklenmez)
+func EnvWebhookSecret() string {
+	return getEnv("WEBHOOK_SIGNING_SECRET", "")
+}
+
+// EnvWebhookTimeout bir webhook isteği için beklenecek en uzun süreyi döndürür
+func EnvWebhookTimeout() time.Duration {
+	return getEnvDuration("WEBHOOK_TIMEOUT", 5*time.Second)
+}
+
+// getEnvInt ortam değişkenini tam sayı olarak okur, geçersizse varsayılanı döndürür
+func getEnvInt(key string, fallback int) int {
+	value := getEnv(key, "")
+	if value == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		slog.Warn("geçersiz sayı, varsayılan kullanılıyor", "key", key, "value", value, "default", fallback)
+		return fallback
+	}
+	return n
+}
+
+// getEnvBool ortam değişkenini true/false olarak okur, geçersizse varsayılanı döndürür
+func getEnvBool(key string, fallback bool) bool {
+	value := getEnv(key, "")
+	if value == "" {
+		return fallback
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		slog.Warn("geçersiz mantıksal değer, varsayılan kullanılıyor", "key", key, "value", value, "default", fallback)
+		return fallback
+	}
+	return b
+}
+
+// EnvModerationWordListFile yasaklı kelime/ifade listesini içeren dosyanın yolunu döndürür (her satırda bir giriş)
+func EnvModerationWordListFile() string {
+	return getEnv("MODERATION_WORDLIST_FILE", "")
+}
+
+// EnvModerationWords dosyaya ek olarak virgülle ayrılmış yasaklı kelimeleri döndürür
+func EnvModerationWords() []string {
+	var words []string
+	for _, w := range strings.Split(getEnv("MODERATION_WORDS", ""), ",") {
+		if w = strings.TrimSpace(w); w != "" {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+// EnvModerationBlockLinks bağlantı içeren yorumların onaya düşüp düşmeyeceğini döndürür
+func EnvModerationBlockLinks() bool {
+	return getEnvBool("MODERATION_BLOCK_LINKS", true)
+}
+
+// EnvModerationReportThreshold yayındaki bir yorumun kaç şikayetten sonra otomatik olarak kuyruğa alınacağını döndürür
+func EnvModerationReportThreshold() int {
+	return getEnvInt("MODERATION_REPORT_THRESHOLD", 3)
+}
+
+// EnvPlaytimeRefreshInterval oyunların ortalama/medyan oynama sürelerinin yeniden hesaplanma aralığını döndürür
+func EnvPlaytimeRefreshInterval() time.Duration {
+	return getEnvInterval("PLAYTIME_REFRESH_INTERVAL", time.Hour)
+}
+
+// EnvPlaytimeMaxSession kabul edilen en uzun oynama oturumunu döndürür; daha uzun oturumlar reddedilir
+func EnvPlaytimeMaxSession() time.Duration {
+	return getEnvDuration("PLAYTIME_MAX_SESSION", 24*time.Hour)
+}
+
+// EnvSimilarTopK her oyun için saklanan ve döndürülen en fazla benzer oyun sayısını döndürür
+func EnvSimilarTopK() int {
+	return getEnvInt("SIMILAR_TOP_K", 10)
+}
+
+// EnvSimilarRefreshInterval benzer oyunların yeniden hesaplanma aralığını döndürür
+func EnvSimilarRefreshInterval() time.Duration {
+	return getEnvInterval("SIMILAR_REFRESH_INTERVAL", 6*time.Hour)
+}
+
+// EnvChartRefreshInterval vitrin grafiklerinin yeniden hesaplanma aralığını döndürür
+func EnvChartRefreshInterval() time.Duration {
+	return getEnvInterval("CHART_REFRESH_INTERVAL", 15*time.Minute)
+}
+
+// EnvChartSize her grafikte tutulan en fazla oyun sayısını döndürür
+func EnvChartSize() int {
+	return getEnvInt("CHART_SIZE", 50)
+}
+
+// EnvChartMinReviews en yüksek puanlılar grafiğinde Bayes ortalamasının güvendiği yorum sayısını döndürür; daha az yorumlu oyunların puanı genel ortalamaya çekilir
+func EnvChartMinReviews() int {
+	return getEnvInt("CHART_MIN_REVIEWS", 10)
+}
+
+// EnvDraftPublishInterval zamanlanmış taslak yayınlarının kontrol edilme aralığını döndürür
+func EnvDraftPublishInterval() time.Duration {
+	return getEnvInterval("DRAFT_PUBLISH_INTERVAL", time.Minute)
+}
+
+// EnvHardwareTiersFile yerleşik donanım tablosuna eklenecek işlemci/ekran kartı seviyelerini içeren dosyanın yolunu
+// döndürür (her satırda "tür|seviye|ad|ifadeler")
+func EnvHardwareTiersFile() string {
+	return getEnv("HARDWARE_TIERS_FILE", "")
+}
+
+// EnvRequirementsReparseInterval sistem gereksinimlerinin donanım tablosuyla yeniden okunma aralığını döndürür
+func EnvRequirementsReparseInterval() time.Duration {
+	return getEnvInterval("REQUIREMENTS_REPARSE_INTERVAL", 24*time.Hour)
+}
+
+// EnvDefaultLocale oyunların kendi başlık ve açıklama alanlarının dilini döndürür; çevirisi olmayan metinler bu dilde gösterilir
+func EnvDefaultLocale() string {
+	return getEnv("DEFAULT_LOCALE", "tr")
+}
+
+// EnvSupportedLocales mağazanın desteklediği dilleri virgülle ayrılmış listeden döndürür (varsayılan dil dahil)
+func EnvSupportedLocales() []string {
+	var locales []string
+	for _, l := range strings.Split(getEnv("SUPPORTED_LOCALES", "tr,en"), ",") {
+		if l = strings.TrimSpace(l); l != "" {
+			locales = append(locales, l)
+		}
+	}
+	return locales
+}
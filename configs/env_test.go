@@ -0,0 +1,28 @@
+package configs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetEnvInterval(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", time.Minute},
+		{"30s", 30 * time.Second},
+		{"0s", time.Minute},
+		{"0", time.Minute},
+		{"-5m", time.Minute},
+		{"abc", time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("TEST_REFRESH_INTERVAL", tt.value)
+			if got := getEnvInterval("TEST_REFRESH_INTERVAL", time.Minute); got != tt.want {
+				t.Errorf("aralık = %v, beklenen %v", got, tt.want)
+			}
+		})
+	}
+}
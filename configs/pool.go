@@ -0,0 +1,52 @@
+package configs
+
+import (
+	"sync/atomic"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// PoolStats MongoDB bağlantı havuzunun anlık sayaçlarıdır
+type PoolStats struct {
+	Created       int64 `json:"created"`        // Açılan toplam bağlantı
+	Closed        int64 `json:"closed"`         // Kapanan toplam bağlantı
+	Open          int64 `json:"open"`           // Şu an açık bağlantı
+	InUse         int64 `json:"in_use"`         // Şu an bir işlem tarafından kullanılan bağlantı
+	CheckOutFails int64 `json:"checkout_fails"` // Havuzdan bağlantı alınamayan istek sayısı
+}
+
+var poolCounters struct {
+	created, closed, checkedOut, checkedIn, checkOutFails atomic.Int64
+}
+
+// poolMonitor sürücünün havuz olaylarını sayaçlara işler
+func poolMonitor() *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(e *event.PoolEvent) {
+			switch e.Type {
+			case event.ConnectionCreated:
+				poolCounters.created.Add(1)
+			case event.ConnectionClosed:
+				poolCounters.closed.Add(1)
+			case event.GetSucceeded:
+				poolCounters.checkedOut.Add(1)
+			case event.GetFailed:
+				poolCounters.checkOutFails.Add(1)
+			case event.ConnectionReturned:
+				poolCounters.checkedIn.Add(1)
+			}
+		},
+	}
+}
+
+// GetPoolStats bağlantı havuzu sayaçlarının anlık görüntüsünü döndürür
+func GetPoolStats() PoolStats {
+	created, closed := poolCounters.created.Load(), poolCounters.closed.Load()
+	return PoolStats{
+		Created:       created,
+		Closed:        closed,
+		Open:          created - closed,
+		InUse:         poolCounters.checkedOut.Load() - poolCounters.checkedIn.Load(),
+		CheckOutFails: poolCounters.checkOutFails.Load(),
+	}
+}
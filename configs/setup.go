@@ -2,11 +2,13 @@ package configs
 
 import (
 	"context"
-	"log"
+	"log/slog"
+	"os"
 	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
 )
 
 func ConnectDB() *mongo.Client {
@@ -17,16 +19,21 @@ func ConnectDB() *mongo.Client {
 	   Çalışması için bulunduğu fonksiyonu sonuna kadar erteler yani deffer ile çağırıln fonksiyonun sonuna kadar erteler
 	*/
 	// Tek adımda MongoDB bağlantısı kurma (önerilen yaklaşım)
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(EnvMongoURI()))
+	clientOpts := options.Client().ApplyURI(EnvMongoURI()).
+		SetPoolMonitor(poolMonitor()).     // havuz istatistikleri /status için tutulur
+		SetMonitor(otelmongo.NewMonitor()) // her MongoDB komutu için bir span açılır
+	client, err := mongo.Connect(ctx, clientOpts)
 	if err != nil {
-		log.Fatalf("MongoDB'ye bağlanılamadı: %v", err)
+		slog.Error("MongoDB'ye bağlanılamadı", "error", err)
+		os.Exit(1)
 	}
 	//*client.pig ile  MongoDB bağlantısının  çalışıp çalışmadığını kontrol ederiz*/
 	err = client.Ping(ctx, nil)
 	if err != nil {
-		log.Fatalf("MongoDB ping testi başarısız: %v", err)
+		slog.Error("MongoDB ping testi başarısız", "error", err)
+		os.Exit(1)
 	}
-	log.Println("MongoDB bağlantısı başarıyla kuruldu")
+	slog.Info("MongoDB bağlantısı başarıyla kuruldu")
 
 	// Veritabanı ve koleksiyonu başlangıçta oluştur fonksiyonu yorum satırına alındı
 	// initDatabase(client)
@@ -35,11 +42,30 @@ func ConnectDB() *mongo.Client {
 }
 
 // *NewClient ile yapılandırdığımız  bağlantıyı Connect ile başlatık ctx değişkeni ile zaman aşımı ayarladık*/
-var DB *mongo.Client = ConnectDB()
+// DB main içinde ConnectDB ile kurulur; paket yüklenirken bağlanılmadığı için configs'i içe aktaran paketler
+// veritabanı olmadan test edilebilir
+var DB *mongo.Client
+
+// DisconnectDB MongoDB bağlantı havuzunu kapatır, ctx süresi dolana kadar açık bağlantıların bitmesini bekler
+func DisconnectDB(ctx context.Context) error {
+	if err := DB.Disconnect(ctx); err != nil {
+		return err
+	}
+	slog.Info("MongoDB bağlantısı kapatıldı")
+	return nil
+}
+
+// DatabaseName uygulamanın kullandığı MongoDB veritabanının adıdır
+const DatabaseName = "GameApi"
+
+// GetDatabase uygulama veritabanını döndürür
+func GetDatabase(client *mongo.Client) *mongo.Database {
+	return client.Database(DatabaseName)
+}
 
 // GetCollection belirtilen koleksiyonu döndürür
 func GetCollection(client *mongo.Client, collectionName string) *mongo.Collection {
-	return client.Database("GameApi").Collection(collectionName)
+	return GetDatabase(client).Collection(collectionName)
 	// client.Database() veritabanına erişim sağlar
 	// .Collection() belirtilen koleksiyona erişim sağlar (SQL'deki tablo benzeri yapı)
 }
@@ -0,0 +1,9 @@
+package configs
+
+import "time"
+
+// Version derleme sırasında -ldflags "-X api-steam/configs.Version=v1.2.3" ile atanır
+var Version = "dev"
+
+// StartedAt sürecin başladığı zamandır, uptime hesabında kullanılır
+var StartedAt = time.Now()
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"api-steam/models"
+	"time"
+)
+
+// AchievementRequest başarım tanımı oluşturma/güncelleme isteğinin gövdesidir; güncellemede key yok sayılır
+type AchievementRequest struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	IconURL     string `json:"icon_url,omitempty"`
+	Hidden      bool   `json:"hidden"`
+	Points      int    `json:"points"`
+}
+
+// AchievementUnlockRequest istemcinin bildirdiği başarım açılışıdır
+type AchievementUnlockRequest struct {
+	UserID     string     `json:"user_id"`
+	GameID     string     `json:"game_id"`
+	Key        string     `json:"key"`
+	UnlockedAt *time.Time `json:"unlocked_at,omitempty"` // Gönderilmezse şimdiki zaman
+}
+
+// AchievementStatus kullanıcının bir başarımdaki durumudur
+type AchievementStatus struct {
+	models.Achievement
+	UnlockedAt *time.Time `json:"unlocked_at,omitempty"` // Açılmadıysa boş
+}
+
+// AchievementProgress kullanıcının bir oyundaki başarım ilerlemesidir
+type AchievementProgress struct {
+	GameID       string              `json:"game_id"`
+	Unlocked     int                 `json:"unlocked"`
+	Total        int                 `json:"total"`
+	Points       int                 `json:"points"`       // Açılan başarımların puanı
+	TotalPoints  int                 `json:"total_points"` // Oyundaki toplam puan
+	Achievements []AchievementStatus `json:"achievements"`
+}
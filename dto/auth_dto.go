@@ -0,0 +1,15 @@
+package dto
+
+import "api-steam/models"
+
+// CreateAPIKeyRequest yeni API anahtarı isteğinin gövdesidir
+type CreateAPIKeyRequest struct {
+	Name  string   `json:"name"`
+	Roles []string `json:"roles,omitempty"`
+}
+
+// CreatedAPIKeyDTO yeni oluşturulan anahtarı döner; Key yalnızca bu yanıtta gösterilir
+type CreatedAPIKeyDTO struct {
+	Key    string        `json:"key"`
+	APIKey models.APIKey `json:"api_key"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import (
+	"api-steam/models"
+	"time"
+)
+
+// DraftRejectRequest incelemedeki taslağı düzenlemeye geri gönderme isteğidir; gerekçe zorunludur
+type DraftRejectRequest struct {
+	Reason string `json:"reason"`
+}
+
+// DraftPublishRequest onaylanmış taslağın yayın isteğidir
+type DraftPublishRequest struct {
+	PublishAt *time.Time `json:"publish_at,omitempty"` // Gelecekteyse taslak zamanlanır; boşsa hemen yayınlanır
+	Force     bool       `json:"force,omitempty"`      // Canlı oyun taslak oluşturulduktan sonra değiştiyse bile yayınla
+}
+
+// DraftPublishResult yayın isteğinin sonucudur: ya yayınlanıp revizyon oluşur ya da taslak zamanlanır
+type DraftPublishResult struct {
+	Published bool                 `json:"published"`
+	Revision  *models.GameRevision `json:"revision,omitempty"`
+	Draft     *models.GameDraft    `json:"draft,omitempty"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+import "api-steam/models"
+
+// HardwareSpecRequest kullanıcının bilgisayarıdır; işlemci ve ekran kartı model adıyla yazılır (ör. "Ryzen 5 3600",
+// "GTX 1060"). Arama isteklerinde aynı alanlar sorgu parametresi olarak verilir.
+type HardwareSpecRequest struct {
+	OS        string  `json:"os,omitempty" query:"os"`                 // Windows 11, macOS, Ubuntu vb.
+	CPU       string  `json:"cpu,omitempty" query:"cpu"`               // İşlemci modeli
+	GPU       string  `json:"gpu,omitempty" query:"gpu"`               // Ekran kartı modeli
+	MemoryGB  float64 `json:"memory_gb,omitempty" query:"memory_gb"`   // RAM (GB)
+	StorageGB float64 `json:"storage_gb,omitempty" query:"storage_gb"` // Boş disk alanı (GB)
+	DirectX   int     `json:"directx,omitempty" query:"directx"`       // Desteklenen en yüksek DirectX sürümü
+}
+
+// Empty istekte hiçbir bileşen verilmediğini gösterir
+func (r HardwareSpecRequest) Empty() bool {
+	return r == HardwareSpecRequest{}
+}
+
+// CanIRunResult bilgisayarın oyunun gereksinimlerini karşılayıp karşılamadığıdır
+type CanIRunResult struct {
+	Verdict     string               `json:"verdict"`           // below_minimum, meets_minimum, meets_recommended
+	Failing     []models.Shortfall   `json:"failing"`           // Minimumun altındaysa minimumu, değilse önerileni karşılamayan bileşenler
+	Unknown     []string             `json:"unknown,omitempty"` // Gereksinimi olduğu halde istekte verilmemiş ya da tanınmayan bileşenler
+	Spec        models.HardwareSpec  `json:"spec"`              // İstekteki bilgisayarın tanınan hali
+	Minimum     *models.HardwareSpec `json:"minimum,omitempty"`
+	Recommended *models.HardwareSpec `json:"recommended,omitempty"`
+}
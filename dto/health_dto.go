@@ -0,0 +1,36 @@
+package dto
+
+import "api-steam/configs"
+
+// CheckDTO tek bir bağımlılık kontrolünün sonucudur
+type CheckDTO struct {
+	OK        bool    `json:"ok"`
+	LatencyMs float64 `json:"latency_ms,omitempty"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// ReadinessDTO /readyz yanıtıdır; Ready false ise yük dengeleyici trafiği bu örneğe yönlendirmemelidir
+type ReadinessDTO struct {
+	Ready  bool                `json:"ready"`
+	Checks map[string]CheckDTO `json:"checks"`
+}
+
+// DatabaseStatusDTO /status yanıtındaki veritabanı bölümüdür
+type DatabaseStatusDTO struct {
+	Reachable bool              `json:"reachable"`
+	LatencyMs float64           `json:"latency_ms"`
+	Error     string            `json:"error,omitempty"`
+	Pool      configs.PoolStats `json:"pool"`
+}
+
+// StatusDTO operatörler için ayrıntılı /status yanıtıdır
+type StatusDTO struct {
+	Version           string            `json:"version"`
+	StartedAt         string            `json:"started_at"`
+	Uptime            string            `json:"uptime"`
+	UptimeSeconds     int64             `json:"uptime_seconds"`
+	Database          DatabaseStatusDTO `json:"database"`
+	MigrationsApplied bool              `json:"migrations_applied"`
+	PendingMigrations []string          `json:"pending_migrations,omitempty"`
+	Workers           map[string]bool   `json:"workers"`
+}
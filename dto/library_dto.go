@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+// LibraryGrantRequest bir kullanıcıya oyun sahipliği kaydetme isteğinin gövdesidir (ödeme sistemi veya destek ekibi tarafından)
+type LibraryGrantRequest struct {
+	GameID     string     `json:"game_id"`
+	Source     string     `json:"source"`                // purchase, gift veya key
+	AcquiredAt *time.Time `json:"acquired_at,omitempty"` // Gönderilmezse şimdiki zaman
+	PricePaid  *float64   `json:"price_paid,omitempty"`  // Gönderilmezse satın almada oyunun güncel fiyatı, diğerlerinde 0
+	Currency   string     `json:"currency,omitempty"`    // Gönderilmezse oyunun para birimi
+	GiftedBy   string     `json:"gifted_by,omitempty"`   // Hediye eden kullanıcının ID'si
+	OrderRef   string     `json:"order_ref,omitempty"`   // Sipariş veya anahtar referansı
+}
+
+// LibraryRevokeRequest sahiplik kaydını silme isteğinin gövdesidir
+type LibraryRevokeRequest struct {
+	Reason string `json:"reason"` // ör. "iade", "geçersiz anahtar"
+}
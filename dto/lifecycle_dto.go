@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"api-steam/models"
+	"time"
+)
+
+// StatusTransitionRequest oyunun yaşam döngüsü durumunu değiştirme isteğidir
+type StatusTransitionRequest struct {
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"` // delisted ve removed için zorunlu
+}
+
+// StatusOption mevcut durumdan geçilebilecek bir durumdur; Missing geçiş için doldurulması gereken alanlardır
+type StatusOption struct {
+	Status  string   `json:"status"`
+	Ready   bool     `json:"ready"`
+	Missing []string `json:"missing"`
+}
+
+// StatusOptions oyunun yaşam döngüsündeki yeri ve geçiş seçenekleridir
+type StatusOptions struct {
+	Current     string                `json:"current"`
+	ChangedAt   time.Time             `json:"changed_at,omitempty"`
+	Transitions []StatusOption        `json:"transitions"`
+	History     []models.StatusChange `json:"history"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+// PlaySessionRequest istemcinin bildirdiği tek bir oynama oturumudur
+type PlaySessionRequest struct {
+	UserID    string    `json:"user_id"`
+	GameID    string    `json:"game_id"`
+	StartedAt time.Time `json:"started_at"`
+	EndedAt   time.Time `json:"ended_at"`
+}
+
+// PlaySessionBatchRequest oturumları toplu gönderme isteğinin gövdesidir
+type PlaySessionBatchRequest struct {
+	Sessions []PlaySessionRequest `json:"sessions"`
+}
+
+// PlaySessionRejection kabul edilmeyen bir oturumu ve nedenini bildirir
+type PlaySessionRejection struct {
+	Index int    `json:"index"` // İstekteki sırası
+	Error string `json:"error"`
+}
+
+// PlaytimeIngestResult toplu oturum gönderiminin sonucudur
+type PlaytimeIngestResult struct {
+	Accepted   int                    `json:"accepted"`   // Kaydedilen oturum sayısı
+	Duplicates int                    `json:"duplicates"` // Daha önce kaydedilmiş olduğu için atlananlar
+	Rejected   []PlaySessionRejection `json:"rejected"`   // Geçersiz oturumlar
+}
@@ -0,0 +1,18 @@
+package dto
+
+import "api-steam/models"
+
+// RecommendationSource öneriyi getiren, kullanıcının ilgilendiği oyundur
+type RecommendationSource struct {
+	GameID string `json:"game_id"`
+	Title  string `json:"title"`
+	Source string `json:"source"` // played, owned, wishlisted veya reviewed
+}
+
+// Recommendation kullanıcıya önerilen bir oyundur
+type Recommendation struct {
+	Game        models.Game           `json:"game"`
+	Score       float64               `json:"score"`             // 0-1 arası öneri puanı
+	Explanation string                `json:"explanation"`       // Kullanıcıya gösterilecek açıklama ("X oynadığın için")
+	Because     *RecommendationSource `json:"because,omitempty"` // En çok katkı veren oyun; kullanıcı hakkında veri yoksa boş
+}
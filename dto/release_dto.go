@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"api-steam/models"
+	"time"
+)
+
+// ReleaseCalendarQuery çıkış takvimi isteğinin seçenekleridir; boş alanlar için varsayılanlar kullanılır
+type ReleaseCalendarQuery struct {
+	From     time.Time // Dahil; boşsa içinde bulunulan ayın başı
+	To       time.Time // Hariç; boşsa From'dan 3 ay sonrası
+	GroupBy  string    // week veya month (varsayılan)
+	Platform string    // Boş değilse yalnızca bu platformdaki çıkışlar
+}
+
+// ReleaseEntry bir oyunun belirli bir tarihteki çıkışıdır; farklı platformlarda farklı tarihlerde çıkan oyun her tarih için ayrı yer alır
+type ReleaseEntry struct {
+	Game        models.RelatedGame `json:"game"`
+	ReleaseDate *time.Time         `json:"release_date,omitempty"` // Tarihi henüz belli olmayan yakında çıkacak oyunlarda boştur
+	Platforms   []string           `json:"platforms"`              // Bu tarihte çıkılan platformlar
+}
+
+// ReleasePeriod takvimdeki bir hafta ya da aydır
+type ReleasePeriod struct {
+	Label string         `json:"label"` // 2026-W42 veya 2026-10
+	Start time.Time      `json:"start"`
+	End   time.Time      `json:"end"` // Hariç
+	Games []ReleaseEntry `json:"games"`
+}
+
+// ReleaseCalendar çıkışların hafta ya da aya göre gruplanmış halidir
+type ReleaseCalendar struct {
+	From     time.Time       `json:"from"`
+	To       time.Time       `json:"to"`
+	GroupBy  string          `json:"group_by"`
+	Platform string          `json:"platform,omitempty"`
+	Periods  []ReleasePeriod `json:"periods"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+// ReviewRequest yorum yazma ve düzenleme isteğinin gövdesidir
+type ReviewRequest struct {
+	Score           int    `json:"score"`            // 1-10 arası puan
+	Recommended     *bool  `json:"recommended"`      // Zorunlu
+	Text            string `json:"text,omitempty"`   // En fazla 8000 karakter
+	PlaytimeMinutes int    `json:"playtime_minutes"` // Oynama süresi (dakika)
+}
+
+// ReviewVoteRequest yoruma faydalı/faydalı değil oyu verme isteğinin gövdesidir
+type ReviewVoteRequest struct {
+	Helpful *bool `json:"helpful"`
+}
+
+// ModerationDecisionRequest moderatör kararının (approve, reject, hide) gövdesidir; reject ve hide için gerekçe zorunludur
+type ModerationDecisionRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ReviewReportRequest kullanıcı şikayetinin gövdesidir
+type ReviewReportRequest struct {
+	Reason  string `json:"reason"`            // spam, offensive, spoiler, off_topic, other
+	Comment string `json:"comment,omitempty"` // İsteğe bağlı açıklama
+}
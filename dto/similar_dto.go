@@ -0,0 +1,17 @@
+package dto
+
+import "api-steam/models"
+
+// SimilarCurationRequest editörün benzer oyun listesine sabitlediği ve listeden çıkardığı oyunlardır; listeler tamamen değiştirilir
+type SimilarCurationRequest struct {
+	Pinned   []string `json:"pinned"`   // Listenin başında verilen sırayla gösterilecek oyunların ID'leri
+	Excluded []string `json:"excluded"` // Listede hiç gösterilmeyecek oyunların ID'leri
+}
+
+// SimilarGame benzer oyun listesindeki bir oyundur
+type SimilarGame struct {
+	Game    models.Game `json:"game"`
+	Score   float64     `json:"score"`             // Hesaplanan benzerlik puanı (0-1); hesaplanmamış sabitlemelerde 0
+	Reasons []string    `json:"reasons,omitempty"` // Puana katkı veren ortak özellikler
+	Pinned  bool        `json:"pinned,omitempty"`  // Editör tarafından sabitlendi mi?
+}
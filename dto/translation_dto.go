@@ -0,0 +1,29 @@
+package dto
+
+import "api-steam/models"
+
+// TranslationRequest oyunun bir dildeki metinleridir; boş bırakılan alanlarda varsayılan dildeki metin gösterilir
+type TranslationRequest struct {
+	Title            string `json:"title,omitempty"`
+	Description      string `json:"description,omitempty"`
+	ShortDescription string `json:"short_description,omitempty"`
+}
+
+// MissingTranslation oyunun bir dilde çevrilmemiş alanlarıdır; Fields varsayılan dilde dolu olup çevirisi boş olanlardır
+type MissingTranslation struct {
+	GameID string   `json:"game_id"`
+	Title  string   `json:"title"` // Varsayılan dildeki başlık
+	Status string   `json:"status"`
+	Locale string   `json:"locale"`
+	Fields []string `json:"fields"`
+}
+
+// GameTranslations oyunun tüm dillerdeki metinleri ve eksik çevirileridir
+type GameTranslations struct {
+	GameID        string               `json:"game_id"`
+	DefaultLocale string               `json:"default_locale"`
+	Locales       []string             `json:"locales"` // Desteklenen diller, varsayılan dil dahil
+	Default       models.Translation   `json:"default"` // Oyunun kendi alanları
+	Translations  []models.Translation `json:"translations"`
+	Missing       []MissingTranslation `json:"missing"`
+}
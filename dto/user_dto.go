@@ -0,0 +1,44 @@
+package dto
+
+// RegisterRequest kayıt isteğinin gövdesidir
+type RegisterRequest struct {
+	Email       string `json:"email"`
+	Password    string `json:"password"`
+	DisplayName string `json:"display_name,omitempty"`
+}
+
+// LoginRequest e-posta ve şifre ile giriş isteğinin gövdesidir
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// RefreshRequest yenileme ve çıkış isteklerinin gövdesidir
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// ForgotPasswordRequest şifre sıfırlama bağlantısı isteğinin gövdesidir
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// ResetPasswordRequest yeni şifre belirleme isteğinin gövdesidir
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// TokenPairDTO giriş ve yenileme yanıtıdır
+type TokenPairDTO struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"` // Erişim token'ının saniye cinsinden ömrü
+}
+
+// ContentSettingsRequest kullanıcının yaş derecesi sınırlarını değiştirme isteğinin gövdesidir; boş alan sınırı kaldırır
+type ContentSettingsRequest struct {
+	MaxPEGI string `json:"max_pegi,omitempty"`
+	MaxESRB string `json:"max_esrb,omitempty"`
+}
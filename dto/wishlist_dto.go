@@ -0,0 +1,20 @@
+package dto
+
+// WishlistAddRequest istek listesine oyun ekleme isteğinin gövdesidir; tercihler gönderilmezse ikisi de açık kabul edilir
+type WishlistAddRequest struct {
+	GameID          string `json:"game_id"`
+	NotifyOnSale    *bool  `json:"notify_on_sale,omitempty"`
+	NotifyOnRelease *bool  `json:"notify_on_release,omitempty"`
+}
+
+// WishlistPreferencesRequest listedeki oyunun bildirim tercihlerini güncelleme isteğinin gövdesidir
+type WishlistPreferencesRequest struct {
+	NotifyOnSale    bool `json:"notify_on_sale"`
+	NotifyOnRelease bool `json:"notify_on_release"`
+}
+
+// NotificationSettingsRequest kullanıcının bildirim kanallarını güncelleme isteğinin gövdesidir
+type NotificationSettingsRequest struct {
+	Channels   []string `json:"channels"`
+	WebhookURL string   `json:"webhook_url,omitempty"`
+}
@@ -0,0 +1,73 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// Handler bir olayı işler; hatalarını kendisi loglamalıdır
+type Handler func(ctx context.Context, event Event)
+
+// Publisher olay yayınlayabilen bileşenlerin (ör. repository katmanı) bağımlı olduğu arayüzdür
+type Publisher interface {
+	Publish(ctx context.Context, event Event)
+}
+
+// Bus süreç içi olay yoludur; abonelere olayları ayrı goroutine'lerde iletir, böylece yayınlayan istek beklemez
+type Bus struct {
+	Log      *slog.Logger
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+	wg       sync.WaitGroup
+}
+
+// NewBus boş bir olay yolu oluşturur
+func NewBus(logger *slog.Logger) *Bus {
+	return &Bus{Log: logger, handlers: map[string][]Handler{}}
+}
+
+// Subscribe handler'ı verilen olay tiplerine abone eder
+func (b *Bus) Subscribe(handler Handler, types ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, t := range types {
+		b.handlers[t] = append(b.handlers[t], handler)
+	}
+}
+
+// Publish olayı abonelere iletir. İsteğin iptal edilmesi aboneleri durdurmaz ama trace ve request_id korunur.
+func (b *Bus) Publish(ctx context.Context, event Event) {
+	b.mu.RLock()
+	handlers := b.handlers[event.Type]
+	b.mu.RUnlock()
+	ctx = context.WithoutCancel(ctx)
+	for _, h := range handlers {
+		b.wg.Add(1)
+		go func(h Handler) {
+			defer b.wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					b.Log.ErrorContext(ctx, "olay işleyicisi panikledi", "type", event.Type, "panic", r)
+				}
+			}()
+			h(ctx, event)
+		}(h)
+	}
+	b.Log.DebugContext(ctx, "olay yayınlandı", "type", event.Type, "game_id", event.GameID, "subscribers", len(handlers))
+}
+
+// Close işlenmekte olan olayların bitmesini ctx süresi dolana kadar bekler
+func (b *Bus) Close(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
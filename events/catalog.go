@@ -0,0 +1,42 @@
+package events
+
+import (
+	"api-steam/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Katalog olay tipleri
+const (
+	GameOnSale        = "game.on_sale"        // Oyun indirime girdi veya indirim oranı arttı
+	GameReleased      = "game.released"       // Oyun coming_soon durumundan early_access ya da active durumuna geçti
+	GameStatusChanged = "game.status_changed" // Oyunun yaşam döngüsü durumu değişti
+)
+
+// Event bir oyunda gerçekleşen değişikliği, değişiklikten önceki ve sonraki haliyle taşır
+type Event struct {
+	Type   string
+	GameID primitive.ObjectID
+	Before models.Game
+	After  models.Game
+	At     time.Time
+}
+
+// GameChanges bir oyunun iki hali arasındaki bildirime değer değişiklikleri olay olarak döndürür
+func GameChanges(before, after models.Game, at time.Time) []Event {
+	var out []Event
+	add := func(t string) {
+		out = append(out, Event{Type: t, GameID: after.ID, Before: before, After: after, At: at})
+	}
+	if after.Price.OnSale && (!before.Price.OnSale || after.Price.Discount > before.Price.Discount) {
+		add(GameOnSale)
+	}
+	if before.Status == models.GameStatusComingSoon && (after.Status == models.GameStatusEarlyAccess || after.Status == models.GameStatusActive) {
+		add(GameReleased)
+	}
+	if before.Status != after.Status {
+		add(GameStatusChanged)
+	}
+	return out
+}
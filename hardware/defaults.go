@@ -0,0 +1,46 @@
+package hardware
+
+// DefaultEntries yaygın işlemci ve ekran kartlarının seviyeleridir. Seviyeler oyun performansına göre kabaca
+// gruplanmıştır; HARDWARE_TIERS_FILE ile yeni modeller eklenebilir ya da aynı adlı modellerin seviyesi değiştirilebilir.
+// Model adları aynı zamanda eşleşme ifadesidir, bu yüzden üretici ve seri adları ("Intel Core", "GeForce") yazılmaz.
+func DefaultEntries() []Entry {
+	var entries []Entry
+	for _, group := range []struct {
+		kind  string
+		tier  int
+		names []string
+	}{
+		// İşlemciler
+		{KindCPU, 1, []string{"Pentium 4", "Core 2 Duo", "E8400", "E6600", "Athlon 64 X2"}},
+		{KindCPU, 2, []string{"Core 2 Quad", "Q6600", "Q9550", "Phenom II X4", "Phenom 2 X4", "i3-2100", "i3-2120"}},
+		{KindCPU, 3, []string{"i5-750", "i5-760", "i3-3220", "i3-3240", "FX-4300", "FX-4350", "Athlon X4 860", "Athlon X4 880"}},
+		{KindCPU, 4, []string{"i5-2400", "i5-2300", "i5-2500", "i3-6100", "i3-7100", "FX-6300", "FX-6350"}},
+		{KindCPU, 5, []string{"i5-3570", "i5-3470", "i5-4460", "i5-4440", "i5-4590", "i7-2600", "FX-8350", "FX-8320", "FX-8300", "Ryzen 3 1200", "Ryzen 3 1300"}},
+		{KindCPU, 6, []string{"i5-4690", "i5-4670", "i7-3770", "i7-4770", "i7-4790", "i5-6600", "i5-6500", "i5-7500", "Ryzen 5 1400", "Ryzen 3 3100", "Ryzen 3 3200"}},
+		{KindCPU, 7, []string{"i5-8400", "i5-9400", "i7-6700", "i7-7700", "i3-10100", "i3-12100", "Ryzen 5 1600", "Ryzen 5 2600"}},
+		{KindCPU, 8, []string{"i7-8700", "i5-10400", "i5-11400", "Ryzen 5 3600", "Ryzen 7 2700", "Ryzen 7 1700"}},
+		{KindCPU, 9, []string{"i7-9700", "i5-12400", "i5-13400", "Ryzen 7 3700", "Ryzen 7 3800", "Ryzen 5 5600"}},
+		{KindCPU, 10, []string{"i9-9900", "i7-12700", "i5-13600", "Ryzen 7 5800", "Ryzen 7 5700", "Ryzen 5 7600"}},
+		{KindCPU, 11, []string{"i9-12900", "i7-13700", "i7-14700", "Ryzen 7 7800", "Ryzen 7 7700"}},
+		{KindCPU, 12, []string{"i9-13900", "i9-14900", "Ryzen 9 7950", "Ryzen 9 7900", "Ryzen 7 9800"}},
+
+		// Ekran kartları
+		{KindGPU, 1, []string{"HD Graphics 4000", "HD 4000", "GT 730", "GT 630", "HD 5570", "HD 6570"}},
+		{KindGPU, 2, []string{"UHD Graphics 620", "UHD 620", "UHD 630", "HD Graphics 620", "HD 620", "GTX 650", "GTX 550 Ti", "GT 1030", "HD 7750", "HD 6850"}},
+		{KindGPU, 3, []string{"GTX 750 Ti", "GTX 750", "GTX 660", "HD 7850", "R7 260", "R7 265", "Iris Xe"}},
+		{KindGPU, 4, []string{"GTX 960", "GTX 760", "GTX 1050", "R9 270", "R9 280", "HD 7870", "HD 7950", "RX 460", "RX 560"}},
+		{KindGPU, 5, []string{"GTX 970", "GTX 780", "GTX 1050 Ti", "GTX 1650", "RX 570", "RX 470", "R9 290", "R9 380"}},
+		{KindGPU, 6, []string{"GTX 1060", "GTX 980", "GTX 1650 Super", "RX 580", "RX 480", "RX 590", "R9 390"}},
+		{KindGPU, 7, []string{"GTX 1070", "GTX 980 Ti", "GTX 1660", "Vega 56", "RX 5500 XT", "RX 6500 XT"}},
+		{KindGPU, 8, []string{"GTX 1080", "GTX 1660 Ti", "GTX 1660 Super", "RTX 2060", "RTX 3050", "RX 5600 XT", "Vega 64", "RX 6600", "Arc A750"}},
+		{KindGPU, 9, []string{"RTX 2070", "GTX 1080 Ti", "RTX 2060 Super", "RTX 3060", "RTX 4060", "RX 5700 XT", "RX 5700", "RX 6600 XT", "RX 7600", "Arc A770"}},
+		{KindGPU, 10, []string{"RTX 2080", "RTX 2070 Super", "RTX 3060 Ti", "RTX 3070", "RTX 4060 Ti", "RX 6700 XT", "RX 6700", "RX 6750 XT", "RX 7600 XT"}},
+		{KindGPU, 11, []string{"RTX 3080", "RTX 2080 Ti", "RTX 3070 Ti", "RTX 4070", "RX 6800 XT", "RX 6800", "RX 7700 XT", "RX 7800 XT"}},
+		{KindGPU, 12, []string{"RTX 4080", "RTX 3090", "RTX 4070 Ti", "RTX 4090", "RX 7900 XTX", "RX 7900 XT", "RX 6900 XT", "RX 6950 XT"}},
+	} {
+		for _, name := range group.names {
+			entries = append(entries, Entry{Kind: group.kind, Tier: group.tier, Name: name})
+		}
+	}
+	return entries
+}
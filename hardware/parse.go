@@ -0,0 +1,152 @@
+package hardware
+
+import (
+	"api-steam/models"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// sizePattern "8 GB", "512MB", "1,5 TB" gibi boyutları yakalar
+	sizePattern = regexp.MustCompile(`(?i)(\d+(?:[.,]\d+)?)\s*(tb|gb|gib|mb|mib)\b`)
+	// directXPattern "DirectX 12", "DX11", "DirectX: 9.0c" gibi metinlerdeki ana sürümü yakalar
+	directXPattern = regexp.MustCompile(`(?i)(?:directx|dx)\s*:?\s*(\d{1,2})`)
+	// versionPattern yalnızca sürümün yazıldığı "Version 11", "12" gibi metinler içindir
+	versionPattern = regexp.MustCompile(`\b(\d{1,2})(?:\.\d+)?[a-zA-Z]?\b`)
+	// osPatterns işletim sistemi ailelerini tanıtan ifadelerdir
+	osPatterns = []struct {
+		family string
+		re     *regexp.Regexp
+	}{
+		{models.OSWindows, regexp.MustCompile(`(?i)\bwin(dows)?\s*(\d+|xp|vista)?\b`)},
+		{models.OSMacOS, regexp.MustCompile(`(?i)\b(mac\s?os|os\s?x|osx|mac)\b`)},
+		{models.OSLinux, regexp.MustCompile(`(?i)\b(linux|ubuntu|steam\s?os|debian|fedora|mint)\b`)},
+	}
+)
+
+// Parse serbest metin gereksinimleri karşılaştırılabilir hale getirir. Birden fazla işlemci ya da ekran kartı
+// seçenek olarak yazılmışsa ("i5-2500K / FX-6300") en düşük seviyeli olan gereksinim kabul edilir. Hiçbir alan
+// okunamazsa nil döner.
+func (t *Table) Parse(req models.Requirements) *models.HardwareSpec {
+	spec := models.HardwareSpec{
+		OS:        OSFamily(req.OS),
+		MemoryGB:  parseSize(req.Memory),
+		StorageGB: parseSize(req.Storage),
+		DirectX:   parseDirectX(req.DirectX),
+	}
+	if cpu, ok := pick(t.Match(KindCPU, req.Processor), false); ok {
+		spec.CPU, spec.CPUTier = cpu.Name, cpu.Tier
+	}
+	if gpu, ok := pick(t.Match(KindGPU, req.Graphics), false); ok {
+		spec.GPU, spec.GPUTier = gpu.Name, gpu.Tier
+	}
+	if spec == (models.HardwareSpec{}) {
+		return nil
+	}
+	return &spec
+}
+
+// Resolve kullanıcının yazdığı işlemci, ekran kartı ve işletim sistemini tablodaki modellere ve aileye çevirir; sayısal
+// alanlar olduğu gibi kalır. Tanınmayan modellerin seviyesi sıfır olur.
+func (t *Table) Resolve(spec models.HardwareSpec) models.HardwareSpec {
+	resolved := spec
+	resolved.OS = OSFamily(spec.OS)
+	resolved.CPU, resolved.CPUTier, resolved.GPU, resolved.GPUTier = "", 0, "", 0
+	if cpu, ok := pick(t.Match(KindCPU, spec.CPU), true); ok {
+		resolved.CPU, resolved.CPUTier = cpu.Name, cpu.Tier
+	}
+	if gpu, ok := pick(t.Match(KindGPU, spec.GPU), true); ok {
+		resolved.GPU, resolved.GPUTier = gpu.Name, gpu.Tier
+	}
+	return resolved
+}
+
+// Compare have'in need'i karşılamayan bileşenlerini ve need belirtildiği halde have'de bilinmeyen bileşenleri döndürür.
+// need'de bilinmeyen bileşenler karşılanmış sayılır.
+func Compare(have, need models.HardwareSpec) (shortfalls []models.Shortfall, unknown []string) {
+	check := func(component string, required, known bool, fails bool, want, got string) {
+		switch {
+		case !required:
+		case !known:
+			unknown = append(unknown, component)
+		case fails:
+			shortfalls = append(shortfalls, models.Shortfall{Component: component, Required: want, Have: got})
+		}
+	}
+	check("os", need.OS != "", have.OS != "", have.OS != need.OS, need.OS, have.OS)
+	check("cpu", need.CPUTier > 0, have.CPUTier > 0, have.CPUTier < need.CPUTier, need.CPU, have.CPU)
+	check("gpu", need.GPUTier > 0, have.GPUTier > 0, have.GPUTier < need.GPUTier, need.GPU, have.GPU)
+	check("memory", need.MemoryGB > 0, have.MemoryGB > 0, have.MemoryGB < need.MemoryGB, formatGB(need.MemoryGB), formatGB(have.MemoryGB))
+	check("storage", need.StorageGB > 0, have.StorageGB > 0, have.StorageGB < need.StorageGB, formatGB(need.StorageGB), formatGB(have.StorageGB))
+	check("directx", need.DirectX > 0, have.DirectX > 0, have.DirectX < need.DirectX, fmt.Sprintf("DirectX %d", need.DirectX), fmt.Sprintf("DirectX %d", have.DirectX))
+	return shortfalls, unknown
+}
+
+// OSFamily metindeki işletim sistemi ailesini döndürür (windows, macos, linux); tanınmazsa boş döner
+func OSFamily(text string) string {
+	for _, p := range osPatterns {
+		if p.re.MatchString(text) {
+			return p.family
+		}
+	}
+	return ""
+}
+
+// pick eşleşmelerden en düşük (highest=false) ya da en yüksek seviyeli olanı seçer
+func pick(matches []Entry, highest bool) (Entry, bool) {
+	if len(matches) == 0 {
+		return Entry{}, false
+	}
+	best := matches[0]
+	for _, m := range matches[1:] {
+		if (highest && m.Tier > best.Tier) || (!highest && m.Tier < best.Tier) {
+			best = m
+		}
+	}
+	return best, true
+}
+
+// parseSize metindeki ilk boyutu GB olarak döndürür
+func parseSize(text string) float64 {
+	m := sizePattern.FindStringSubmatch(text)
+	if m == nil {
+		return 0
+	}
+	value, err := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", "."), 64)
+	if err != nil {
+		return 0
+	}
+	switch strings.ToLower(m[2]) {
+	case "tb":
+		value *= 1024
+	case "mb", "mib":
+		value /= 1024
+	}
+	return math.Round(value*100) / 100
+}
+
+// parseDirectX metindeki DirectX ana sürümünü döndürür; 7'den küçük ve 12'den büyük değerler geçersiz sayılır
+func parseDirectX(text string) int {
+	m := directXPattern.FindStringSubmatch(text)
+	if m == nil {
+		m = versionPattern.FindStringSubmatch(text)
+	}
+	if m == nil {
+		return 0
+	}
+	version, err := strconv.Atoi(m[1])
+	if err != nil || version < 7 || version > 12 {
+		return 0
+	}
+	return version
+}
+
+func formatGB(value float64) string {
+	if value <= 0 {
+		return ""
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64) + " GB"
+}
@@ -0,0 +1,215 @@
+package hardware
+
+import (
+	"api-steam/models"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		text string
+		want float64
+	}{
+		{"8 GB RAM", 8},
+		{"512MB", 0.5},
+		{"1,5 TB boş alan", 1536},
+		{"16GiB", 16},
+		{"50 GB available space; SSD önerilir", 50},
+		{"8 gigabayt", 0},
+		{"", 0},
+	}
+	for _, tt := range tests {
+		if got := parseSize(tt.text); got != tt.want {
+			t.Errorf("parseSize(%q) = %v, beklenen %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestParseDirectX(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"DirectX 12", 12},
+		{"DX11", 11},
+		{"DirectX: 9.0c", 9},
+		{"Version 11", 11},
+		{"12", 12},
+		{"DirectX 6", 0},
+		{"Vulkan", 0},
+		{"", 0},
+	}
+	for _, tt := range tests {
+		if got := parseDirectX(tt.text); got != tt.want {
+			t.Errorf("parseDirectX(%q) = %d, beklenen %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestOSFamily(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Windows 10 64-bit", models.OSWindows},
+		{"Win 7", models.OSWindows},
+		{"macOS 12 Monterey", models.OSMacOS},
+		{"OS X 10.13", models.OSMacOS},
+		{"Ubuntu 22.04", models.OSLinux},
+		{"SteamOS", models.OSLinux},
+		{"Amiga", ""},
+	}
+	for _, tt := range tests {
+		if got := OSFamily(tt.text); got != tt.want {
+			t.Errorf("OSFamily(%q) = %q, beklenen %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestTableMatch(t *testing.T) {
+	table := NewTable(DefaultEntries())
+	tests := []struct {
+		name string
+		kind string
+		text string
+		want []string
+	}{
+		{"uzun eşleşme kısayı örter", KindGPU, "NVIDIA GeForce RTX 3060 Ti", []string{"RTX 3060 Ti"}},
+		{"bitişik yazım", KindGPU, "GTX1060 6GB", []string{"GTX 1060"}},
+		{"seçenekler", KindCPU, "Intel Core i5-2500K / AMD FX-6300", []string{"i5-2500", "FX-6300"}},
+		{"tanınmayan", KindCPU, "Herhangi bir çift çekirdekli işlemci", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, entry := range table.Match(tt.kind, tt.text) {
+				got = append(got, entry.Name)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("eşleşmeler = %v, beklenen %v", got, tt.want)
+			}
+			for _, name := range tt.want {
+				found := false
+				for _, g := range got {
+					found = found || g == name
+				}
+				if !found {
+					t.Errorf("eşleşmeler = %v, %s yok", got, name)
+				}
+			}
+		})
+	}
+}
+
+func TestTableParse(t *testing.T) {
+	table := NewTable([]Entry{
+		{Kind: KindCPU, Tier: 4, Name: "i5-2500"},
+		{Kind: KindCPU, Tier: 2, Name: "Q6600"},
+		{Kind: KindGPU, Tier: 6, Name: "GTX 1060"},
+		{Kind: KindGPU, Tier: 5, Name: "RX 570"},
+	})
+	tests := []struct {
+		name string
+		req  models.Requirements
+		want *models.HardwareSpec
+	}{
+		{
+			"tam gereksinim",
+			models.Requirements{OS: "Windows 10", Processor: "i5-2500K or Core 2 Quad Q6600", Memory: "8 GB RAM", Graphics: "GTX 1060 / RX 570", DirectX: "Version 11", Storage: "40 GB"},
+			&models.HardwareSpec{OS: models.OSWindows, CPU: "Q6600", CPUTier: 2, GPU: "RX 570", GPUTier: 5, MemoryGB: 8, StorageGB: 40, DirectX: 11},
+		},
+		{"yalnızca bellek", models.Requirements{Memory: "4096 MB"}, &models.HardwareSpec{MemoryGB: 4}},
+		{"okunamayan", models.Requirements{Processor: "hızlı bir işlemci", AdditionalNotes: "8 GB"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := table.Parse(tt.req); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse = %+v, beklenen %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTableResolve(t *testing.T) {
+	table := NewTable([]Entry{
+		{Kind: KindCPU, Tier: 8, Name: "Ryzen 5 3600"},
+		{Kind: KindGPU, Tier: 9, Name: "RTX 3060"},
+		{Kind: KindGPU, Tier: 10, Name: "RTX 3060 Ti"},
+	})
+	got := table.Resolve(models.HardwareSpec{OS: "Windows 11", CPU: "AMD Ryzen 5 3600X", GPU: "rtx3060ti", MemoryGB: 16, CPUTier: 99})
+	want := models.HardwareSpec{OS: models.OSWindows, CPU: "Ryzen 5 3600", CPUTier: 8, GPU: "RTX 3060 Ti", GPUTier: 10, MemoryGB: 16}
+	if got != want {
+		t.Errorf("Resolve = %+v, beklenen %+v", got, want)
+	}
+	// tanınmayan model istemcinin gönderdiği seviyeyi taşımaz
+	if got := table.Resolve(models.HardwareSpec{CPU: "bilinmeyen", CPUTier: 12}); got.CPU != "" || got.CPUTier != 0 {
+		t.Errorf("tanınmayan işlemci = %q/%d, beklenen boş", got.CPU, got.CPUTier)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	need := models.HardwareSpec{OS: models.OSWindows, CPU: "i5-2500", CPUTier: 4, GPU: "GTX 1060", GPUTier: 6, MemoryGB: 8, DirectX: 11}
+	tests := []struct {
+		name        string
+		have        models.HardwareSpec
+		wantShort   []string
+		wantUnknown []string
+	}{
+		{"karşılıyor", models.HardwareSpec{OS: models.OSWindows, CPUTier: 8, GPUTier: 9, MemoryGB: 16, StorageGB: 100, DirectX: 12}, nil, nil},
+		{"eksikler", models.HardwareSpec{OS: models.OSLinux, CPUTier: 3, GPUTier: 6, MemoryGB: 4, DirectX: 12}, []string{"os", "cpu", "memory"}, nil},
+		{"bilinmeyenler", models.HardwareSpec{OS: models.OSWindows, MemoryGB: 8}, nil, []string{"cpu", "gpu", "directx"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shortfalls, unknown := Compare(tt.have, need)
+			var short []string
+			for _, s := range shortfalls {
+				short = append(short, s.Component)
+			}
+			if !reflect.DeepEqual(short, tt.wantShort) || !reflect.DeepEqual(unknown, tt.wantUnknown) {
+				t.Errorf("Compare = %v / %v, beklenen %v / %v", short, unknown, tt.wantShort, tt.wantUnknown)
+			}
+		})
+	}
+	shortfalls, _ := Compare(models.HardwareSpec{MemoryGB: 4}, models.HardwareSpec{MemoryGB: 8})
+	if want := (models.Shortfall{Component: "memory", Required: "8 GB", Have: "4 GB"}); len(shortfalls) != 1 || shortfalls[0] != want {
+		t.Errorf("bellek eksiği = %+v, beklenen %+v", shortfalls, want)
+	}
+}
+
+func TestLoadTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Entry
+		wantErr bool
+	}{
+		{
+			"geçerli",
+			"# yorum\n\ngpu|13|RTX 5090|rtx 5090, 5090\nCPU | 12 | Ryzen 9 9950\n",
+			[]Entry{{Kind: KindGPU, Tier: 13, Name: "RTX 5090", Patterns: []string{"rtx 5090", "5090"}}, {Kind: KindCPU, Tier: 12, Name: "Ryzen 9 9950"}},
+			false,
+		},
+		{"eksik alan", "gpu|13\n", nil, true},
+		{"geçersiz tür", "ram|1|DDR5\n", nil, true},
+		{"geçersiz seviye", "cpu|0|i3\n", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "tiers.txt")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			got, err := LoadTable(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("hata = %v, beklenen hata %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadTable = %+v, beklenen %+v", got, tt.want)
+			}
+		})
+	}
+}
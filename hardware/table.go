@@ -0,0 +1,157 @@
+package hardware
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Donanım türleri
+const (
+	KindCPU = "cpu"
+	KindGPU = "gpu"
+)
+
+// Entry donanım tablosundaki bir modeldir. Tier aynı türdeki modeller arasında göreli performanstır (büyük olan güçlü);
+// Patterns gereksinim metinlerinde modeli tanıtan ifadelerdir (ör. "gtx 1060"), boşsa modelin adı kullanılır.
+type Entry struct {
+	Kind     string
+	Tier     int
+	Name     string
+	Patterns []string
+}
+
+type pattern struct {
+	entry  Entry
+	phrase string // normalize edilmiş ifade
+}
+
+// Table işlemci ve ekran kartı metinlerini tablodaki modellere eşler
+type Table struct {
+	patterns map[string][]pattern
+	entries  int
+}
+
+// NewTable girişlerden bir tablo kurar; aynı türde ve adda birden fazla giriş varsa sonuncusu geçerlidir
+func NewTable(entries []Entry) *Table {
+	byName := map[string]Entry{}
+	var order []string
+	for _, entry := range entries {
+		key := entry.Kind + "|" + strings.ToLower(entry.Name)
+		if _, ok := byName[key]; !ok {
+			order = append(order, key)
+		}
+		byName[key] = entry
+	}
+	t := &Table{patterns: map[string][]pattern{}, entries: len(order)}
+	for _, key := range order {
+		entry := byName[key]
+		phrases := entry.Patterns
+		if len(phrases) == 0 {
+			phrases = []string{entry.Name}
+		}
+		for _, phrase := range phrases {
+			if normalized := normalize(phrase); normalized != "" {
+				t.patterns[entry.Kind] = append(t.patterns[entry.Kind], pattern{entry: entry, phrase: normalized})
+			}
+		}
+	}
+	return t
+}
+
+// Len tablodaki model sayısını döndürür
+func (t *Table) Len() int {
+	return t.entries
+}
+
+// Match metinde geçen kind türündeki modelleri döndürür. Başka bir eşleşmenin parçası olan eşleşmeler atlanır; böylece
+// "RTX 3060 Ti" metni yalnızca RTX 3060 Ti'ye eşlenir, RTX 3060'a eşlenmez.
+func (t *Table) Match(kind, text string) []Entry {
+	normalized := " " + normalize(text) + " "
+	var found []pattern
+	for _, p := range t.patterns[kind] {
+		if strings.Contains(normalized, " "+p.phrase+" ") {
+			found = append(found, p)
+		}
+	}
+	var matches []Entry
+	seen := map[string]bool{}
+	for _, p := range found {
+		covered := false
+		for _, other := range found {
+			if other.phrase != p.phrase && strings.Contains(" "+other.phrase+" ", " "+p.phrase+" ") {
+				covered = true
+				break
+			}
+		}
+		if !covered && !seen[p.entry.Name] {
+			seen[p.entry.Name] = true
+			matches = append(matches, p.entry)
+		}
+	}
+	return matches
+}
+
+// LoadTable satır başına bir model içeren dosyayı okur: "tür|seviye|ad|ifade1,ifade2". İfadeler isteğe bağlıdır;
+// boş satırlar ve # ile başlayanlar atlanır.
+func LoadTable(path string) ([]Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Split(text, "|")
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("%s:%d: tür|seviye|ad biçiminde olmalıdır", path, line)
+		}
+		kind := strings.ToLower(strings.TrimSpace(fields[0]))
+		if kind != KindCPU && kind != KindGPU {
+			return nil, fmt.Errorf("%s:%d: tür cpu veya gpu olmalıdır", path, line)
+		}
+		tier, err := strconv.Atoi(strings.TrimSpace(fields[1]))
+		if err != nil || tier <= 0 {
+			return nil, fmt.Errorf("%s:%d: seviye pozitif bir tam sayı olmalıdır", path, line)
+		}
+		entry := Entry{Kind: kind, Tier: tier, Name: strings.TrimSpace(fields[2])}
+		if len(fields) > 3 {
+			for _, phrase := range strings.Split(fields[3], ",") {
+				if phrase = strings.TrimSpace(phrase); phrase != "" {
+					entry.Patterns = append(entry.Patterns, phrase)
+				}
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// normalize metni küçük harfli kelimelere böler; harf ile rakam arası da ayraç sayılır ("GTX1060" ve "gtx-1060"
+// aynı "gtx 1060" olur)
+func normalize(text string) string {
+	var b strings.Builder
+	var prev rune
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if prev != 0 && prev != ' ' && unicode.IsDigit(prev) != unicode.IsDigit(r) {
+				b.WriteRune(' ')
+			}
+			b.WriteRune(r)
+			prev = r
+		case prev != 0 && prev != ' ':
+			b.WriteRune(' ')
+			prev = ' '
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
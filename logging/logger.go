@@ -0,0 +1,70 @@
+package logging
+
+import (
+	"api-steam/configs"
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+type requestIDKey struct{}
+
+// New verilen paket için JSON (veya LOG_FORMAT=text ise metin) çıktı veren bir logger oluşturur.
+// Seviye LOG_LEVELS içinde paket adıyla tanımlıysa oradan, değilse LOG_LEVEL'dan okunur.
+// Context ile loglanan her satıra isteğin request_id değeri eklenir.
+func New(component string) *slog.Logger {
+	level := parseLevel(configs.EnvLogLevel())
+	if value, ok := configs.EnvLogLevels()[component]; ok {
+		level = parseLevel(value)
+	}
+	return slog.New(contextHandler{newHandler(os.Stdout, level)}).With("component", component)
+}
+
+func newHandler(w io.Writer, level slog.Level) slog.Handler {
+	opts := &slog.HandlerOptions{Level: level}
+	if configs.EnvLogFormat() == "text" {
+		return slog.NewTextHandler(w, opts)
+	}
+	return slog.NewJSONHandler(w, opts)
+}
+
+func parseLevel(value string) slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.ToUpper(value))); err != nil {
+		return slog.LevelInfo
+	}
+	return level
+}
+
+// WithRequestID isteğin kimliğini context'e ekler
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID context'teki istek kimliğini döndürür, yoksa boş string döner
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// contextHandler log kaydına context'teki request_id değerini ekler
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
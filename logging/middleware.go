@@ -0,0 +1,59 @@
+package logging
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderRequestID istemcinin gönderebileceği ve yanıtta geri dönen istek kimliği başlığıdır
+const HeaderRequestID = echo.HeaderXRequestID
+
+// Middleware gelen X-Request-ID başlığını (yoksa yeni bir UUID) isteğin context'ine koyar,
+// yanıt başlığına yazar ve istek tamamlandığında bir erişim logu basar
+func Middleware(logger *slog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			id := c.Request().Header.Get(HeaderRequestID)
+			if id == "" || len(id) > 128 {
+				id = uuid.NewString()
+			}
+			ctx := WithRequestID(c.Request().Context(), id)
+			c.SetRequest(c.Request().WithContext(ctx))
+			c.Response().Header().Set(HeaderRequestID, id)
+
+			start := time.Now()
+			err := next(c)
+			// Hata dışarıdaki middleware'lere (otelecho) ve Echo'nun hata işleyicisine döner; yanıt henüz yazılmadığı için
+			// loglanacak durum kodu hatadan alınır
+			status := c.Response().Status
+			if err != nil && !c.Response().Committed {
+				status = http.StatusInternalServerError
+				var httpErr *echo.HTTPError
+				if errors.As(err, &httpErr) {
+					status = httpErr.Code
+				}
+			}
+			level := slog.LevelInfo
+			if status >= 500 {
+				level = slog.LevelError
+			}
+			attrs := []any{
+				"method", c.Request().Method,
+				"route", c.Path(),
+				"status", status,
+				"duration_ms", time.Since(start).Milliseconds(),
+				"remote_ip", c.RealIP(),
+			}
+			if err != nil {
+				attrs = append(attrs, "error", err)
+			}
+			logger.Log(ctx, level, "istek tamamlandı", attrs...)
+			return err
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestMiddlewareReturnsHandlerError(t *testing.T) {
+	failure := errors.New("veritabanı kapalı")
+	tests := []struct {
+		name       string
+		handler    echo.HandlerFunc
+		wantErr    error
+		wantStatus int
+		wantLevel  string
+	}{
+		{"başarılı", func(c echo.Context) error { return c.NoContent(http.StatusCreated) }, nil, http.StatusCreated, "INFO"},
+		{"HTTP hatası", func(c echo.Context) error { return echo.ErrNotFound }, echo.ErrNotFound, http.StatusNotFound, "INFO"},
+		{"beklenmeyen hata", func(c echo.Context) error { return failure }, failure, http.StatusInternalServerError, "ERROR"},
+		{"yanıt yazıldıktan sonra hata", func(c echo.Context) error {
+			_ = c.NoContent(http.StatusAccepted)
+			return failure
+		}, failure, http.StatusAccepted, "INFO"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			e := echo.New()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/games", nil), httptest.NewRecorder())
+			err := Middleware(slog.New(slog.NewJSONHandler(&out, nil)))(tt.handler)(c)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("dönen hata = %v, beklenen %v", err, tt.wantErr)
+			}
+			if c.Response().Header().Get(HeaderRequestID) == "" {
+				t.Error("X-Request-ID yazılmadı")
+			}
+			var entry struct {
+				Level  string `json:"level"`
+				Status int    `json:"status"`
+				Error  string `json:"error"`
+			}
+			if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+				t.Fatalf("erişim logu okunamadı: %v (%s)", err, out.String())
+			}
+			if entry.Status != tt.wantStatus || entry.Level != tt.wantLevel {
+				t.Errorf("log = %+v, beklenen durum %d seviye %s", entry, tt.wantStatus, tt.wantLevel)
+			}
+			if (tt.wantErr != nil) != (entry.Error != "") {
+				t.Errorf("logdaki hata = %q, beklenen %v", entry.Error, tt.wantErr)
+			}
+		})
+	}
+}
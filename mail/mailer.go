@@ -0,0 +1,99 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Message gönderilecek düz metin e-postadır
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer e-posta gönderimi için takılabilir arayüzdür
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// New MAIL_DRIVER ayarına göre bir Mailer döndürür: "smtp", "file" (geliştirme için) veya "log"
+func New(driver, from, smtpAddr, smtpUser, smtpPassword, dir string, logger *slog.Logger) (Mailer, error) {
+	switch driver {
+	case "smtp":
+		return &SMTPMailer{Addr: smtpAddr, From: from, Username: smtpUser, Password: smtpPassword}, nil
+	case "file":
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+		return &FileMailer{Dir: dir, From: from}, nil
+	case "log", "":
+		return &LogMailer{Log: logger}, nil
+	}
+	return nil, fmt.Errorf("bilinmeyen mail sürücüsü: %q", driver)
+}
+
+// SMTPMailer e-postayı bir SMTP sunucusuna (veya MailHog gibi bir yakalayıcıya) gönderir
+type SMTPMailer struct {
+	Addr     string // host:port
+	From     string
+	Username string
+	Password string
+}
+
+// Send mesajı SMTP üzerinden gönderir
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	var auth smtp.Auth
+	if m.Username != "" {
+		host, _, _ := strings.Cut(m.Addr, ":")
+		auth = smtp.PlainAuth("", m.Username, m.Password, host)
+	}
+	return smtp.SendMail(m.Addr, auth, m.From, []string{msg.To}, render(m.From, msg))
+}
+
+// FileMailer her mesajı Dir altında bir .eml dosyasına yazar (yerel geliştirme için)
+type FileMailer struct {
+	Dir  string
+	From string
+}
+
+// Send mesajı dosyaya yazar
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), sanitize(msg.To))
+	return os.WriteFile(filepath.Join(m.Dir, name), render(m.From, msg), 0o644)
+}
+
+// LogMailer mesajı yalnızca loglar; mail ayarı yapılmamış ortamlar için varsayılandır
+type LogMailer struct {
+	Log *slog.Logger
+}
+
+// Send mesajın yalnızca alıcısını ve konusunu loglar; gövde şifre sıfırlama bağlantısı gibi gizli bilgiler
+// içerebildiği için loglara yazılmaz. Gövdeyi görmek için "file" sürücüsü kullanılmalıdır.
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	m.Log.InfoContext(ctx, "e-posta (gönderilmedi, log sürücüsü)", "to", msg.To, "subject", msg.Subject, "body_bytes", len(msg.Body))
+	return nil
+}
+
+func render(from string, msg Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\n", from, msg.To, msg.Subject, time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '@' || r == '.' || r == '-' || r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, s)
+}
@@ -0,0 +1,24 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogMailerOmitsBody(t *testing.T) {
+	var out bytes.Buffer
+	m := &LogMailer{Log: slog.New(slog.NewJSONHandler(&out, nil))}
+	msg := Message{To: "oyuncu@example.com", Subject: "Şifre sıfırlama", Body: "https://store.example.com/reset-password?token=gizli"}
+	if err := m.Send(context.Background(), msg); err != nil {
+		t.Fatalf("beklenmeyen hata: %v", err)
+	}
+	if strings.Contains(out.String(), "gizli") || strings.Contains(out.String(), "reset-password") {
+		t.Errorf("gövde loga yazıldı: %s", out.String())
+	}
+	if !strings.Contains(out.String(), msg.To) || !strings.Contains(out.String(), `"body_bytes":52`) {
+		t.Errorf("alıcı ya da gövde uzunluğu loglanmadı: %s", out.String())
+	}
+}
@@ -2,37 +2,515 @@ package main
 
 import (
 	"api-steam/app"
+	"api-steam/auth"
 	"api-steam/configs"
+	"api-steam/events"
+	"api-steam/hardware"
+	"api-steam/logging"
+	"api-steam/mail"
+	"api-steam/metrics"
+	"api-steam/migrations"
+	"api-steam/models"
+	"api-steam/moderation"
+	"api-steam/notify"
+	"api-steam/ratelimit"
 	"api-steam/repository"
 	"api-steam/services"
-	"log"
+	"api-steam/telemetry"
+	"api-steam/workers"
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
 )
 
 func main() {
+	// Her paket kendi logger'ını alır; seviyeler LOG_LEVEL / LOG_LEVELS ile ayarlanır
+	logger := logging.New("main")
+	slog.SetDefault(logger)
+
+	// Trace exporter ve traceparent yayılımı
+	shutdownTracing, err := telemetry.Setup(context.Background())
+	if err != nil {
+		logger.Error("tracing başlatılamadı", "error", err)
+		os.Exit(1)
+	}
+
 	// Echo instance oluştur
 	e := echo.New()
+	e.HideBanner, e.HidePort = true, true             // stdout yalnızca JSON log satırları içersin
+	e.IPExtractor = newIPExtractor(logger)            // hız sınırı ve loglar için istemci IP'si; başlıklara yalnızca güvenilen proxy'ler arkasında bakılır
+	e.Use(otelecho.Middleware(telemetry.ServiceName)) // her istek için traceparent'ı okuyup bir span açar
+	e.Use(logging.Middleware(logging.New("http")))    // X-Request-ID atar ve erişim logu basar
+	e.Use(metrics.Middleware())                       // istek sayısı, süre ve eşzamanlı istek metrikleri
+
+	// Katalog olayları (indirim, çıkış) repository katmanından yayınlanır, bildirim servisi bunlara abone olur
+	eventBus := events.NewBus(logging.New("events"))
+
+	// DB bağlantısı kurulamazsa ConnectDB uygulamayı sonlandırır
+	configs.DB = configs.ConnectDB()
+	dbClient := configs.GetCollection(configs.DB, "games")                                                    //tabloya bağlanmak için
+	productRepositoryDB := newProductRepository(dbClient, eventBus)                                           //Repistory katmanına bağlantı nesnesini veririz, metrik katmanı her metodun süresini ölçer
+	hardwareTable := newHardwareTable(logger)                                                                 // sistem gereksinimlerindeki işlemci/ekran kartı seviyeleri
+	productService := services.NewProductService(productRepositoryDB, hardwareTable, logging.New("services")) // servis katmanında repistory katmanındakifonksiyonlara erişmek için
+	productHandler := app.ProductHandler{Services: productService, Log: logging.New("app")}                   //handlerda kulancağımız servis elamanları için handlera servis den bir nesne veiriz
+
+	// Kimlik doğrulama: kullanıcılar için JWT, servisler için API anahtarı
+	jwtVerifier, err := auth.NewJWTVerifier(configs.EnvJWTSecret(), configs.EnvJWKSFile(), configs.EnvJWTIssuer(), configs.EnvJWTAudience())
+	if err != nil {
+		logger.Error("JWT doğrulayıcı oluşturulamadı", "error", err)
+		os.Exit(1)
+	}
+	apiKeyRepositoryDB := repository.NewAPIKeyRepository(configs.GetCollection(configs.DB, "api_keys"), logging.New("repository"))
+	authenticator := auth.NewAuthenticator(jwtVerifier, apiKeyRepositoryDB, logging.New("auth"))
+	apiKeyHandler := app.APIKeyHandler{Services: services.NewAPIKeyService(apiKeyRepositoryDB, logging.New("services")), Log: logging.New("app")}
+	e.Use(authenticator.Middleware()) // çağıranı isteğin context'ine ekler, anonim istekler devam eder
+
+	// İstek sınırlama: API anahtarı, kullanıcı veya IP başına okuma/yazma/toplu bütçeleri
+	rateLimiter, sweepRateLimits := newRateLimiter(logger)
+	e.Use(rateLimiter.Middleware())
+
+	// Kullanıcı hesapları
+	mailer, err := mail.New(configs.EnvMailDriver(), configs.EnvMailFrom(), configs.EnvSMTPAddr(), configs.EnvSMTPUser(), configs.EnvSMTPPassword(), configs.EnvMailDir(), logging.New("mail"))
+	if err != nil {
+		logger.Error("mail sürücüsü oluşturulamadı", "error", err)
+		os.Exit(1)
+	}
+	jwtSigner := auth.NewJWTSigner(configs.EnvJWTSecret(), configs.EnvJWTIssuer(), configs.EnvJWTAudience(), configs.EnvAccessTokenTTL())
+	userRepositoryDB := repository.NewUserRepository(configs.GetCollection(configs.DB, "users"), logging.New("repository"))
+	tokenRepositoryDB := repository.NewTokenRepository(configs.GetCollection(configs.DB, "refresh_tokens"), configs.GetCollection(configs.DB, "password_resets"), logging.New("repository"))
+	userService := services.NewUserService(userRepositoryDB, tokenRepositoryDB, jwtSigner, mailer, logging.New("services"),
+		configs.EnvAccessTokenTTL(), configs.EnvRefreshTokenTTL(), configs.EnvPasswordResetTTL(), configs.EnvPublicBaseURL())
+	userHandler := app.UserHandler{Services: userService, Log: logging.New("app")}
+
+	// İstek listeleri ve bildirimler
+	wishlistRepositoryDB := repository.NewWishlistRepository(configs.GetCollection(configs.DB, "wishlists"), logging.New("repository"))
+	notificationRepositoryDB := repository.NewNotificationRepository(configs.GetCollection(configs.DB, "notifications"), logging.New("repository"))
+	dispatcher := notify.NewDispatcher(logging.New("notify"),
+		&notify.InboxChannel{Notifications: notificationRepositoryDB},
+		&notify.EmailChannel{Mailer: mailer},
+		notify.NewWebhookChannel(configs.EnvWebhookTimeout(), configs.EnvWebhookSecret()),
+	)
+	notificationService := services.NewNotificationService(wishlistRepositoryDB, userRepositoryDB, notificationRepositoryDB, dispatcher, logging.New("services"), configs.EnvPublicBaseURL())
+	eventBus.Subscribe(notificationService.HandleGameEvent, events.GameOnSale, events.GameReleased)
+	wishlistHandler := app.WishlistHandler{Services: services.NewWishlistService(wishlistRepositoryDB, productRepositoryDB, logging.New("services")), Log: logging.New("app")}
+	notificationHandler := app.NotificationHandler{Services: notificationService, Log: logging.New("app")}
+
+	// Yorumlar; oyunun Rating alanları yorumlardan artımlı olarak hesaplanır
+	reviewRepositoryDB := repository.NewReviewRepository(configs.GetCollection(configs.DB, "reviews"), configs.GetCollection(configs.DB, "review_votes"), configs.GetCollection(configs.DB, "review_reports"), logging.New("repository"))
+	libraryRepositoryDB := repository.NewLibraryRepository(configs.GetCollection(configs.DB, "library"), logging.New("repository"))
+	reviewService := services.NewReviewService(reviewRepositoryDB, productRepositoryDB, libraryRepositoryDB, newContentFilter(logger), logging.New("services"))
+	reviewHandler := app.ReviewHandler{Services: reviewService, Log: logging.New("app")}
+
+	// Moderasyon ve denetim kaydı
+	auditService := services.NewAuditService(repository.NewAuditRepository(configs.GetCollection(configs.DB, "audit_log"), logging.New("repository")), logging.New("services"))
+	moderationService := services.NewModerationService(reviewRepositoryDB, reviewService, auditService, logging.New("services"), configs.EnvModerationReportThreshold())
+	moderationHandler := app.ModerationHandler{Services: moderationService, Log: logging.New("app")}
+	auditHandler := app.AuditHandler{Services: auditService, Log: logging.New("app")}
+
+	// Kütüphane: kullanıcıların sahip olduğu oyunlar
+	libraryService := services.NewLibraryService(libraryRepositoryDB, productRepositoryDB, userRepositoryDB, reviewRepositoryDB, auditService, logging.New("services"))
+	libraryHandler := app.LibraryHandler{Services: libraryService, Log: logging.New("app")}
+
+	// Oynama süreleri: oturumlar alınır, oyunların ortalama/medyan süreleri periyodik olarak hesaplanır
+	playtimeRepositoryDB := repository.NewPlaytimeRepository(configs.GetCollection(configs.DB, "play_sessions"), configs.GetCollection(configs.DB, "play_stats"), logging.New("repository"))
+	playtimeService := services.NewPlaytimeService(playtimeRepositoryDB, productRepositoryDB, logging.New("services"), configs.EnvPlaytimeMaxSession())
+	playtimeHandler := app.PlaytimeHandler{Services: playtimeService, Log: logging.New("app")}
+
+	// Başarımlar: tanımlar oyun başına, açılışlar istemcilerden gelir; yüzdeler kütüphane sahiplerine göre hesaplanır
+	achievementRepositoryDB := repository.NewAchievementRepository(configs.GetCollection(configs.DB, "achievements"), configs.GetCollection(configs.DB, "achievement_unlocks"), logging.New("repository"))
+	achievementService := services.NewAchievementService(achievementRepositoryDB, productRepositoryDB, libraryRepositoryDB, logging.New("services"))
+	achievementHandler := app.AchievementHandler{Services: achievementService, Log: logging.New("app")}
+	productHandler.Achievements = achievementService
+
+	// Benzer oyunlar: zamanlanmış iş ortak özelliklerden puan hesaplar, editörler sabitleyip çıkarabilir
+	similarRepositoryDB := repository.NewSimilarRepository(configs.GetCollection(configs.DB, "similar_games"), logging.New("repository"))
+	similarService := services.NewSimilarService(similarRepositoryDB, productRepositoryDB, logging.New("services"), configs.EnvSimilarTopK())
+	similarHandler := app.SimilarHandler{Services: similarService, Log: logging.New("app")}
+
+	// Kişisel öneriler: kütüphane, istek listesi, yorumlar ve oynama sürelerinden istek anında hesaplanır
+	recommendationService := services.NewRecommendationService(productRepositoryDB, userRepositoryDB, libraryRepositoryDB, wishlistRepositoryDB, reviewRepositoryDB, playtimeRepositoryDB, logging.New("services"))
+	recommendationHandler := app.RecommendationHandler{Services: recommendationService, Log: logging.New("app")}
+
+	// Vitrin grafikleri: zamanlanmış iş hesaplar, istekler bellekteki son halinden sunulur
+	chartService := services.NewChartService(productRepositoryDB, reviewRepositoryDB, wishlistRepositoryDB, libraryRepositoryDB, logging.New("services"), configs.EnvChartSize(), configs.EnvChartMinReviews())
+	chartHandler := app.ChartHandler{Services: chartService, Log: logging.New("app")}
+
+	// Çıkış takvimi: oyun ve platform çıkış tarihlerinden takvim, yakında çıkacaklar ve .ics aboneliği
+	releaseService := services.NewReleaseService(productRepositoryDB, logging.New("services"))
+	releaseHandler := app.ReleaseHandler{Services: releaseService, Log: logging.New("app")}
+
+	// Yaşam döngüsü: durum yalnızca izinli geçişlerle değişir; geçişler olay yayınlar ve denetim kaydına yazılır
+	lifecycleHandler := app.LifecycleHandler{Services: services.NewLifecycleService(productRepositoryDB, auditService, logging.New("services")), Log: logging.New("app")}
+
+	// Taslaklar: editörler canlı oyun yerine taslağı düzenler; başka bir kullanıcı onaylar, yayın hemen ya da zamanlanmış yapılır
+	draftService := services.NewDraftService(repository.NewDraftRepository(configs.GetCollection(configs.DB, "game_drafts"), configs.GetCollection(configs.DB, "game_revisions"), logging.New("repository")), productService, auditService, logging.New("services"))
+	draftHandler := app.DraftHandler{Services: draftService, Log: logging.New("app")}
+
+	// Sistem gereksinimleri: metinler donanım tablosuyla okunur, "çalıştırır mı?" sorusu bunlarla yanıtlanır
+	requirementService := services.NewRequirementService(productRepositoryDB, hardwareTable, logging.New("services"))
+	requirementHandler := app.RequirementHandler{Services: requirementService, Log: logging.New("app")}
+
+	// Yerelleştirme: oyun metinleri varsayılan dilde, diğer diller translations alanında tutulur
+	locales := services.NewLocales(configs.EnvDefaultLocale(), configs.EnvSupportedLocales())
+	translationHandler := app.TranslationHandler{Services: services.NewTranslationService(productRepositoryDB, auditService, locales, logging.New("services")), Log: logging.New("app")}
+
+	requireAuth := auth.RequireAuth()
+	contentFilter := app.ContentFilter(userService, logging.New("app")) // ?max_pegi=/?max_esrb= ya da profil ayarlarıyla yaş filtresi
+	localized := app.Localize(locales)                                  // ?lang= ya da Accept-Language ile oyun metinlerinin dili
+	authorizer := auth.NewAuthorizer(logging.New("auth"))               // izinler auth/rbac.go içinde rol bazında tanımlıdır
+	currentPrice := func(ctx context.Context, id primitive.ObjectID) (models.Price, bool, error) {
+		game, err := productService.ProductGetByID(ctx, id)
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.Price{}, false, nil
+		}
+		return game.Price, err == nil, err
+	}
+
+	// Arka plan işleri ve migration'lar
+	backgroundWorkers := workers.NewManager(logging.New("workers"))
+	migrationRunner := migrations.NewRunner(configs.GetDatabase(configs.DB), logging.New("migrations"))
+	healthService := services.NewHealthService(configs.DB, migrationRunner, backgroundWorkers, configs.EnvReadinessTimeout())
+	healthHandler := app.HealthHandler{Services: healthService}
+	if sweepRateLimits != nil {
+		backgroundWorkers.Add("rate-limit-sweeper", workers.Every(time.Minute, sweepRateLimits))
+	}
+	backgroundWorkers.Add("playtime-aggregator", workers.Every(configs.EnvPlaytimeRefreshInterval(), func(ctx context.Context) {
+		if _, err := playtimeService.PlaytimeRecompute(ctx); err != nil {
+			logger.WarnContext(ctx, "oynama süreleri hesaplanamadı", "error", err)
+		}
+	}))
+	backgroundWorkers.Add("similar-games", workers.Every(configs.EnvSimilarRefreshInterval(), func(ctx context.Context) {
+		if _, err := similarService.SimilarRecompute(ctx); err != nil {
+			logger.WarnContext(ctx, "benzer oyunlar hesaplanamadı", "error", err)
+		}
+	}))
+	backgroundWorkers.Add("charts", workers.Every(configs.EnvChartRefreshInterval(), func(ctx context.Context) {
+		if _, err := chartService.ChartRefresh(ctx); err != nil {
+			logger.WarnContext(ctx, "grafikler hesaplanamadı", "error", err)
+		}
+	}))
+	backgroundWorkers.Add("scheduled-publish", workers.Every(configs.EnvDraftPublishInterval(), func(ctx context.Context) {
+		if _, err := draftService.DraftPublishDue(ctx); err != nil {
+			logger.WarnContext(ctx, "zamanlanmış taslaklar yayınlanamadı", "error", err)
+		}
+	}))
+	backgroundWorkers.Add("requirements-parser", workers.Every(configs.EnvRequirementsReparseInterval(), func(ctx context.Context) {
+		if _, err := requirementService.RequirementsReparse(ctx); err != nil {
+			logger.WarnContext(ctx, "sistem gereksinimleri yeniden hesaplanamadı", "error", err)
+		}
+	}))
+	backgroundWorkers.Add("catalog-metrics", workers.Every(configs.EnvMetricsRefreshInterval(), func(ctx context.Context) {
+		byStatus, onSale, err := productService.ProductStats(ctx)
+		if err != nil {
+			logger.WarnContext(ctx, "katalog metrikleri güncellenemedi", "error", err)
+			return
+		}
+		metrics.SetCatalogStats(byStatus, onSale)
+	}))
+
+	// sağlık kontrolleri
+	e.GET("/healthz", healthHandler.Healthz) // Süreç ayakta mı (liveness)
+	e.GET("/readyz", healthHandler.Readyz)   // Trafik almaya hazır mı (readiness)
+	e.GET("/status", healthHandler.Status)   // Operatörler için ayrıntılı durum
+	e.GET("/metrics", metrics.Handler())     // Prometheus metrikleri
+
+	// kimlik ve API anahtarları
+	e.GET("/api/auth/me", apiKeyHandler.Me, requireAuth)                                                      // Kimliği doğrulanmış çağıranı döner
+	e.POST("/api/auth/api-keys", apiKeyHandler.CreateAPIKey, authorizer.Require(auth.PermAPIKeyManage))       // Yeni API anahtarı üretir
+	e.GET("/api/auth/api-keys", apiKeyHandler.ListAPIKeys, authorizer.Require(auth.PermAPIKeyManage))         // API anahtarlarını listeler
+	e.DELETE("/api/auth/api-keys/:id", apiKeyHandler.RevokeAPIKey, authorizer.Require(auth.PermAPIKeyManage)) // API anahtarını iptal eder
+
+	// kullanıcı hesapları
+	e.POST("/api/auth/register", userHandler.Register)                                      // Yeni hesap açar
+	e.POST("/api/auth/login", userHandler.Login)                                            // E-posta ve şifre ile giriş
+	e.POST("/api/auth/refresh", userHandler.Refresh)                                        // Yenileme token'ını yeni bir çiftle değiştirir
+	e.POST("/api/auth/logout", userHandler.Logout)                                          // Oturumu kapatır
+	e.POST("/api/auth/password/forgot", userHandler.ForgotPassword)                         // Şifre sıfırlama bağlantısı gönderir
+	e.POST("/api/auth/password/reset", userHandler.ResetPassword)                           // Tek kullanımlık token ile yeni şifre belirler
+	e.GET("/api/users/me", userHandler.Profile, requireAuth)                                // Giriş yapmış kullanıcının profili
+	e.PUT("/api/users/me/content-settings", userHandler.UpdateContentSettings, requireAuth) // Yaş derecesi sınırları (PEGI/ESRB)
+
+	// istek listesi ve bildirimler
+	e.GET("/api/users/me/wishlist", wishlistHandler.GetWishlist, requireAuth)                                 // İstek listesini döner
+	e.POST("/api/users/me/wishlist", wishlistHandler.AddToWishlist, requireAuth)                              // Listeye oyun ekler
+	e.PATCH("/api/users/me/wishlist/:gameId", wishlistHandler.UpdateWishlistItem, requireAuth)                // Bildirim tercihlerini günceller
+	e.DELETE("/api/users/me/wishlist/:gameId", wishlistHandler.RemoveFromWishlist, requireAuth)               // Oyunu listeden çıkarır
+	e.GET("/api/users/me/notifications", notificationHandler.GetNotifications, requireAuth)                   // Gelen kutusu
+	e.POST("/api/users/me/notifications/:id/read", notificationHandler.MarkNotificationRead, requireAuth)     // Bildirimi okundu işaretler
+	e.GET("/api/users/me/notification-settings", notificationHandler.GetNotificationSettings, requireAuth)    // Bildirim kanallarını döner
+	e.PUT("/api/users/me/notification-settings", notificationHandler.UpdateNotificationSettings, requireAuth) // Bildirim kanallarını değiştirir
 
-	// DB bağlantısı configs.DB üzerinden zaten kurulmuş durumda
-	dbClient := configs.GetCollection(configs.DB, "games")            //tabloya bağlanmak için
-	productRepositoryDB := repository.NewProductRepository(dbClient)  //Repistory katmanına bağlantı nesnesini veririz
-	productService := services.NewProductService(productRepositoryDB) // servis katmanında repistory katmanındakifonksiyonlara erişmek için
-	productHandler := app.ProductHandler{Services: productService}    //handlerda kulancağımız servis elamanları için handlera servis den bir nesne veiriz
+	// yorumlar
+	e.GET("/api/game/:id/reviews", reviewHandler.GetReviews)                                                         // Oyunun yayınlanmış yorumları
+	e.POST("/api/game/:id/reviews", reviewHandler.CreateReview, requireAuth)                                         // Oyuna yorum yazar
+	e.PUT("/api/game/:id/reviews/me", reviewHandler.UpdateMyReview, requireAuth)                                     // Kendi yorumunu düzenler
+	e.DELETE("/api/game/:id/reviews/me", reviewHandler.DeleteMyReview, requireAuth)                                  // Kendi yorumunu siler
+	e.POST("/api/game/:id/rating/recompute", reviewHandler.RecomputeRating, authorizer.Require(auth.PermGameUpdate)) // Puanları yorumlardan baştan hesaplar
+	e.POST("/api/reviews/:id/vote", reviewHandler.VoteReview, requireAuth)                                           // Faydalı/faydalı değil oyu
+	e.DELETE("/api/reviews/:id/vote", reviewHandler.UnvoteReview, requireAuth)                                       // Oyu geri alır
+	e.POST("/api/reviews/:id/report", moderationHandler.ReportReview, requireAuth)                                   // Yorumu şikayet eder
+
+	// kütüphane
+	e.GET("/api/users/me/library", libraryHandler.GetMyLibrary, requireAuth, localized)                               // Sahip olunan oyunlar; satın alınmış oyunlar içerik ayarlarından bağımsız olarak listelenir
+	e.GET("/api/users/me/library/:gameId", libraryHandler.GetMyOwnership, requireAuth, localized)                     // Oyuna sahip mi?
+	e.GET("/api/users/:id/library", libraryHandler.GetUserLibrary, authorizer.Require(auth.PermLibraryManage))        // Kullanıcının kütüphanesi (destek)
+	e.POST("/api/users/:id/library", libraryHandler.GrantGame, authorizer.Require(auth.PermLibraryManage))            // Satın alma, hediye veya anahtar kaydı
+	e.DELETE("/api/users/:id/library/:gameId", libraryHandler.RevokeGame, authorizer.Require(auth.PermLibraryManage)) // Sahipliği kaldırır (iade)
+
+	// oynama süreleri
+	e.POST("/api/playtime/sessions", playtimeHandler.IngestSessions, authorizer.Require(auth.PermPlaytimeIngest)) // Oynama oturumlarını toplu kaydeder
+	e.POST("/api/playtime/recompute", playtimeHandler.RecomputePlaytime, authorizer.Require(auth.PermGameUpdate)) // Oyunların sürelerini hemen yeniden hesaplar
+	e.GET("/api/users/me/playtime", playtimeHandler.GetMyPlaytime, requireAuth)                                   // Oyun başına oynama süreleri
+	e.GET("/api/users/me/playtime/:gameId", playtimeHandler.GetMyGamePlaytime, requireAuth)                       // Tek oyundaki süre ve son oturumlar
+
+	// Başarımlar
+	e.GET("/api/game/:id/achievements", achievementHandler.GetAchievements)                                                    // Açılma yüzdeleriyle başarımlar
+	e.POST("/api/game/:id/achievements", achievementHandler.CreateAchievement, authorizer.Require(auth.PermGameUpdate))        // Başarım tanımı ekler
+	e.PUT("/api/game/:id/achievements/:key", achievementHandler.UpdateAchievement, authorizer.Require(auth.PermGameUpdate))    // Başarım tanımını günceller
+	e.DELETE("/api/game/:id/achievements/:key", achievementHandler.DeleteAchievement, authorizer.Require(auth.PermGameUpdate)) // Başarımı ve açılışlarını siler
+	e.POST("/api/achievements/unlocks", achievementHandler.UnlockAchievement, authorizer.Require(auth.PermAchievementUnlock))  // İstemciden gelen açılışı kaydeder
+	e.GET("/api/users/me/achievements/:gameId", achievementHandler.GetMyAchievements, requireAuth)                             // Oyundaki başarım ilerlemesi
+
+	// Benzer oyunlar
+	e.GET("/api/game/:id/similar", similarHandler.GetSimilar, contentFilter, localized)                            // Puanlarıyla benzer oyunlar
+	e.PUT("/api/game/:id/similar/curation", similarHandler.CurateSimilar, authorizer.Require(auth.PermGameUpdate)) // Sabitlenen ve çıkarılan oyunlar
+	e.POST("/api/similar/recompute", similarHandler.RecomputeSimilar, authorizer.Require(auth.PermGameUpdate))     // Benzerlikleri hemen yeniden hesaplar
+
+	// Kişisel öneriler
+	e.GET("/api/users/me/recommendations", recommendationHandler.GetMyRecommendations, requireAuth, contentFilter, localized) // "Sana önerilenler" listesi
+
+	// Vitrin grafikleri
+	e.GET("/api/charts", chartHandler.GetCharts)                                                       // Grafik adları ve hesaplanma zamanları
+	e.GET("/api/charts/:name", chartHandler.GetChart, contentFilter, localized)                        // Sıralı grafik (?limit=)
+	e.POST("/api/charts/refresh", chartHandler.RefreshCharts, authorizer.Require(auth.PermGameUpdate)) // Grafikleri hemen yeniden hesaplar
+
+	// Çıkış takvimi
+	e.GET("/api/releases/calendar", releaseHandler.GetCalendar, contentFilter, localized)         // Hafta ya da aya göre gruplanmış çıkışlar
+	e.GET("/api/releases/calendar.ics", releaseHandler.GetCalendarFeed, contentFilter, localized) // Takvim aboneliği (iCalendar)
+	e.GET("/api/releases/coming-soon", releaseHandler.GetComingSoon, contentFilter, localized)    // Yakında çıkacak oyunlar
+
+	// moderasyon ve denetim kaydı
+	e.GET("/api/moderation/reviews", moderationHandler.GetQueue, authorizer.Require(auth.PermReviewModerate))                       // Moderasyon kuyruğu
+	e.GET("/api/moderation/reviews/:id/reports", moderationHandler.GetReports, authorizer.Require(auth.PermReviewModerate))         // Yorumun şikayetleri
+	e.POST("/api/moderation/reviews/:id/approve", moderationHandler.Decide("approve"), authorizer.Require(auth.PermReviewModerate)) // Yorumu yayınlar
+	e.POST("/api/moderation/reviews/:id/reject", moderationHandler.Decide("reject"), authorizer.Require(auth.PermReviewModerate))   // Yorumu reddeder (gerekçe zorunlu)
+	e.POST("/api/moderation/reviews/:id/hide", moderationHandler.Decide("hide"), authorizer.Require(auth.PermReviewModerate))       // Yorumu gizler (gerekçe zorunlu)
+	e.GET("/api/audit", auditHandler.GetAuditLog, authorizer.Require(auth.PermAuditRead))                                           // Denetim kayıtları
 
 	//endpointi
-	e.POST("/api/game", productHandler.CreateProduct)                    // Yeni bir oyun oluşturur
-	e.GET("/api/games", productHandler.GetAllProduct)                    // Tüm oyunları listeler
-	e.DELETE("/api/game/:id", productHandler.DeleteProduct)              // ID'ye göre oyun siler
-	e.PUT("/api/game/:id", productHandler.UpdateProduct)                 // ID'ye göre oyunu tamamen günceller
-	e.PATCH("/api/game/:id", productHandler.PatchProduct)                // ID'ye göre oyunun belirli alanlarını günceller
-	e.GET("/api/game/:id", productHandler.GetByID)                       // ID'ye göre oyun getirir
-	e.GET("/api/games/sorted", productHandler.GetGamesSorted)            // Oyunları belirtilen alana göre sıralar (asc/desc)
-	e.GET("/api/games/exact", productHandler.GetGamesByExactName)        // Tam isim eşleşmesine göre oyun arar
-	e.GET("/api/games/search", productHandler.GetGamesByPartialName)     // Kısmi isim eşleşmesine göre oyun arar
-	e.POST("/api/games/bulk", productHandler.CreateManyProducts)         // Birden fazla oyunu toplu ekler
-	e.GET("/api/games/price-range", productHandler.GetGamesByPriceRange) // Fiyat aralığına göre oyunları filtreler
-	// Sunucuyu başlat
-	log.Println("Server 8080 portunda başlatılıyor...")
-	log.Fatal(e.Start(":8080"))
+	e.POST("/api/game", productHandler.CreateProduct, authorizer.CreateGame())                                                    // Yeni bir oyun oluşturur
+	e.GET("/api/games", productHandler.GetAllProduct, contentFilter, localized)                                                   // Tüm oyunları listeler
+	e.DELETE("/api/game/:id", productHandler.DeleteProduct, authorizer.Require(auth.PermGameDelete))                              // ID'ye göre oyun siler
+	e.PUT("/api/game/:id", productHandler.UpdateProduct, authorizer.ReplaceGame(currentPrice))                                    // ID'ye göre oyunu tamamen günceller
+	e.PATCH("/api/game/:id", productHandler.PatchProduct, authorizer.PatchGame())                                                 // ID'ye göre oyunun belirli alanlarını günceller
+	e.GET("/api/game/:id", productHandler.GetByID, contentFilter, localized)                                                      // ID'ye göre oyun getirir; yaş sınırını aşan oyun 404 döner
+	e.POST("/api/game/:id/can-i-run", requirementHandler.CanIRun)                                                                 // Bilgisayar oyunun gereksinimlerini karşılıyor mu?
+	e.GET("/api/game/:id/translations", translationHandler.GetTranslations, authorizer.Require(auth.PermGameUpdate))              // Tüm dillerdeki metinler ve eksik çeviriler
+	e.PUT("/api/game/:id/translations/:locale", translationHandler.PutTranslation, authorizer.Require(auth.PermGameUpdate))       // Bir dildeki çeviriyi ekler ya da değiştirir
+	e.DELETE("/api/game/:id/translations/:locale", translationHandler.DeleteTranslation, authorizer.Require(auth.PermGameUpdate)) // Bir dildeki çeviriyi siler
+	e.GET("/api/translations/missing", translationHandler.GetMissingTranslations, authorizer.Require(auth.PermGameUpdate))        // Eksik çeviri raporu (?locale=)
+	e.GET("/api/game/:id/status", lifecycleHandler.GetStatus, authorizer.Require(auth.PermGameUpdate))                            // Durum, geçmiş ve geçilebilecek durumlar
+	e.POST("/api/game/:id/status", lifecycleHandler.TransitionStatus, authorizer.TransitionGame())                                // Durum geçişi (removed için game:delete gerekir)
+	e.POST("/api/game/:id/draft", draftHandler.CreateDraft, authorizer.Require(auth.PermGameUpdate))                              // Canlı oyundan taslak oluşturur
+	e.GET("/api/game/:id/draft", draftHandler.GetDraft, authorizer.Require(auth.PermGameUpdate))                                  // Taslak önizlemesi
+	e.PUT("/api/game/:id/draft", draftHandler.UpdateDraft, authorizer.ReplaceGame(currentPrice))                                  // Taslağı düzenler
+	e.DELETE("/api/game/:id/draft", draftHandler.DiscardDraft, authorizer.Require(auth.PermGameUpdate))                           // Taslağı siler
+	e.POST("/api/game/:id/draft/submit", draftHandler.SubmitDraft, authorizer.Require(auth.PermGameUpdate))                       // İncelemeye gönderir
+	e.POST("/api/game/:id/draft/approve", draftHandler.ApproveDraft, authorizer.Require(auth.PermGamePublish))                    // Başka bir kullanıcı onaylar
+	e.POST("/api/game/:id/draft/reject", draftHandler.RejectDraft, authorizer.Require(auth.PermGamePublish))                      // Gerekçeyle geri gönderir
+	e.POST("/api/game/:id/draft/publish", draftHandler.PublishDraft, authorizer.Require(auth.PermGamePublish))                    // Hemen yayınlar ya da zamanlar
+	e.GET("/api/game/:id/revisions", draftHandler.GetRevisions, authorizer.Require(auth.PermGameUpdate))                          // Yayın geçmişi
+	e.GET("/api/drafts", draftHandler.ListDrafts, authorizer.Require(auth.PermGameUpdate))                                        // Taslaklar (?state=in_review inceleme kuyruğu)
+	e.GET("/api/games/sorted", productHandler.GetGamesSorted, contentFilter, localized)                                           // Oyunları belirtilen alana göre sıralar (asc/desc)
+	e.GET("/api/games/exact", productHandler.GetGamesByExactName, contentFilter, localized)                                       // Tam isim eşleşmesine göre oyun arar
+	e.GET("/api/games/search", productHandler.GetGamesByPartialName, contentFilter, localized)                                    // Kısmi isim ve/veya bilgisayar özelliklerine göre oyun arar
+	e.POST("/api/games/bulk", productHandler.CreateManyProducts, authorizer.Require(auth.PermGameBulk))                           // Birden fazla oyunu toplu ekler
+	e.GET("/api/games/price-range", productHandler.GetGamesByPriceRange, contentFilter, localized)                                // Fiyat aralığına göre oyunları filtreler
+
+	// Migration'lar başarısız olursa sunucu ayağa kalkar ama /readyz hazır değil döner; uygulanana kadar arka planda yeniden denenir
+	if err := runMigrations(migrationRunner); err != nil {
+		logger.Error("migration'lar uygulanamadı", "error", err)
+		go retryMigrations(migrationRunner, logger)
+	}
+	backgroundWorkers.Start(context.Background())
+
+	// Sunucuyu başlat; Start kapanışta http.ErrServerClosed döner
+	addr := configs.EnvServerAddr()
+	go func() {
+		logger.Info("sunucu başlatılıyor", "addr", addr)
+		if err := e.Start(addr); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("sunucu başlatılamadı", "error", err)
+			os.Exit(1)
+		}
+	}()
+
+	// SIGINT/SIGTERM gelene kadar bekle
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+	stop()
+	logger.Info("kapanış sinyali alındı, sunucu durduruluyor")
+
+	// Yeni bağlantıları kabul etmeyi bırak, devam eden istekleri grace period içinde bitir
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), configs.EnvShutdownTimeout())
+	defer cancel()
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		logger.Error("HTTP sunucusu düzgün kapatılamadı", "error", err)
+	}
+	if err := backgroundWorkers.Stop(shutdownCtx); err != nil {
+		logger.Error("arka plan işleri zamanında durmadı", "error", err)
+	}
+	if err := eventBus.Close(shutdownCtx); err != nil {
+		logger.Error("bekleyen bildirimler zamanında tamamlanmadı", "error", err)
+	}
+	if err := userService.UserWait(shutdownCtx); err != nil {
+		logger.Error("bekleyen şifre sıfırlama e-postaları zamanında gönderilemedi", "error", err)
+	}
+	if err := configs.DisconnectDB(shutdownCtx); err != nil {
+		logger.Error("MongoDB bağlantısı kapatılamadı", "error", err)
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Error("bekleyen span'ler gönderilemedi", "error", err)
+	}
+	logger.Info("sunucu kapatıldı")
+}
+
+// newRateLimiter ortam değişkenlerindeki bütçelerle istek sınırlayıcıyı kurar.
+// Bellek içi depoda dolmuş kovaları temizleyen iş de döner; Mongo deposunda bunu TTL indeksi yapar.
+func newRateLimiter(logger *slog.Logger) (*ratelimit.Limiter, func(ctx context.Context)) {
+	var budgets ratelimit.Budgets
+	var err error
+	for _, b := range []struct {
+		rule *ratelimit.Rule
+		name string
+		spec string
+	}{
+		{&budgets.Read, "read", configs.EnvRateLimitRead()},
+		{&budgets.Write, "write", configs.EnvRateLimitWrite()},
+		{&budgets.Bulk, "bulk", configs.EnvRateLimitBulk()},
+	} {
+		if *b.rule, err = ratelimit.ParseRule(b.name, b.spec); err != nil {
+			logger.Error("istek sınırı bütçesi geçersiz", "error", err)
+			os.Exit(1)
+		}
+	}
+	bulkRoutes := []string{"/api/games/bulk"}
+
+	switch backend := configs.EnvRateLimitBackend(); backend {
+	case "mongo":
+		store := ratelimit.NewMongoStore(configs.GetCollection(configs.DB, "rate_limits"))
+		return ratelimit.NewLimiter(store, budgets, bulkRoutes, logging.New("ratelimit")), nil
+	case "memory":
+		store := ratelimit.NewMemoryStore()
+		sweep := func(ctx context.Context) { store.Sweep(time.Now()) }
+		return ratelimit.NewLimiter(store, budgets, bulkRoutes, logging.New("ratelimit")), sweep
+	default:
+		logger.Error("bilinmeyen istek sınırı deposu", "backend", backend)
+		os.Exit(1)
+		return nil, nil
+	}
+}
+
+// newProductRepository MongoDB repository'sini katmanlarıyla birlikte kurar: en içte metrik/trace, en dışta olay yayını
+func newProductRepository(collection *mongo.Collection, publisher events.Publisher) repository.ProductRepository {
+	repo := repository.NewProductRepository(collection, logging.New("repository"))
+	repo = repository.NewInstrumentedProductRepository(repo)
+	return repository.NewEventedProductRepository(repo, publisher)
+}
+
+// runMigrations migration'ları bir dakikalık süre sınırıyla bir kez çalıştırır
+func runMigrations(runner *migrations.Runner) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	return runner.Run(ctx)
+}
+
+// retryMigrations başarısız olan migration'ları artan aralıklarla (en fazla beş dakika) yeniden dener; başka bir
+// instance hepsini uyguladığında ya da deneme başarılı olduğunda durur
+func retryMigrations(runner *migrations.Runner, logger *slog.Logger) {
+	for wait := 30 * time.Second; ; wait = min(2*wait, 5*time.Minute) {
+		time.Sleep(wait)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		applied := runner.Check(ctx)
+		cancel()
+		if applied {
+			return
+		}
+		if err := runMigrations(runner); err != nil {
+			logger.Error("migration'lar yeniden denenirken uygulanamadı", "error", err)
+			continue
+		}
+		logger.Info("migration'lar yeniden denemede uygulandı")
+		return
+	}
+}
+
+// newIPExtractor TRUSTED_PROXIES boşsa bağlantının adresini kullanır; doluysa X-Forwarded-For zincirini yalnızca listedeki
+// proxy'lerden gelen adımlar için izler. İstemcinin gönderdiği başlıklarla hız sınırı aşılamasın diye varsayılan olarak
+// hiçbir ağa güvenilmez. Geçersiz bir giriş varsa uygulama başlamaz.
+func newIPExtractor(logger *slog.Logger) echo.IPExtractor {
+	proxies := configs.EnvTrustedProxies()
+	if len(proxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			logger.Error("TRUSTED_PROXIES geçersiz", "value", proxy, "error", err)
+			os.Exit(1)
+		}
+		options = append(options, echo.TrustIPRange(network))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
+// newHardwareTable yerleşik donanım tablosunu HARDWARE_TIERS_FILE'daki girişlerle genişletir; dosya okunamazsa uygulama başlamaz
+func newHardwareTable(logger *slog.Logger) *hardware.Table {
+	entries := hardware.DefaultEntries()
+	if path := configs.EnvHardwareTiersFile(); path != "" {
+		extra, err := hardware.LoadTable(path)
+		if err != nil {
+			logger.Error("donanım tablosu okunamadı", "path", path, "error", err)
+			os.Exit(1)
+		}
+		entries = append(entries, extra...)
+	}
+	table := hardware.NewTable(entries)
+	logger.Info("donanım tablosu hazır", "models", table.Len())
+	return table
+}
+
+// newContentFilter yorum filtresini MODERATION_WORDLIST_FILE ve MODERATION_WORDS'ten kurar; dosya okunamazsa uygulama başlamaz
+func newContentFilter(logger *slog.Logger) *moderation.Filter {
+	words := configs.EnvModerationWords()
+	if path := configs.EnvModerationWordListFile(); path != "" {
+		entries, err := moderation.LoadWordList(path)
+		if err != nil {
+			logger.Error("moderasyon kelime listesi okunamadı", "path", path, "error", err)
+			os.Exit(1)
+		}
+		words = append(words, entries...)
+	}
+	logger.Info("yorum filtresi hazır", "entries", len(words), "block_links", configs.EnvModerationBlockLinks())
+	return moderation.NewFilter(words, configs.EnvModerationBlockLinks())
 }
@@ -0,0 +1,125 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// unmatchedRoute main.go'da kayıtlı olmayan yollara gelen istekler için kullanılan etikettir (kardinaliteyi sınırlar)
+const unmatchedRoute = "unmatched"
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Route, method ve durum koduna göre HTTP istek sayısı.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Route, method ve durum koduna göre HTTP istek süresi.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	httpInFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "http_requests_in_flight",
+		Help: "Şu an işlenmekte olan HTTP istekleri.",
+	}, []string{"method", "route"})
+
+	repositoryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "repository_operation_duration_seconds",
+		Help:    "Repository metodlarına göre MongoDB işlem süresi.",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"repository", "method", "outcome"})
+
+	gamesByStatus = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "catalog_games",
+		Help: "Duruma göre katalogdaki oyun sayısı.",
+	}, []string{"status"})
+
+	gamesOnSale = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "catalog_games_on_sale",
+		Help: "Şu an indirimde olan oyun sayısı.",
+	})
+
+	rateLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_rate_limited_total",
+		Help: "Bütçeye göre istek sınırı aşıldığı için 429 ile reddedilen istek sayısı.",
+	}, []string{"budget"})
+)
+
+// Handler /metrics endpoint'ini Prometheus metin formatında sunar
+func Handler() echo.HandlerFunc {
+	return echo.WrapHandler(promhttp.Handler())
+}
+
+// Middleware her isteğin sayısını, süresini ve eşzamanlı istek sayısını route şablonuna göre (ör. /api/game/:id) kaydeder
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			route := routeLabel(c)
+			method := c.Request().Method
+			inFlight := httpInFlight.WithLabelValues(method, route)
+			inFlight.Inc()
+			defer inFlight.Dec()
+
+			start := time.Now()
+			err := next(c)
+			status := c.Response().Status
+			if err != nil {
+				// Hata handler'dan döndüyse yanıt henüz yazılmamıştır, kodu hatadan al
+				var httpErr *echo.HTTPError
+				if errors.As(err, &httpErr) {
+					status = httpErr.Code
+				} else if !c.Response().Committed {
+					status = http.StatusInternalServerError
+				}
+			}
+			labels := []string{method, route, strconv.Itoa(status)}
+			httpRequests.WithLabelValues(labels...).Inc()
+			httpDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+			return err
+		}
+	}
+}
+
+// routeLabel isteğin eşleştiği kayıtlı route şablonunu döndürür; router eşleşmeyen isteklerde boş yol bırakır, böylece
+// etiket değerleri ham URL'lerle çoğalmaz
+func routeLabel(c echo.Context) string {
+	if path := c.Path(); path != "" {
+		return path
+	}
+	return unmatchedRoute
+}
+
+// ObserveRepository bir repository metodunun süresini ve sonucunu kaydeder
+func ObserveRepository(repository, method string, start time.Time, err error) {
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+	repositoryDuration.WithLabelValues(repository, method, outcome).Observe(time.Since(start).Seconds())
+}
+
+// SetCatalogStats iş metriklerini (duruma göre oyun sayısı, indirimdeki oyunlar) günceller
+func SetCatalogStats(byStatus map[string]int64, onSale int64) {
+	gamesByStatus.Reset()
+	for status, count := range byStatus {
+		if status == "" {
+			status = "unknown"
+		}
+		gamesByStatus.WithLabelValues(status).Set(float64(count))
+	}
+	gamesOnSale.Set(float64(onSale))
+}
+
+// ObserveRateLimited istek sınırına takılan bir isteği kaydeder
+func ObserveRateLimited(budget string) {
+	rateLimited.WithLabelValues(budget).Inc()
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestRouteLabel(t *testing.T) {
+	e := echo.New()
+	var got string
+	record := func(c echo.Context) error {
+		got = routeLabel(c)
+		return nil
+	}
+	e.GET("/api/game/:id", record)
+	e.GET("/api/games", record)
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			got = routeLabel(c)
+			return next(c)
+		}
+	})
+	tests := []struct {
+		method, path, want string
+	}{
+		{http.MethodGet, "/api/game/65f0c0ffee", "/api/game/:id"},
+		{http.MethodGet, "/api/games?sort=price", "/api/games"},
+		{http.MethodGet, "/api/nope/123", unmatchedRoute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got = ""
+			e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.path, nil))
+			if got != tt.want {
+				t.Errorf("etiket = %q, beklenen %q", got, tt.want)
+			}
+		})
+	}
+}
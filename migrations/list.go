@@ -0,0 +1,375 @@
+package migrations
+
+import (
+	"api-steam/models"
+	"context"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// All uygulamanın migration listesini uygulanma sırasıyla döndürür; yeni migration'lar sona eklenir
+func All() []Migration {
+	return []Migration{
+		{
+			ID:          "0001_games_indexes",
+			Description: "games koleksiyonunda başlık, fiyat ve durum indeksleri",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection("games").Indexes().CreateMany(ctx, []mongo.IndexModel{
+					{Keys: bson.D{{Key: "title", Value: 1}}},
+					{Keys: bson.D{{Key: "price.amount", Value: 1}}},
+					{Keys: bson.D{{Key: "status", Value: 1}}},
+				})
+				return err
+			},
+		},
+		{
+			ID:          "0002_api_keys_prefix_unique",
+			Description: "api_keys koleksiyonunda prefix için benzersiz indeks",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection("api_keys").Indexes().CreateOne(ctx, mongo.IndexModel{
+					Keys:    bson.D{{Key: "prefix", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				})
+				return err
+			},
+		},
+		{
+			ID:          "0003_users_and_tokens",
+			Description: "users e-posta benzersiz indeksi, token özet indeksleri ve süresi dolan token'lar için TTL",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				if _, err := db.Collection("users").Indexes().CreateOne(ctx, mongo.IndexModel{
+					Keys:    bson.D{{Key: "email", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				}); err != nil {
+					return err
+				}
+				for _, name := range []string{"refresh_tokens", "password_resets"} {
+					if _, err := db.Collection(name).Indexes().CreateMany(ctx, []mongo.IndexModel{
+						{Keys: bson.D{{Key: "hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+						{Keys: bson.D{{Key: "user_id", Value: 1}}},
+						{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+					}); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			ID:          "0004_rate_limits_ttl",
+			Description: "rate_limits koleksiyonunda dolmuş kovaları silen TTL indeksi",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection("rate_limits").Indexes().CreateOne(ctx, mongo.IndexModel{
+					Keys:    bson.D{{Key: "expires_at", Value: 1}},
+					Options: options.Index().SetExpireAfterSeconds(0),
+				})
+				return err
+			},
+		},
+		{
+			ID:          "0005_wishlists_and_notifications",
+			Description: "wishlists kullanıcı+oyun benzersiz indeksi, oyuna göre arama ve gelen kutusu sıralama indeksleri",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				if _, err := db.Collection("wishlists").Indexes().CreateMany(ctx, []mongo.IndexModel{
+					{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "game_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+					{Keys: bson.D{{Key: "game_id", Value: 1}}},
+				}); err != nil {
+					return err
+				}
+				_, err := db.Collection("notifications").Indexes().CreateOne(ctx, mongo.IndexModel{
+					Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+				})
+				return err
+			},
+		},
+		{
+			ID:          "0006_reviews",
+			Description: "reviews ve review_votes indeksleri; elle girilmiş Rating ortalamaları yorumlardan hesaplanacağı için sıfırlanır",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				if _, err := db.Collection("reviews").Indexes().CreateMany(ctx, []mongo.IndexModel{
+					{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "game_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+					{Keys: bson.D{{Key: "game_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: -1}}},
+					{Keys: bson.D{{Key: "game_id", Value: 1}, {Key: "status", Value: 1}, {Key: "helpful_count", Value: -1}}},
+				}); err != nil {
+					return err
+				}
+				if _, err := db.Collection("review_votes").Indexes().CreateOne(ctx, mongo.IndexModel{
+					Keys:    bson.D{{Key: "review_id", Value: 1}, {Key: "user_id", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				}); err != nil {
+					return err
+				}
+				_, err := db.Collection("games").UpdateMany(ctx,
+					bson.M{"rating.score_sum": bson.M{"$exists": false}},
+					bson.M{"$unset": bson.M{"rating.average_score": "", "rating.total_reviews": "", "rating.positive_percentage": ""}})
+				return err
+			},
+		},
+		{
+			ID:          "0007_moderation_and_audit",
+			Description: "moderasyon kuyruğu, review_reports kullanıcı+yorum benzersiz indeksi ve audit_log sorgu indeksleri",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				if _, err := db.Collection("reviews").Indexes().CreateOne(ctx, mongo.IndexModel{
+					Keys: bson.D{{Key: "status", Value: 1}, {Key: "report_count", Value: -1}, {Key: "created_at", Value: 1}},
+				}); err != nil {
+					return err
+				}
+				if _, err := db.Collection("review_reports").Indexes().CreateMany(ctx, []mongo.IndexModel{
+					{Keys: bson.D{{Key: "review_id", Value: 1}, {Key: "reporter_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+					{Keys: bson.D{{Key: "review_id", Value: 1}, {Key: "created_at", Value: -1}}},
+				}); err != nil {
+					return err
+				}
+				_, err := db.Collection("audit_log").Indexes().CreateMany(ctx, []mongo.IndexModel{
+					{Keys: bson.D{{Key: "at", Value: -1}}},
+					{Keys: bson.D{{Key: "resource", Value: 1}, {Key: "resource_id", Value: 1}, {Key: "at", Value: -1}}},
+					{Keys: bson.D{{Key: "actor_id", Value: 1}, {Key: "at", Value: -1}}},
+				})
+				return err
+			},
+		},
+		{
+			ID:          "0008_library",
+			Description: "library kullanıcı+oyun benzersiz indeksi, edinme tarihine göre liste ve oyuna göre arama indeksleri",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection("library").Indexes().CreateMany(ctx, []mongo.IndexModel{
+					{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "game_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+					{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "acquired_at", Value: -1}}},
+					{Keys: bson.D{{Key: "game_id", Value: 1}}},
+				})
+				return err
+			},
+		},
+		{
+			ID:          "0009_playtime",
+			Description: "play_sessions ve play_stats indeksleri; elle girilmiş total_playtime değerleri oturumlardan hesaplanacağı için sıfırlanır",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				if _, err := db.Collection("play_sessions").Indexes().CreateOne(ctx, mongo.IndexModel{
+					Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "game_id", Value: 1}, {Key: "started_at", Value: -1}},
+					Options: options.Index().SetUnique(true),
+				}); err != nil {
+					return err
+				}
+				if _, err := db.Collection("play_stats").Indexes().CreateMany(ctx, []mongo.IndexModel{
+					{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "game_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+					{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "last_played_at", Value: -1}}},
+					{Keys: bson.D{{Key: "game_id", Value: 1}, {Key: "total_seconds", Value: 1}}},
+				}); err != nil {
+					return err
+				}
+				_, err := db.Collection("games").UpdateMany(ctx,
+					bson.M{"playtime_stats": bson.M{"$exists": false}},
+					bson.M{"$unset": bson.M{"total_playtime": ""}})
+				return err
+			},
+		},
+		{
+			ID:          "0010_achievements",
+			Description: "achievements oyun+key benzersiz indeksi; achievement_unlocks kullanıcı+başarım benzersiz indeksi ve ilerleme indeksleri",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				if _, err := db.Collection("achievements").Indexes().CreateMany(ctx, []mongo.IndexModel{
+					{Keys: bson.D{{Key: "game_id", Value: 1}, {Key: "key", Value: 1}}, Options: options.Index().SetUnique(true)},
+					{Keys: bson.D{{Key: "game_id", Value: 1}, {Key: "created_at", Value: 1}}},
+				}); err != nil {
+					return err
+				}
+				_, err := db.Collection("achievement_unlocks").Indexes().CreateMany(ctx, []mongo.IndexModel{
+					{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "achievement_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+					{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "game_id", Value: 1}, {Key: "unlocked_at", Value: 1}}},
+					{Keys: bson.D{{Key: "achievement_id", Value: 1}}},
+				})
+				return err
+			},
+		},
+		{
+			ID:          "0011_game_relations",
+			Description: "games parent_id ve bundle_items indeksleri (DLC/sürüm listesi ve oyunu içeren paketler)",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection("games").Indexes().CreateMany(ctx, []mongo.IndexModel{
+					{Keys: bson.D{{Key: "parent_id", Value: 1}}, Options: options.Index().SetSparse(true)},
+					{Keys: bson.D{{Key: "bundle_items", Value: 1}}, Options: options.Index().SetSparse(true)},
+				})
+				return err
+			},
+		},
+		{
+			ID:          "0012_similar_games",
+			Description: "similar_games oyun başına benzersiz indeks; elle girilmiş games.similar_games listeleri editör sabitlemelerine taşınır",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				similar := db.Collection("similar_games")
+				if _, err := similar.Indexes().CreateOne(ctx, mongo.IndexModel{
+					Keys:    bson.D{{Key: "game_id", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				}); err != nil {
+					return err
+				}
+				games := db.Collection("games")
+				filter := bson.M{"similar_games.0": bson.M{"$exists": true}}
+				cursor, err := games.Find(ctx, filter, options.Find().SetProjection(bson.M{"similar_games": 1}))
+				if err != nil {
+					return err
+				}
+				defer cursor.Close(ctx)
+				for cursor.Next(ctx) {
+					var row struct {
+						ID      primitive.ObjectID   `bson:"_id"`
+						Similar []primitive.ObjectID `bson:"similar_games"`
+					}
+					if err := cursor.Decode(&row); err != nil {
+						return err
+					}
+					if _, err := similar.UpdateOne(ctx, bson.M{"game_id": row.ID}, bson.M{
+						"$set":         bson.M{"pinned": row.Similar, "updated_at": time.Now()},
+						"$setOnInsert": bson.M{"computed": bson.A{}, "excluded": bson.A{}},
+					}, options.Update().SetUpsert(true)); err != nil {
+						return err
+					}
+				}
+				if err := cursor.Err(); err != nil {
+					return err
+				}
+				_, err = games.UpdateMany(ctx, bson.M{"similar_games": bson.M{"$exists": true}}, bson.M{"$unset": bson.M{"similar_games": ""}})
+				return err
+			},
+		},
+		{
+			ID:          "0013_platform_release_dates",
+			Description: "platforms.release_date metinden tarihe çevrilir (okunamayan TBA gibi değerler kaldırılır); çıkış tarihi indeksleri",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				games := db.Collection("games")
+				if _, err := games.Indexes().CreateMany(ctx, []mongo.IndexModel{
+					{Keys: bson.D{{Key: "release_date", Value: 1}}},
+					{Keys: bson.D{{Key: "platforms.release_date", Value: 1}}, Options: options.Index().SetSparse(true)},
+				}); err != nil {
+					return err
+				}
+				filter := bson.M{"platforms.release_date": bson.M{"$type": "string"}}
+				cursor, err := games.Find(ctx, filter, options.Find().SetProjection(bson.M{"platforms": 1}))
+				if err != nil {
+					return err
+				}
+				defer cursor.Close(ctx)
+				for cursor.Next(ctx) {
+					var row struct {
+						ID        primitive.ObjectID `bson:"_id"`
+						Platforms []bson.M           `bson:"platforms"`
+					}
+					if err := cursor.Decode(&row); err != nil {
+						return err
+					}
+					for _, platform := range row.Platforms {
+						value, ok := platform["release_date"].(string)
+						if !ok {
+							continue
+						}
+						if date, ok := parseLegacyDate(value); ok {
+							platform["release_date"] = date
+						} else {
+							delete(platform, "release_date")
+						}
+					}
+					if _, err := games.UpdateOne(ctx, bson.M{"_id": row.ID}, bson.M{"$set": bson.M{"platforms": row.Platforms}}); err != nil {
+						return err
+					}
+				}
+				return cursor.Err()
+			},
+		},
+		{
+			ID:          "0014_game_lifecycle",
+			Description: "erken erişimdeki oyunlar early_access durumuna taşınır; is_early_access durumdan türetilir",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				games := db.Collection("games")
+				legacy := bson.M{"is_early_access": true, "status": bson.M{"$in": bson.A{"active", "coming_soon", "", nil}}}
+				if _, err := games.UpdateMany(ctx, legacy, bson.M{"$set": bson.M{"status": "early_access"}}); err != nil {
+					return err
+				}
+				if _, err := games.UpdateMany(ctx, bson.M{"status": bson.M{"$ne": "early_access"}, "is_early_access": true}, bson.M{"$set": bson.M{"is_early_access": false}}); err != nil {
+					return err
+				}
+				_, err := games.UpdateMany(ctx, bson.M{"status": "early_access"}, bson.M{"$set": bson.M{"is_early_access": true}})
+				return err
+			},
+		},
+		{
+			ID:          "0015_game_drafts",
+			Description: "game_drafts oyun başına tek taslak ve zamanlanmış yayın indeksleri, game_revisions oyun+numara benzersiz indeksi",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				if _, err := db.Collection("game_drafts").Indexes().CreateMany(ctx, []mongo.IndexModel{
+					{Keys: bson.D{{Key: "game_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+					{Keys: bson.D{{Key: "state", Value: 1}, {Key: "publish_at", Value: 1}}},
+				}); err != nil {
+					return err
+				}
+				_, err := db.Collection("game_revisions").Indexes().CreateOne(ctx, mongo.IndexModel{
+					Keys:    bson.D{{Key: "game_id", Value: 1}, {Key: "number", Value: -1}},
+					Options: options.Index().SetUnique(true),
+				})
+				return err
+			},
+		},
+		{
+			ID:          "0016_rating_min_age",
+			Description: "rating.min_age ESRB ve PEGI derecelerinden hesaplanır; ESRB büyük harfe çevrilir",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				games := db.Collection("games")
+				filter := bson.M{"$or": bson.A{bson.M{"rating.esrb": bson.M{"$nin": bson.A{"", nil}}}, bson.M{"rating.pegi": bson.M{"$nin": bson.A{"", nil}}}}}
+				cursor, err := games.Find(ctx, filter, options.Find().SetProjection(bson.M{"rating.esrb": 1, "rating.pegi": 1}))
+				if err != nil {
+					return err
+				}
+				defer cursor.Close(ctx)
+				for cursor.Next(ctx) {
+					var row struct {
+						ID     primitive.ObjectID `bson:"_id"`
+						Rating models.Rating      `bson:"rating"`
+					}
+					if err := cursor.Decode(&row); err != nil {
+						return err
+					}
+					rating := models.Rating{ESRB: strings.ToUpper(strings.TrimSpace(row.Rating.ESRB)), PEGI: strings.TrimSpace(row.Rating.PEGI)}
+					age, _ := rating.MinimumAge()
+					set := bson.M{"rating.esrb": rating.ESRB, "rating.pegi": rating.PEGI, "rating.min_age": age}
+					if _, err := games.UpdateOne(ctx, bson.M{"_id": row.ID}, bson.M{"$set": set}); err != nil {
+						return err
+					}
+				}
+				return cursor.Err()
+			},
+		},
+		{
+			ID:          "0017_games_translation_titles",
+			Description: "games koleksiyonunda çeviri başlıkları için indeks; arama tüm dillerdeki başlıklarda yapılır",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection("games").Indexes().CreateOne(ctx, mongo.IndexModel{
+					Keys: bson.D{{Key: "translations.title", Value: 1}},
+				})
+				return err
+			},
+		},
+	}
+}
+
+// legacyDateLayouts eskiden serbest metin olarak girilen tarihlerde rastlanan biçimlerdir; gün/ay sırası belirsiz
+// olanlarda Türkiye'deki gibi gün önce kabul edilir
+var legacyDateLayouts = []string{
+	time.RFC3339, "2006-01-02T15:04:05", time.DateOnly, "2006/01/02",
+	"02.01.2006", "2.1.2006", "02/01/2006", "2/1/2006", "02-01-2006",
+	"Jan 2, 2006", "January 2, 2006", "2 Jan, 2006", "2 Jan 2006", "2 January 2006",
+}
+
+// parseLegacyDate serbest metin tarihi bilinen biçimlerden biriyle UTC olarak okur
+func parseLegacyDate(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range legacyDateLayouts {
+		if date, err := time.ParseInLocation(layout, value, time.UTC); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,164 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	lockID        = "migrations"
+	lockLease     = 5 * time.Minute        // Kilit sahibi çökerse diğer instance'lar bu süreden sonra kilidi alabilir
+	lockRetryWait = 500 * time.Millisecond // Kilit başka instance'tayken yeniden deneme aralığı
+)
+
+// Migration veritabanı şemasında (indeksler, veri dönüşümleri) bir kez uygulanacak değişikliği temsil eder
+type Migration struct {
+	ID          string                                              // Benzersiz ve sıralı kimlik (ör. "0001_games_indexes")
+	Description string                                              // Kısa açıklama
+	Up          func(ctx context.Context, db *mongo.Database) error // Değişikliği uygular, tekrar çalıştırılabilir olmalıdır
+}
+
+// appliedMigration schema_migrations koleksiyonundaki kaydı temsil eder
+type appliedMigration struct {
+	ID        string    `bson:"_id"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
+// Runner kayıtlı migration'ları sırayla uygular ve hangilerinin uygulandığını schema_migrations koleksiyonunda tutar
+type Runner struct {
+	DB         *mongo.Database
+	Migrations []Migration
+	Log        *slog.Logger
+	applied    atomic.Bool
+}
+
+// NewRunner uygulamanın tüm migration'larıyla bir Runner oluşturur
+func NewRunner(db *mongo.Database, logger *slog.Logger) *Runner {
+	return &Runner{DB: db, Migrations: All(), Log: logger}
+}
+
+// Run uygulanmamış migration'ları sırayla çalıştırır; ilk hatada durur. Aynı anda başlayan instance'lar
+// schema_migrations_lock koleksiyonundaki kiralık kilidi sırayla alır: kilidi bekleyen instance, sahibi bitirdiğinde
+// uygulanmış kayıtları görür ve yalnızca kalanları çalıştırır.
+func (r *Runner) Run(ctx context.Context) error {
+	owner, err := r.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer r.unlock(owner)
+	done, err := r.appliedIDs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, m := range r.Migrations {
+		if done[m.ID] {
+			continue
+		}
+		r.Log.InfoContext(ctx, "migration uygulanıyor", "migration", m.ID, "description", m.Description)
+		if err := m.Up(ctx, r.DB); err != nil {
+			r.Log.ErrorContext(ctx, "migration başarısız", "migration", m.ID, "error", err)
+			return err
+		}
+		// kayıt upsert edilir; kilidin süresi dolup başka bir instance da uyguladıysa kayıt zaten vardır
+		record := bson.M{"$setOnInsert": bson.M{"applied_at": time.Now()}}
+		if _, err := r.collection().UpdateByID(ctx, m.ID, record, options.Update().SetUpsert(true)); err != nil && !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+	}
+	r.applied.Store(true)
+	return nil
+}
+
+// lock kilidi alana ya da ctx bitene kadar bekler ve kilit sahibinin kimliğini döndürür. Kilit belgesi yoksa ya da
+// süresi dolmuşsa upsert onu bu instance'a verir; başka bir instance tutuyorsa upsert yinelenen anahtar hatası alır.
+func (r *Runner) lock(ctx context.Context) (string, error) {
+	owner := primitive.NewObjectID().Hex()
+	for {
+		now := time.Now()
+		filter := bson.M{"_id": lockID, "expires_at": bson.M{"$lt": now}}
+		update := bson.M{"$set": bson.M{"owner": owner, "expires_at": now.Add(lockLease)}}
+		_, err := r.DB.Collection("schema_migrations_lock").UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+		if err == nil {
+			return owner, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return "", err
+		}
+		r.Log.DebugContext(ctx, "migration kilidi başka bir instance'ta, bekleniyor")
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(lockRetryWait):
+		}
+	}
+}
+
+// unlock kilidi yalnızca hâlâ bu instance'taysa bırakır
+func (r *Runner) unlock(owner string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := r.DB.Collection("schema_migrations_lock").DeleteOne(ctx, bson.M{"_id": lockID, "owner": owner}); err != nil {
+		r.Log.WarnContext(ctx, "migration kilidi bırakılamadı; süresi dolunca serbest kalır", "error", err)
+	}
+}
+
+// Applied bu süreçte tüm migration'ların başarıyla uygulanıp uygulanmadığını döndürür
+func (r *Runner) Applied() bool {
+	return r.applied.Load()
+}
+
+// Check migration'ların uygulanıp uygulanmadığını döndürür. Bu süreçteki Run başarısız olduysa (ör. kilidi bekleyen
+// instance'ın süresi dolduysa) schema_migrations yeniden okunur; başka bir instance hepsini uyguladıysa hazır sayılır.
+func (r *Runner) Check(ctx context.Context) bool {
+	if r.applied.Load() {
+		return true
+	}
+	pending, err := r.Pending(ctx)
+	if err != nil || len(pending) > 0 {
+		return false
+	}
+	r.applied.Store(true)
+	return true
+}
+
+// Pending henüz uygulanmamış migration kimliklerini döndürür
+func (r *Runner) Pending(ctx context.Context) ([]string, error) {
+	done, err := r.appliedIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pending []string
+	for _, m := range r.Migrations {
+		if !done[m.ID] {
+			pending = append(pending, m.ID)
+		}
+	}
+	return pending, nil
+}
+
+func (r *Runner) appliedIDs(ctx context.Context) (map[string]bool, error) {
+	cursor, err := r.collection().Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	var records []appliedMigration
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+	done := make(map[string]bool, len(records))
+	for _, rec := range records {
+		done[rec.ID] = true
+	}
+	return done, nil
+}
+
+func (r *Runner) collection() *mongo.Collection {
+	return r.DB.Collection("schema_migrations")
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Achievement bir oyunun başarım tanımıdır; key oyun içinde benzersizdir
+type Achievement struct {
+	ID            primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`                  // Benzersiz tanımlayıcı
+	GameID        primitive.ObjectID `json:"game_id" bson:"game_id"`                             // Başarımın ait olduğu oyun
+	Key           string             `json:"key" bson:"key"`                                     // İstemcilerin kullandığı sabit anahtar (ör. "first_blood")
+	Name          string             `json:"name" bson:"name"`                                   // Görünen ad
+	Description   string             `json:"description,omitempty" bson:"description,omitempty"` // Açıklama
+	IconURL       string             `json:"icon_url,omitempty" bson:"icon_url,omitempty"`       // Simge
+	Hidden        bool               `json:"hidden" bson:"hidden"`                               // Açılana kadar adı ve açıklaması gizlenir
+	Points        int                `json:"points" bson:"points"`                               // Başarım puanı
+	UnlockCount   int64              `json:"unlock_count" bson:"unlock_count"`                   // Başarımı açan kullanıcı sayısı
+	UnlockPercent float64            `json:"unlock_percent" bson:"-"`                            // Oyunun sahipleri arasında açanların yüzdesi; okunurken hesaplanır
+	CreatedAt     time.Time          `json:"created_at" bson:"created_at"`                       // Oluşturulma tarihi
+	UpdatedAt     time.Time          `json:"updated_at" bson:"updated_at"`                       // Son güncelleme tarihi
+}
+
+// AchievementUnlock bir kullanıcının bir başarımı açtığı kayıttır
+type AchievementUnlock struct {
+	ID            primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	UserID        primitive.ObjectID `json:"user_id" bson:"user_id"`
+	GameID        primitive.ObjectID `json:"game_id" bson:"game_id"`
+	AchievementID primitive.ObjectID `json:"achievement_id" bson:"achievement_id"`
+	Key           string             `json:"key" bson:"key"`
+	UnlockedAt    time.Time          `json:"unlocked_at" bson:"unlocked_at"` // Oyunda açıldığı zaman
+	ReceivedAt    time.Time          `json:"received_at" bson:"received_at"` // Sunucuya ulaştığı zaman
+}
@@ -0,0 +1,35 @@
+package models
+
+import "strings"
+
+// PEGIAges PEGI derecelerinin karşılık geldiği en düşük yaştır
+var PEGIAges = map[string]int{"3": 3, "7": 7, "12": 12, "16": 16, "18": 18}
+
+// ESRBAges ESRB derecelerinin karşılık geldiği en düşük yaştır; RP (derecelendirme bekleniyor) yaş belirtmez
+var ESRBAges = map[string]int{"EC": 3, "E": 6, "E10+": 10, "T": 13, "M": 17, "AO": 18}
+
+// ContentSettings kullanıcının görmek istediği en yüksek yaş derecelerini tutar; boş alan sınır yok demektir
+type ContentSettings struct {
+	MaxPEGI string `json:"max_pegi,omitempty" bson:"max_pegi,omitempty"` // PEGI sınırı (3, 7, 12, 16, 18)
+	MaxESRB string `json:"max_esrb,omitempty" bson:"max_esrb,omitempty"` // ESRB sınırı (EC, E, E10+, T, M, AO)
+}
+
+// MaxAge ayarlardaki sınırların en kısıtlayıcısını yaş olarak döndürür; sınır yoksa ok=false döner
+func (c ContentSettings) MaxAge() (age int, ok bool) {
+	for _, limit := range []int{PEGIAges[strings.TrimSpace(c.MaxPEGI)], ESRBAges[strings.ToUpper(strings.TrimSpace(c.MaxESRB))]} {
+		if limit > 0 && (!ok || limit < age) {
+			age, ok = limit, true
+		}
+	}
+	return age, ok
+}
+
+// MinimumAge oyunun PEGI ve ESRB derecelerinden yüksek olanını yaş olarak döndürür; derece yoksa ok=false döner
+func (r Rating) MinimumAge() (age int, ok bool) {
+	for _, a := range []int{PEGIAges[strings.TrimSpace(r.PEGI)], ESRBAges[strings.ToUpper(strings.TrimSpace(r.ESRB))]} {
+		if a > 0 && a > age {
+			age, ok = a, true
+		}
+	}
+	return age, ok
+}
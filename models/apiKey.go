@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// APIKey servisler arası çağrılar için verilen anahtarı temsil eder; anahtarın kendisi saklanmaz, yalnızca SHA-256 özeti tutulur
+type APIKey struct {
+	ID         primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`                    // Benzersiz tanımlayıcı
+	Name       string             `json:"name" bson:"name"`                                     // Anahtarı kullanan servisin adı
+	Prefix     string             `json:"prefix" bson:"prefix"`                                 // Anahtarın aranmasında kullanılan açık kısım
+	Hash       string             `json:"-" bson:"hash"`                                        // Anahtarın SHA-256 özeti (JSON'da gösterilmez)
+	Roles      []string           `json:"roles,omitempty" bson:"roles,omitempty"`               // Anahtara verilen roller
+	CreatedBy  string             `json:"created_by,omitempty" bson:"created_by,omitempty"`     // Anahtarı oluşturan kullanıcı
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`                         // Oluşturulma tarihi
+	LastUsedAt time.Time          `json:"last_used_at,omitempty" bson:"last_used_at,omitempty"` // Son kullanım tarihi
+	Revoked    bool               `json:"revoked" bson:"revoked"`                               // İptal edildi mi?
+}
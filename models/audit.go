@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuditEntry yönetimsel bir işlemin kaydıdır; kim, ne zaman, neyi, neden değiştirdi
+type AuditEntry struct {
+	ID         primitive.ObjectID     `json:"id,omitempty" bson:"_id,omitempty"` // Benzersiz tanımlayıcı
+	At         time.Time              `json:"at" bson:"at"`                      // İşlem zamanı
+	ActorID    string                 `json:"actor_id" bson:"actor_id"`          // İşlemi yapan kullanıcı/API anahtarı ID'si veya "system"
+	ActorKind  string                 `json:"actor_kind" bson:"actor_kind"`      // user, service veya system
+	ActorName  string                 `json:"actor_name,omitempty" bson:"actor_name,omitempty"`
+	Action     string                 `json:"action" bson:"action"`                       // Ör. review.approve, review.hide
+	Resource   string                 `json:"resource" bson:"resource"`                   // Ör. review, game
+	ResourceID string                 `json:"resource_id" bson:"resource_id"`             // Etkilenen kaydın ID'si
+	Reason     string                 `json:"reason,omitempty" bson:"reason,omitempty"`   // İşlemin gerekçesi
+	Details    map[string]interface{} `json:"details,omitempty" bson:"details,omitempty"` // Önceki/sonraki durum gibi ek bilgiler
+	RequestID  string                 `json:"request_id,omitempty" bson:"request_id,omitempty"`
+}
+
+// ReviewReport bir kullanıcının bir yorum hakkındaki şikayetidir
+type ReviewReport struct {
+	ID         primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	ReviewID   primitive.ObjectID `json:"review_id" bson:"review_id"`
+	ReporterID primitive.ObjectID `json:"reporter_id" bson:"reporter_id"`
+	Reason     string             `json:"reason" bson:"reason"`                       // spam, offensive, spoiler, off_topic, other
+	Comment    string             `json:"comment,omitempty" bson:"comment,omitempty"` // Serbest açıklama
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
+	ResolvedAt *time.Time         `json:"resolved_at,omitempty" bson:"resolved_at,omitempty"` // Moderatör yorum hakkında karar verdiğinde dolar
+}
+
+// ReportReasons kabul edilen şikayet nedenleridir
+var ReportReasons = map[string]bool{"spam": true, "offensive": true, "spoiler": true, "off_topic": true, "other": true}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// Vitrin grafikleri
+const (
+	ChartTopSellers   = "top-sellers"   // Son 7 günün satın almaları
+	ChartTopRated     = "top-rated"     // Bayes ağırlıklı ortalama puan
+	ChartMostReviewed = "most-reviewed" // Toplam yorum sayısı
+	ChartTrending     = "trending"      // Son 7 günün yorum ve istek listesi hızı
+	ChartNewReleases  = "new-releases"  // Son çıkan oyunlar
+	ChartUpcoming     = "upcoming"      // Yakında çıkacak oyunlar
+	ChartDiscounts    = "discounts"     // En yüksek indirimler
+)
+
+// ChartNames grafiklerin listelenme sırasıdır
+var ChartNames = []string{ChartTopSellers, ChartTopRated, ChartMostReviewed, ChartTrending, ChartNewReleases, ChartUpcoming, ChartDiscounts}
+
+// Chart zamanlanmış işin hesapladığı sıralı bir oyun listesidir
+type Chart struct {
+	Name        string       `json:"name"`
+	GeneratedAt time.Time    `json:"generated_at"` // Listenin hesaplandığı zaman
+	Entries     []ChartEntry `json:"entries"`
+}
+
+// ChartEntry grafikteki bir oyundur
+type ChartEntry struct {
+	Rank  int         `json:"rank"`
+	Game  RelatedGame `json:"game"`
+	Score float64     `json:"score"` // Sıralamada kullanılan değer (puan, yorum sayısı, indirim oranı vb.)
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidReleaseDate = errors.New("çıkış tarihi RFC3339 veya YYYY-MM-DD biçiminde olmalıdır")
+
+// ParseReleaseDate çıkış tarihini RFC3339 veya YYYY-MM-DD (UTC gün başı) olarak okur
+func ParseReleaseDate(value string) (time.Time, error) {
+	if date, err := time.Parse(time.RFC3339, value); err == nil {
+		return date, nil
+	}
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidReleaseDate, value)
+	}
+	return date, nil
+}
+
+// ReleaseDate JSON'dan RFC3339 ya da YYYY-MM-DD olarak okunan çıkış tarihidir; POST, PUT, PATCH ve taslaklar aynı
+// biçimleri kabul etsin diye Game ve Platform release_date alanlarını bu türle çözer. null ve boş metin tarihi siler.
+type ReleaseDate time.Time
+
+// UnmarshalJSON tarihi ParseReleaseDate ile okur
+func (d *ReleaseDate) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = ReleaseDate{}
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidReleaseDate, data)
+	}
+	if value == "" {
+		*d = ReleaseDate{}
+		return nil
+	}
+	date, err := ParseReleaseDate(value)
+	if err != nil {
+		return err
+	}
+	*d = ReleaseDate(date)
+	return nil
+}
+
+// UnmarshalJSON release_date alanını ReleaseDate ile çözer; diğer alanlar varsayılan kurallarla okunur
+func (g *Game) UnmarshalJSON(data []byte) error {
+	type game Game // UnmarshalJSON'u taşımayan kopya, aksi halde sonsuz özyineleme olur
+	aux := struct {
+		*game
+		ReleaseDate ReleaseDate `json:"release_date"`
+	}{game: (*game)(g), ReleaseDate: ReleaseDate(g.ReleaseDate)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	g.ReleaseDate = time.Time(aux.ReleaseDate)
+	return nil
+}
+
+// UnmarshalJSON release_date alanını ReleaseDate ile çözer; gövdede olmayan alanlar p'deki değerlerini korur
+func (p *Platform) UnmarshalJSON(data []byte) error {
+	type platform Platform
+	aux := struct {
+		*platform
+		ReleaseDate ReleaseDate `json:"release_date"`
+	}{platform: (*platform)(p), ReleaseDate: ReleaseDate(p.ReleaseDate)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	p.ReleaseDate = time.Time(aux.ReleaseDate)
+	return nil
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGameReleaseDateJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    time.Time
+		wantErr bool
+	}{
+		{"RFC3339", `{"title":"x","release_date":"2026-03-01T10:00:00Z"}`, time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC), false},
+		{"yalnızca gün", `{"title":"x","release_date":"2026-03-01"}`, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{"boş", `{"title":"x","release_date":""}`, time.Time{}, false},
+		{"null", `{"title":"x","release_date":null}`, time.Time{}, false},
+		{"yok", `{"title":"x"}`, time.Time{}, false},
+		{"geçersiz biçim", `{"release_date":"01.03.2026"}`, time.Time{}, true},
+		{"sayı", `{"release_date":20260301}`, time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var game Game
+			err := json.Unmarshal([]byte(tt.body), &game)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidReleaseDate) {
+					t.Fatalf("hata = %v, beklenen ErrInvalidReleaseDate", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("beklenmeyen hata: %v", err)
+			}
+			if !game.ReleaseDate.Equal(tt.want) || game.Title != "x" {
+				t.Errorf("oyun = %q %v, beklenen x %v", game.Title, game.ReleaseDate, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlatformJSONMergesIntoExisting(t *testing.T) {
+	date := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		body string
+		want Platform
+	}{
+		{"yalnızca gereksinim", `{"name":"PC","requirements":"8 GB"}`, Platform{Name: "PC", ReleaseDate: date, Requirements: "8 GB"}},
+		{"yalnızca gün", `{"name":"PC","release_date":"2026-06-01"}`, Platform{Name: "PC", ReleaseDate: date.AddDate(0, 1, 0), Requirements: "4 GB"}},
+		{"tarih silinir", `{"name":"PC","release_date":null}`, Platform{Name: "PC", Requirements: "4 GB"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			platform := Platform{Name: "PC", ReleaseDate: date, Requirements: "4 GB"}
+			if err := json.Unmarshal([]byte(tt.body), &platform); err != nil {
+				t.Fatalf("beklenmeyen hata: %v", err)
+			}
+			if platform.Name != tt.want.Name || !platform.ReleaseDate.Equal(tt.want.ReleaseDate) || platform.Requirements != tt.want.Requirements {
+				t.Errorf("platform = %+v, beklenen %+v", platform, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Taslak durumları
+const (
+	DraftEditing    = "editing"    // Serbestçe düzenlenebilir
+	DraftInReview   = "in_review"  // İncelemeye gönderildi; düzenlenirse yeniden editing olur
+	DraftApproved   = "approved"   // Başka bir kullanıcı onayladı, yayınlanabilir
+	DraftScheduled  = "scheduled"  // Onaylandı, PublishAt zamanında yayınlanacak
+	DraftPublishing = "publishing" // Yayınlanıyor; aynı taslağın iki kez yayınlanmasını önler
+)
+
+// GameDraft canlı oyunun editörlerin üzerinde çalıştığı kopyasıdır; her oyunun en fazla bir taslağı olur
+type GameDraft struct {
+	ID          primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	GameID      primitive.ObjectID `json:"game_id" bson:"game_id"`
+	Game        Game               `json:"game" bson:"game"`                                     // Taslak içerik
+	State       string             `json:"state" bson:"state"`                                   // editing, in_review, approved, scheduled, publishing
+	BaseVersion time.Time          `json:"base_version" bson:"base_version"`                     // Taslak oluşturulduğunda canlı oyunun updated_at değeri
+	CreatedBy   string             `json:"created_by,omitempty" bson:"created_by,omitempty"`     // Kullanıcı/API anahtarı ID'si
+	UpdatedBy   string             `json:"updated_by,omitempty" bson:"updated_by,omitempty"`     // Son düzenleyen
+	SubmittedBy string             `json:"submitted_by,omitempty" bson:"submitted_by,omitempty"` // İncelemeye gönderen
+	ApprovedBy  string             `json:"approved_by,omitempty" bson:"approved_by,omitempty"`   // Onaylayan; düzenleyen ve gönderen olamaz
+	ReviewNote  string             `json:"review_note,omitempty" bson:"review_note,omitempty"`   // Reddetme gerekçesi
+	PublishAt   *time.Time         `json:"publish_at,omitempty" bson:"publish_at,omitempty"`     // Zamanlanmış yayın zamanı
+	SubmittedAt *time.Time         `json:"submitted_at,omitempty" bson:"submitted_at,omitempty"`
+	ApprovedAt  *time.Time         `json:"approved_at,omitempty" bson:"approved_at,omitempty"`
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// GameRevision yayınlanan bir taslağın kaydıdır; canlı oyunun yayından önceki ve sonraki halini taşır
+type GameRevision struct {
+	ID          primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	GameID      primitive.ObjectID `json:"game_id" bson:"game_id"`
+	Number      int                `json:"number" bson:"number"` // Oyun başına 1'den artan sıra
+	DraftID     primitive.ObjectID `json:"draft_id" bson:"draft_id"`
+	Changes     []string           `json:"changes" bson:"changes"` // Değişen üst düzey alanlar (JSON adlarıyla)
+	Before      Game               `json:"before" bson:"before"`
+	After       Game               `json:"after" bson:"after"`
+	EditedBy    string             `json:"edited_by,omitempty" bson:"edited_by,omitempty"`
+	ApprovedBy  string             `json:"approved_by,omitempty" bson:"approved_by,omitempty"`
+	PublishedBy string             `json:"published_by,omitempty" bson:"published_by,omitempty"` // Zamanlanmış yayınlarda "system"
+	PublishedAt time.Time          `json:"published_at" bson:"published_at"`
+}
@@ -0,0 +1,35 @@
+package models
+
+// İşletim sistemi aileleri
+const (
+	OSWindows = "windows"
+	OSMacOS   = "macos"
+	OSLinux   = "linux"
+)
+
+// Uyumluluk sonuçları
+const (
+	VerdictBelowMinimum     = "below_minimum"     // En az bir bileşen minimum gereksinimin altında
+	VerdictMeetsMinimum     = "meets_minimum"     // Minimum karşılanıyor, önerilen karşılanmıyor ya da belirtilmemiş
+	VerdictMeetsRecommended = "meets_recommended" // Önerilen gereksinimler karşılanıyor
+)
+
+// HardwareSpec bir gereksinim setinin ya da bir bilgisayarın karşılaştırılabilir halidir. İşlemci ve ekran kartı
+// donanım tablosundaki seviyeleriyle karşılaştırılır; sıfır değerler bilinmiyor demektir.
+type HardwareSpec struct {
+	OS        string  `json:"os,omitempty" bson:"os,omitempty"`                 // İşletim sistemi ailesi: windows, macos, linux
+	CPU       string  `json:"cpu,omitempty" bson:"cpu,omitempty"`               // Donanım tablosunda eşleşen işlemci
+	CPUTier   int     `json:"cpu_tier,omitempty" bson:"cpu_tier,omitempty"`     // İşlemcinin tablodaki seviyesi
+	GPU       string  `json:"gpu,omitempty" bson:"gpu,omitempty"`               // Donanım tablosunda eşleşen ekran kartı
+	GPUTier   int     `json:"gpu_tier,omitempty" bson:"gpu_tier,omitempty"`     // Ekran kartının tablodaki seviyesi
+	MemoryGB  float64 `json:"memory_gb,omitempty" bson:"memory_gb,omitempty"`   // RAM (GB)
+	StorageGB float64 `json:"storage_gb,omitempty" bson:"storage_gb,omitempty"` // Boş disk alanı (GB)
+	DirectX   int     `json:"directx,omitempty" bson:"directx,omitempty"`       // DirectX ana sürümü
+}
+
+// Shortfall gereksinimi karşılamayan bir bileşendir
+type Shortfall struct {
+	Component string `json:"component"` // os, cpu, gpu, memory, storage, directx
+	Required  string `json:"required"`
+	Have      string `json:"have"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Oyunun kütüphaneye giriş yolları
+const (
+	AcquiredPurchase = "purchase" // Satın alma
+	AcquiredGift     = "gift"     // Başka bir kullanıcıdan hediye
+	AcquiredKey      = "key"      // Ürün anahtarı kullanımı
+)
+
+// AcquisitionSources geçerli edinme yollarıdır
+var AcquisitionSources = map[string]bool{
+	AcquiredPurchase: true,
+	AcquiredGift:     true,
+	AcquiredKey:      true,
+}
+
+// LibraryEntry bir kullanıcının sahip olduğu bir oyunun kaydıdır; kullanıcı başına oyun başına bir tane olabilir
+type LibraryEntry struct {
+	ID         primitive.ObjectID  `json:"id,omitempty" bson:"_id,omitempty"`              // Benzersiz tanımlayıcı
+	UserID     primitive.ObjectID  `json:"user_id" bson:"user_id"`                         // Sahip
+	GameID     primitive.ObjectID  `json:"game_id" bson:"game_id"`                         // Sahip olunan oyun (models.Game ID'si)
+	Source     string              `json:"source" bson:"source"`                           // purchase, gift veya key
+	AcquiredAt time.Time           `json:"acquired_at" bson:"acquired_at"`                 // Edinme tarihi
+	PricePaid  float64             `json:"price_paid" bson:"price_paid"`                   // Ödenen tutar (hediye ve anahtarda genelde 0)
+	Currency   string              `json:"currency,omitempty" bson:"currency,omitempty"`   // Ödenen tutarın para birimi
+	GiftedBy   *primitive.ObjectID `json:"gifted_by,omitempty" bson:"gifted_by,omitempty"` // Hediye eden kullanıcı
+	OrderRef   string              `json:"order_ref,omitempty" bson:"order_ref,omitempty"` // Ödeme sistemi veya anahtar dağıtıcısındaki referans
+	CreatedAt  time.Time           `json:"created_at" bson:"created_at"`                   // Kaydın oluşturulma tarihi
+	Game       *LibraryGame        `json:"game,omitempty" bson:"-"`                        // Oyunun güncel başlığı ve kapağı; okunurken doldurulur
+}
+
+// LibraryGame kütüphane listesinde gösterilen güncel oyun özetidir
+type LibraryGame struct {
+	Title        string `json:"title"`
+	CoverImage   string `json:"cover_image,omitempty"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	Status       string `json:"status,omitempty"`
+}
@@ -0,0 +1,78 @@
+package models
+
+import "time"
+
+// Oyun yaşam döngüsü durumları
+const (
+	GameStatusDraft       = "draft"        // Katalogda görünmez, düzenleniyor
+	GameStatusInReview    = "in_review"    // Yayına alınmadan önce inceleniyor
+	GameStatusComingSoon  = "coming_soon"  // Mağazada görünür, henüz satın alınamaz
+	GameStatusEarlyAccess = "early_access" // Erken erişimde satışta
+	GameStatusActive      = "active"       // Tam sürüm satışta
+	GameStatusDelisted    = "delisted"     // Mağazadan kaldırıldı, sahipleri oynamaya devam eder
+	GameStatusRemoved     = "removed"      // Tamamen kaldırıldı
+)
+
+// GameStatuses durumların yaşam döngüsündeki sırasıdır
+var GameStatuses = []string{GameStatusDraft, GameStatusInReview, GameStatusComingSoon, GameStatusEarlyAccess, GameStatusActive, GameStatusDelisted, GameStatusRemoved}
+
+// StatusTransitions her durumdan geçilebilecek durumlardır; removed son durumdur
+var StatusTransitions = map[string][]string{
+	GameStatusDraft:       {GameStatusInReview, GameStatusRemoved},
+	GameStatusInReview:    {GameStatusDraft, GameStatusComingSoon, GameStatusEarlyAccess, GameStatusActive, GameStatusRemoved},
+	GameStatusComingSoon:  {GameStatusEarlyAccess, GameStatusActive, GameStatusDelisted, GameStatusRemoved},
+	GameStatusEarlyAccess: {GameStatusActive, GameStatusDelisted, GameStatusRemoved},
+	GameStatusActive:      {GameStatusDelisted, GameStatusRemoved},
+	GameStatusDelisted:    {GameStatusActive, GameStatusRemoved},
+	GameStatusRemoved:     {},
+}
+
+// IsGameStatus durumun yaşam döngüsünde tanımlı olup olmadığını döndürür
+func IsGameStatus(status string) bool {
+	_, ok := StatusTransitions[status]
+	return ok
+}
+
+// CanTransition from durumundan to durumuna geçilip geçilemeyeceğini döndürür. Yaşam döngüsünden önce yazılmış
+// tanımsız durumlardan (boş dahil) removed dışındaki her duruma geçilebilir, böylece eski kayıtlar düzeltilebilir.
+func CanTransition(from, to string) bool {
+	if !IsGameStatus(to) || from == to {
+		return false
+	}
+	allowed, ok := StatusTransitions[from]
+	if !ok {
+		return true
+	}
+	for _, status := range allowed {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// StorefrontStatuses mağazada listelenen durumlardır
+var StorefrontStatuses = []string{GameStatusComingSoon, GameStatusEarlyAccess, GameStatusActive}
+
+// IsStorefrontVisible oyunun herkese açık listelerde görünüp görünmeyeceğini döndürür. Durumu hiç yazılmamış eski kayıtlar
+// yaşam döngüsünden önce de listelendiği için görünür kabul edilir.
+func IsStorefrontVisible(status string) bool {
+	if status == "" {
+		return true
+	}
+	for _, visible := range StorefrontStatuses {
+		if status == visible {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusChange oyunun bir durum geçişinin kaydıdır
+type StatusChange struct {
+	From   string    `json:"from,omitempty" bson:"from,omitempty"` // İlk kayıtta boştur
+	To     string    `json:"to" bson:"to"`
+	At     time.Time `json:"at" bson:"at"`
+	By     string    `json:"by,omitempty" bson:"by,omitempty"`         // Geçişi yapan kullanıcı/API anahtarı ID'si
+	Reason string    `json:"reason,omitempty" bson:"reason,omitempty"` // Geçişin gerekçesi
+}
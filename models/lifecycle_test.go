@@ -0,0 +1,73 @@
+package models
+
+import "testing"
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{GameStatusDraft, GameStatusInReview, true},
+		{GameStatusDraft, GameStatusActive, false},
+		{GameStatusDraft, GameStatusRemoved, true},
+		{GameStatusInReview, GameStatusDraft, true},
+		{GameStatusInReview, GameStatusEarlyAccess, true},
+		{GameStatusComingSoon, GameStatusDraft, false},
+		{GameStatusEarlyAccess, GameStatusActive, true},
+		{GameStatusActive, GameStatusEarlyAccess, false},
+		{GameStatusActive, GameStatusDelisted, true},
+		{GameStatusDelisted, GameStatusActive, true},
+		{GameStatusRemoved, GameStatusActive, false},
+		{GameStatusRemoved, GameStatusDraft, false},
+		{GameStatusActive, GameStatusActive, false},
+		{GameStatusActive, "archived", false},
+		{"", GameStatusActive, true},
+		{"", GameStatusRemoved, true},
+		{"eski", GameStatusDraft, true},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+"->"+tt.to, func(t *testing.T) {
+			if got := CanTransition(tt.from, tt.to); got != tt.want {
+				t.Errorf("CanTransition(%q, %q) = %v, beklenen %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusTransitionsCoverLifecycle(t *testing.T) {
+	for _, status := range GameStatuses {
+		if !IsGameStatus(status) {
+			t.Errorf("%s için geçiş tanımı yok", status)
+		}
+		for _, to := range StatusTransitions[status] {
+			if !IsGameStatus(to) {
+				t.Errorf("%s -> %s tanımsız bir duruma geçiyor", status, to)
+			}
+		}
+	}
+}
+
+func TestIsStorefrontVisible(t *testing.T) {
+	tests := []struct {
+		status string
+		want   bool
+	}{
+		{"", true},
+		{GameStatusDraft, false},
+		{GameStatusInReview, false},
+		{GameStatusComingSoon, true},
+		{GameStatusEarlyAccess, true},
+		{GameStatusActive, true},
+		{GameStatusDelisted, false},
+		{GameStatusRemoved, false},
+		{"bilinmeyen", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			if got := IsStorefrontVisible(tt.status); got != tt.want {
+				t.Errorf("IsStorefrontVisible(%q) = %v, beklenen %v", tt.status, got, tt.want)
+			}
+		})
+	}
+}
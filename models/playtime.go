@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PlaySession istemciden (launcher, oyun) gelen tek bir oynama oturumudur
+type PlaySession struct {
+	ID              primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`        // Benzersiz tanımlayıcı
+	UserID          primitive.ObjectID `json:"user_id" bson:"user_id"`                   // Oynayan kullanıcı
+	GameID          primitive.ObjectID `json:"game_id" bson:"game_id"`                   // Oynanan oyun
+	StartedAt       time.Time          `json:"started_at" bson:"started_at"`             // Oturum başlangıcı
+	EndedAt         time.Time          `json:"ended_at" bson:"ended_at"`                 // Oturum bitişi
+	DurationSeconds int64              `json:"duration_seconds" bson:"duration_seconds"` // Oturum süresi (saniye)
+	ReceivedAt      time.Time          `json:"received_at" bson:"received_at"`           // Sunucuya ulaştığı zaman
+}
+
+// PlayStats bir kullanıcının bir oyundaki toplam oynama istatistiğidir; her oturumla artımlı güncellenir
+type PlayStats struct {
+	UserID        primitive.ObjectID `json:"user_id" bson:"user_id"`                 // Kullanıcı
+	GameID        primitive.ObjectID `json:"game_id" bson:"game_id"`                 // Oyun
+	TotalSeconds  int64              `json:"total_seconds" bson:"total_seconds"`     // Toplam oynama süresi (saniye)
+	SessionCount  int                `json:"session_count" bson:"session_count"`     // Oturum sayısı
+	FirstPlayedAt time.Time          `json:"first_played_at" bson:"first_played_at"` // İlk oturumun başlangıcı
+	LastPlayedAt  time.Time          `json:"last_played_at" bson:"last_played_at"`   // Son oturumun bitişi
+	Game          *LibraryGame       `json:"game,omitempty" bson:"-"`                // Oyunun güncel başlığı ve kapağı; okunurken doldurulur
+}
+
+// PlaytimeStats oyunun oyuncu verilerinden hesaplanan oynama süreleridir
+type PlaytimeStats struct {
+	AverageMinutes int       `json:"average_minutes" bson:"average_minutes"` // Oyuncu başına ortalama toplam süre (dakika)
+	MedianMinutes  int       `json:"median_minutes" bson:"median_minutes"`   // Oyuncu başına medyan toplam süre (dakika)
+	Players        int       `json:"players" bson:"players"`                 // En az bir oturumu olan oyuncu sayısı
+	ComputedAt     time.Time `json:"computed_at" bson:"computed_at"`         // Son hesaplama zamanı
+}
@@ -14,20 +14,21 @@ import (
 
 // Platform, oyunun çalıştığı platformları temsil eder
 type Platform struct {
-	Name         string `json:"name" bson:"name"`                                     // Platform adı (PC, PS5, Xbox Series X, vb.)
-	ReleaseDate  string `json:"release_date,omitempty" bson:"release_date,omitempty"` // Bu platformda çıkış tarihi
-	Requirements string `json:"requirements,omitempty" bson:"requirements,omitempty"` // Platform gereksinimleri
+	Name         string    `json:"name" bson:"name"`                                     // Platform adı (PC, PS5, Xbox Series X, vb.)
+	ReleaseDate  time.Time `json:"release_date,omitempty" bson:"release_date,omitempty"` // Bu platformda çıkış tarihi; boşsa oyunun çıkış tarihi geçerlidir
+	Requirements string    `json:"requirements,omitempty" bson:"requirements,omitempty"` // Platform gereksinimleri
 }
 
 // Requirements, belirli bir donanım gereksinim setini temsil eder
 type Requirements struct {
-	OS              string `json:"os,omitempty" bson:"os,omitempty"`                             // İşletim sistemi gereksinimleri
-	Processor       string `json:"processor,omitempty" bson:"processor,omitempty"`               // İşlemci gereksinimleri
-	Memory          string `json:"memory,omitempty" bson:"memory,omitempty"`                     // Bellek gereksinimleri
-	Graphics        string `json:"graphics,omitempty" bson:"graphics,omitempty"`                 // Ekran kartı gereksinimleri
-	DirectX         string `json:"directx,omitempty" bson:"directx,omitempty"`                   // DirectX gereksinimleri
-	Storage         string `json:"storage,omitempty" bson:"storage,omitempty"`                   // Depolama gereksinimleri
-	AdditionalNotes string `json:"additional_notes,omitempty" bson:"additional_notes,omitempty"` // Ek notlar
+	OS              string        `json:"os,omitempty" bson:"os,omitempty"`                             // İşletim sistemi gereksinimleri
+	Processor       string        `json:"processor,omitempty" bson:"processor,omitempty"`               // İşlemci gereksinimleri
+	Memory          string        `json:"memory,omitempty" bson:"memory,omitempty"`                     // Bellek gereksinimleri
+	Graphics        string        `json:"graphics,omitempty" bson:"graphics,omitempty"`                 // Ekran kartı gereksinimleri
+	DirectX         string        `json:"directx,omitempty" bson:"directx,omitempty"`                   // DirectX gereksinimleri
+	Storage         string        `json:"storage,omitempty" bson:"storage,omitempty"`                   // Depolama gereksinimleri
+	AdditionalNotes string        `json:"additional_notes,omitempty" bson:"additional_notes,omitempty"` // Ek notlar
+	Spec            *HardwareSpec `json:"spec,omitempty" bson:"spec,omitempty"`                         // Yukarıdaki metinlerden hesaplanır; istekle değiştirilemez
 }
 
 // SystemRequirements, oyunun sistem gereksinimlerini temsil eder
@@ -89,6 +90,9 @@ type Rating struct {
 	PositivePercentage int     `json:"positive_percentage,omitempty" bson:"positive_percentage,omitempty"` // Olumlu değerlendirme yüzdesi
 	ESRB               string  `json:"esrb,omitempty" bson:"esrb,omitempty"`                               // ESRB derecesi (E, T, M, vb.)
 	PEGI               string  `json:"pegi,omitempty" bson:"pegi,omitempty"`                               // PEGI derecesi (3, 7, 12, 16, 18)
+	MinAge             int     `json:"min_age,omitempty" bson:"min_age,omitempty"`                         // ESRB ve PEGI'den hesaplanan yaş sınırı (yüksek olanı)
+	ScoreSum           float64 `json:"-" bson:"score_sum,omitempty"`                                       // Yorum puanlarının toplamı (artımlı ortalama için)
+	PositiveCount      int     `json:"-" bson:"positive_count,omitempty"`                                  // Öneren yorum sayısı (artımlı yüzde için)
 }
 
 // Game, API'deki ana oyun verisini temsil eder
@@ -109,11 +113,37 @@ type Game struct {
 	Rating           Rating               `json:"rating,omitempty" bson:"rating,omitempty"`                           // Değerlendirme bilgileri
 	Features         []string             `json:"features,omitempty" bson:"features,omitempty"`                       // Özellikler (çok oyunculu, bulut kaydetme, vb.)
 	Languages        []string             `json:"languages,omitempty" bson:"languages,omitempty"`                     // Desteklenen diller
-	IsEarlyAccess    bool                 `json:"is_early_access" bson:"is_early_access"`                             // Erken erişimde mi?
+	IsEarlyAccess    bool                 `json:"is_early_access" bson:"is_early_access"`                             // Erken erişimde mi? Durumdan türetilir (early_access)
 	IsMultiplayer    bool                 `json:"is_multiplayer" bson:"is_multiplayer"`                               // Çok oyunculu mu?
-	TotalPlayTime    int                  `json:"total_playtime,omitempty" bson:"total_playtime,omitempty"`           // Ortalama oynanış süresi (dakika)
-	SimilarGames     []primitive.ObjectID `json:"similar_games,omitempty" bson:"similar_games,omitempty"`             // Benzer oyunların ID'leri
+	TotalPlayTime    int                  `json:"total_playtime,omitempty" bson:"total_playtime,omitempty"`           // Ortalama oynanış süresi (dakika); oturum verilerinden periyodik olarak hesaplanır
+	PlaytimeStats    *PlaytimeStats       `json:"playtime_stats,omitempty" bson:"playtime_stats,omitempty"`           // Oynanış süresi istatistikleri (ortalama, medyan, oyuncu sayısı)
+	Achievements     []Achievement        `json:"achievements,omitempty" bson:"-"`                                    // Başarımlar ve açılma yüzdeleri; oyun detayında doldurulur
+	Kind             string               `json:"kind,omitempty" bson:"kind,omitempty"`                               // Katalog öğesi türü (game, dlc, edition, bundle); boşsa game
+	ParentID         *primitive.ObjectID  `json:"parent_id,omitempty" bson:"parent_id,omitempty"`                     // DLC ve sürümlerin bağlı olduğu ana oyun
+	BundleItems      []primitive.ObjectID `json:"bundle_items,omitempty" bson:"bundle_items,omitempty"`               // Paketin içerdiği oyunlar
+	BundlePricing    *BundlePricing       `json:"bundle_pricing,omitempty" bson:"-"`                                  // Paket fiyat dökümü; oyun detayında hesaplanır
+	DLCs             []RelatedGame        `json:"dlcs,omitempty" bson:"-"`                                            // Ana oyunun DLC'leri; ?include=dlcs ile doldurulur
+	Editions         []RelatedGame        `json:"editions,omitempty" bson:"-"`                                        // Ana oyunun sürümleri; ?include=editions ile doldurulur
 	CreatedAt        time.Time            `json:"created_at" bson:"created_at"`                                       // Veritabanına eklenme tarihi
 	UpdatedAt        time.Time            `json:"updated_at" bson:"updated_at"`                                       // Son güncelleme tarihi
-	Status           string               `json:"status" bson:"status"`                                               // Oyunun durumu (active, coming_soon, removed, vb.)
+	Status           string               `json:"status" bson:"status"`                                               // Yaşam döngüsü durumu (draft, in_review, coming_soon, early_access, active, delisted, removed); yalnızca durum geçişiyle değişir
+	StatusChangedAt  time.Time            `json:"status_changed_at,omitempty" bson:"status_changed_at,omitempty"`     // Son durum geçişinin zamanı
+	StatusHistory    []StatusChange       `json:"status_history,omitempty" bson:"status_history,omitempty"`           // Durum geçişleri, eskiden yeniye
+	Translations     []Translation        `json:"translations,omitempty" bson:"translations,omitempty"`               // Başlık ve açıklamaların diğer dillerdeki karşılıkları; yalnızca çeviri uç noktalarıyla değişir
+}
+
+// Effective indirim uygulanmışsa indirimli, değilse liste fiyatını döndürür
+func (p Price) Effective() float64 {
+	if p.OnSale && p.Discount > 0 {
+		return p.Amount * (1 - p.Discount)
+	}
+	return p.Amount
+}
+
+// ReleaseDateOn oyunun verilen platformdaki çıkış tarihini döndürür; platformun kendi tarihi yoksa oyunun tarihi geçerlidir
+func (g Game) ReleaseDateOn(platform Platform) time.Time {
+	if !platform.ReleaseDate.IsZero() {
+		return platform.ReleaseDate
+	}
+	return g.ReleaseDate
 }
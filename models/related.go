@@ -0,0 +1,49 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Katalog öğesi türleri; kind alanı boş olan eski kayıtlar ana oyun sayılır
+const (
+	GameKindBase    = "game"    // Tek başına satılan ana oyun
+	GameKindDLC     = "dlc"     // Bir ana oyunu gerektiren ek içerik
+	GameKindEdition = "edition" // Bir ana oyunun sürümü (Deluxe, GOTY vb.)
+	GameKindBundle  = "bundle"  // Birden fazla oyunu içeren paket
+)
+
+// GameKinds geçerli katalog öğesi türleridir
+var GameKinds = map[string]bool{
+	GameKindBase:    true,
+	GameKindDLC:     true,
+	GameKindEdition: true,
+	GameKindBundle:  true,
+}
+
+// RelatedGame oyun detayında gömülen DLC, sürüm ve paket içeriklerinin özetidir
+type RelatedGame struct {
+	ID         primitive.ObjectID `json:"id"`
+	Title      string             `json:"title"`
+	Kind       string             `json:"kind"`
+	Price      Price              `json:"price"`
+	CoverImage string             `json:"cover_image,omitempty"`
+	Status     string             `json:"status"`
+	MinAge     int                `json:"min_age,omitempty"` // Yaş sınırı; içerik filtreleri için
+	Titles     map[string]string  `json:"-"`                 // Başlığın diğer dillerdeki karşılıkları; dil seçimi için
+}
+
+// BundlePricing paketin içerdiği oyunların güncel fiyatlarından hesaplanan fiyat dökümüdür
+type BundlePricing struct {
+	Items          []RelatedGame `json:"items"`           // Paketteki oyunlar (silinmiş olanlar hariç)
+	ItemsTotal     float64       `json:"items_total"`     // Oyunların tek tek güncel (indirimli) fiyatlarının toplamı
+	Price          float64       `json:"price"`           // Paket fiyatı: toplamdan paket indirimi düşülmüş hali
+	Savings        float64       `json:"savings"`         // Tek tek almaya göre kazanç
+	SavingsPercent int           `json:"savings_percent"` // Kazancın toplama oranı (%)
+	Currency       string        `json:"currency"`
+}
+
+// KindOrBase oyunun türünü döndürür; tür belirtilmemişse ana oyundur
+func (g Game) KindOrBase() string {
+	if g.Kind == "" {
+		return GameKindBase
+	}
+	return g.Kind
+}
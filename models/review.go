@@ -0,0 +1,80 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Yorum moderasyon durumları; yalnızca yayınlanmış yorumlar oyunun Rating değerlerine katılır
+const (
+	ReviewPublished = "published" // Herkese açık
+	ReviewPending   = "pending"   // Moderatör onayı bekliyor
+	ReviewHidden    = "hidden"    // Moderatör tarafından gizlendi
+	ReviewRejected  = "rejected"  // Moderatör tarafından reddedildi
+)
+
+// Review bir kullanıcının bir oyun hakkındaki değerlendirmesidir; kullanıcı başına oyun başına bir tane olabilir
+type Review struct {
+	ID               primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`                              // Benzersiz tanımlayıcı
+	GameID           primitive.ObjectID `json:"game_id" bson:"game_id"`                                         // Değerlendirilen oyun
+	UserID           primitive.ObjectID `json:"user_id" bson:"user_id"`                                         // Yorumu yazan kullanıcı
+	Score            int                `json:"score" bson:"score"`                                             // Puan (1-10 arası)
+	Recommended      bool               `json:"recommended" bson:"recommended"`                                 // Öneriyor mu?
+	Text             string             `json:"text,omitempty" bson:"text,omitempty"`                           // Yorum metni
+	PlaytimeMinutes  int                `json:"playtime_minutes" bson:"playtime_minutes"`                       // Yorum yazıldığındaki oynama süresi (dakika)
+	VerifiedOwner    bool               `json:"verified_owner" bson:"verified_owner"`                           // Yazan kullanıcı oyuna sahip mi (kütüphane kaydına göre)
+	HelpfulCount     int                `json:"helpful_count" bson:"helpful_count"`                             // "Faydalı" oyları
+	NotHelpfulCount  int                `json:"not_helpful_count" bson:"not_helpful_count"`                     // "Faydalı değil" oyları
+	Status           string             `json:"status" bson:"status"`                                           // Moderasyon durumu (published, pending, hidden, rejected)
+	Flags            []string           `json:"flags,omitempty" bson:"flags,omitempty"`                         // Otomatik işaretleme nedenleri (word:..., link, reports)
+	ReportCount      int                `json:"report_count,omitempty" bson:"report_count,omitempty"`           // Çözülmemiş şikayet sayısı
+	ModerationReason string             `json:"moderation_reason,omitempty" bson:"moderation_reason,omitempty"` // Son moderasyon kararının gerekçesi
+	ModeratedBy      string             `json:"moderated_by,omitempty" bson:"moderated_by,omitempty"`           // Son kararı veren moderatör
+	ModeratedAt      *time.Time         `json:"moderated_at,omitempty" bson:"moderated_at,omitempty"`           // Son karar zamanı
+	CreatedAt        time.Time          `json:"created_at" bson:"created_at"`                                   // Oluşturulma tarihi
+	UpdatedAt        time.Time          `json:"updated_at" bson:"updated_at"`                                   // Son düzenleme tarihi
+}
+
+// Counted yorumun oyunun Rating değerlerine katılıp katılmadığını döndürür
+func (r Review) Counted() bool {
+	return r.Status == ReviewPublished
+}
+
+// Totals yorumun Rating toplamlarına katkısını döndürür; sayılmayan yorumların katkısı sıfırdır
+func (r Review) Totals() RatingTotals {
+	if !r.Counted() {
+		return RatingTotals{}
+	}
+	t := RatingTotals{Count: 1, ScoreSum: float64(r.Score)}
+	if r.Recommended {
+		t.Positive = 1
+	}
+	return t
+}
+
+// ReviewVote bir kullanıcının bir yoruma verdiği faydalı/faydalı değil oyudur
+type ReviewVote struct {
+	ID        primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	ReviewID  primitive.ObjectID `json:"review_id" bson:"review_id"`
+	UserID    primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Helpful   bool               `json:"helpful" bson:"helpful"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// RatingTotals Rating ortalamalarının hesaplandığı ham toplamlardır; artımlı güncellemede fark olarak da kullanılır
+type RatingTotals struct {
+	Count    int     `json:"count" bson:"count"`         // Sayılan yorum sayısı
+	ScoreSum float64 `json:"score_sum" bson:"score_sum"` // Puanların toplamı
+	Positive int     `json:"positive" bson:"positive"`   // Öneren yorum sayısı
+}
+
+// Sub iki toplam arasındaki farkı döndürür
+func (t RatingTotals) Sub(o RatingTotals) RatingTotals {
+	return RatingTotals{Count: t.Count - o.Count, ScoreSum: t.ScoreSum - o.ScoreSum, Positive: t.Positive - o.Positive}
+}
+
+// IsZero toplamların hiçbir değişiklik içermediğini döndürür
+func (t RatingTotals) IsZero() bool {
+	return t == RatingTotals{}
+}
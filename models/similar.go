@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Benzerlik puanına katkı veren ortak özellikler
+const (
+	SimilarByGenres     = "genres"
+	SimilarByTags       = "tags"
+	SimilarByFeatures   = "features"
+	SimilarByDevelopers = "developers"
+	SimilarByPriceTier  = "price_tier"
+)
+
+// SimilarScore bir oyunun başka bir oyuna hesaplanan benzerlik puanıdır
+type SimilarScore struct {
+	GameID  primitive.ObjectID `json:"game_id" bson:"game_id"`                     // Benzer oyun
+	Score   float64            `json:"score" bson:"score"`                         // 0-1 arası benzerlik puanı
+	Reasons []string           `json:"reasons,omitempty" bson:"reasons,omitempty"` // Puana katkı veren ortak özellikler
+}
+
+// SimilarGames bir oyunun hesaplanan benzer oyunlarını ve editörlerin sabitlediği/çıkardığı oyunları tutar; oyun başına bir kayıt
+type SimilarGames struct {
+	GameID     primitive.ObjectID   `json:"game_id" bson:"game_id"`                             // Oyun
+	Computed   []SimilarScore       `json:"computed" bson:"computed"`                           // Zamanlanmış işin bulduğu en benzer oyunlar (puana göre azalan)
+	Pinned     []primitive.ObjectID `json:"pinned" bson:"pinned"`                               // Editörün listenin başına sabitlediği oyunlar (verilen sırayla)
+	Excluded   []primitive.ObjectID `json:"excluded" bson:"excluded"`                           // Editörün listeden çıkardığı oyunlar
+	ComputedAt time.Time            `json:"computed_at,omitempty" bson:"computed_at,omitempty"` // Son hesaplama zamanı
+	UpdatedAt  time.Time            `json:"updated_at,omitempty" bson:"updated_at,omitempty"`   // Son editör değişikliği
+}
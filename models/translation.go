@@ -0,0 +1,41 @@
+package models
+
+// Translation oyunun çevrilebilir metinlerinin bir dildeki karşılığıdır. Oyunun kendi title, description ve
+// short_description alanları varsayılan dildedir; boş bırakılan alanlarda varsayılan dildeki metin gösterilir.
+type Translation struct {
+	Locale           string `json:"locale" bson:"locale"`                                           // Dil kodu (en, de, ...)
+	Title            string `json:"title,omitempty" bson:"title,omitempty"`                         // Oyun adı
+	Description      string `json:"description,omitempty" bson:"description,omitempty"`             // Açıklama
+	ShortDescription string `json:"short_description,omitempty" bson:"short_description,omitempty"` // Kısa açıklama
+}
+
+// TranslatableFields çevrilebilen oyun alanlarının JSON adlarıdır
+var TranslatableFields = []string{"title", "description", "short_description"}
+
+// TranslationFor oyunun verilen dildeki çevirisini döndürür
+func (g Game) TranslationFor(locale string) (Translation, bool) {
+	for _, t := range g.Translations {
+		if t.Locale == locale {
+			return t, true
+		}
+	}
+	return Translation{}, false
+}
+
+// DefaultTranslation oyunun varsayılan dildeki metinlerini çeviri olarak döndürür
+func (g Game) DefaultTranslation(locale string) Translation {
+	return Translation{Locale: locale, Title: g.Title, Description: g.Description, ShortDescription: g.ShortDescription}
+}
+
+// Field çevirinin JSON adı verilen alanını döndürür
+func (t Translation) Field(name string) string {
+	switch name {
+	case "title":
+		return t.Title
+	case "description":
+		return t.Description
+	case "short_description":
+		return t.ShortDescription
+	}
+	return ""
+}
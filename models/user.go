@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// User platformdaki bir kullanıcı hesabını temsil eder
+type User struct {
+	ID            primitive.ObjectID    `json:"id,omitempty" bson:"_id,omitempty"`                      // Benzersiz tanımlayıcı
+	Email         string                `json:"email" bson:"email"`                                     // Giriş için kullanılan e-posta (küçük harfe çevrilerek saklanır)
+	DisplayName   string                `json:"display_name,omitempty" bson:"display_name,omitempty"`   // Görünen ad
+	PasswordHash  string                `json:"-" bson:"password_hash"`                                 // bcrypt özeti (JSON'da gösterilmez)
+	Roles         []string              `json:"roles,omitempty" bson:"roles,omitempty"`                 // Roller (viewer, editor, ...)
+	CreatedAt     time.Time             `json:"created_at" bson:"created_at"`                           // Kayıt tarihi
+	UpdatedAt     time.Time             `json:"updated_at" bson:"updated_at"`                           // Son güncelleme tarihi
+	LastLoginAt   time.Time             `json:"last_login_at,omitempty" bson:"last_login_at,omitempty"` // Son giriş tarihi
+	Notifications *NotificationSettings `json:"notifications,omitempty" bson:"notifications,omitempty"` // Bildirim kanalları (boşsa varsayılanlar kullanılır)
+	Content       *ContentSettings      `json:"content,omitempty" bson:"content,omitempty"`             // Yaş derecesi sınırları (boşsa sınır yok)
+}
+
+// RefreshToken uzun ömürlü oturum yenileme token'ını temsil eder; token'ın kendisi değil özeti saklanır
+type RefreshToken struct {
+	ID        primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	UserID    primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Hash      string             `json:"-" bson:"hash"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+	ExpiresAt time.Time          `json:"expires_at" bson:"expires_at"`
+	RevokedAt *time.Time         `json:"revoked_at,omitempty" bson:"revoked_at,omitempty"` // Kullanıldığında veya çıkış yapıldığında dolar
+}
+
+// PasswordResetToken tek kullanımlık şifre sıfırlama token'ını temsil eder
+type PasswordResetToken struct {
+	ID        primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	UserID    primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Hash      string             `json:"-" bson:"hash"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+	ExpiresAt time.Time          `json:"expires_at" bson:"expires_at"`
+	UsedAt    *time.Time         `json:"used_at,omitempty" bson:"used_at,omitempty"`
+}
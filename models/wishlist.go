@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WishlistItem kullanıcının istek listesindeki bir oyunu ve bildirim tercihlerini temsil eder
+type WishlistItem struct {
+	ID              primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`          // Benzersiz tanımlayıcı
+	UserID          primitive.ObjectID `json:"user_id" bson:"user_id"`                     // Listenin sahibi
+	GameID          primitive.ObjectID `json:"game_id" bson:"game_id"`                     // İstenen oyun
+	NotifyOnSale    bool               `json:"notify_on_sale" bson:"notify_on_sale"`       // İndirime girince bildir
+	NotifyOnRelease bool               `json:"notify_on_release" bson:"notify_on_release"` // Çıkış yapınca bildir
+	AddedAt         time.Time          `json:"added_at" bson:"added_at"`                   // Listeye eklenme tarihi
+	UpdatedAt       time.Time          `json:"updated_at" bson:"updated_at"`               // Son güncelleme tarihi
+}
+
+// Notification kullanıcıya gönderilen bir bildirimi temsil eder; inbox kanalı bunları saklar
+type Notification struct {
+	ID        primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`          // Benzersiz tanımlayıcı
+	UserID    primitive.ObjectID `json:"user_id" bson:"user_id"`                     // Alıcı
+	Type      string             `json:"type" bson:"type"`                           // Olay tipi (game.on_sale, game.released)
+	GameID    primitive.ObjectID `json:"game_id,omitempty" bson:"game_id,omitempty"` // İlgili oyun
+	Title     string             `json:"title" bson:"title"`                         // Kısa başlık
+	Body      string             `json:"body" bson:"body"`                           // Bildirim metni
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`               // Oluşturulma tarihi
+	ReadAt    *time.Time         `json:"read_at,omitempty" bson:"read_at,omitempty"` // Okunduğu zaman
+}
+
+// NotificationSettings kullanıcının bildirimleri hangi kanallardan almak istediğini tutar
+type NotificationSettings struct {
+	Channels   []string `json:"channels" bson:"channels"`                           // inbox, email, webhook
+	WebhookURL string   `json:"webhook_url,omitempty" bson:"webhook_url,omitempty"` // webhook kanalı için hedef adres
+}
@@ -0,0 +1,97 @@
+package moderation
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Otomatik işaretleme nedenleri; yorumun Flags alanına yazılır
+const (
+	FlagWord    = "word:"   // Kara listedeki bir kelime veya ifade (ör. "word:casino")
+	FlagLink    = "link"    // Metinde bağlantı var
+	FlagReports = "reports" // Kullanıcı şikayetleri eşiği aştı
+)
+
+// linkPattern http(s) adreslerini, www. ile başlayanları ve yaygın alan adı uzantılarını yakalar
+var linkPattern = regexp.MustCompile(`(?i)(https?://|www\.)\S+|\b[a-z0-9-]+\.(com|net|org|io|gg|ru|xyz|ly|me|co|tk|info|biz|shop)\b`)
+
+// Filter yorum metinlerini kelime listesine ve bağlantılara göre denetler
+type Filter struct {
+	words      map[string]bool // Tek kelimelik girişler
+	phrases    []string        // Birden fazla kelimeden oluşan girişler
+	blockLinks bool
+}
+
+// NewFilter verilen kelime listesiyle bir filtre oluşturur; blockLinks açıksa bağlantı içeren metinler de işaretlenir
+func NewFilter(entries []string, blockLinks bool) *Filter {
+	f := &Filter{words: map[string]bool{}, blockLinks: blockLinks}
+	for _, entry := range entries {
+		tokens := tokenize(entry)
+		switch len(tokens) {
+		case 0:
+		case 1:
+			f.words[tokens[0]] = true
+		default:
+			f.phrases = append(f.phrases, strings.Join(tokens, " "))
+		}
+	}
+	return f
+}
+
+// LoadWordList satır başına bir giriş içeren dosyayı okur; boş satırlar ve # ile başlayanlar atlanır
+func LoadWordList(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var entries []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			entries = append(entries, line)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// Check metnin neden işaretlenmesi gerektiğini döndürür; boş sonuç metnin temiz olduğunu gösterir
+func (f *Filter) Check(text string) []string {
+	var flags []string
+	seen := map[string]bool{}
+	add := func(flag string) {
+		if !seen[flag] {
+			seen[flag] = true
+			flags = append(flags, flag)
+		}
+	}
+	tokens := tokenize(text)
+	for _, token := range tokens {
+		if f.words[token] {
+			add(FlagWord + token)
+		}
+	}
+	if len(f.phrases) > 0 {
+		normalized := " " + strings.Join(tokens, " ") + " "
+		for _, phrase := range f.phrases {
+			if strings.Contains(normalized, " "+phrase+" ") {
+				add(FlagWord + phrase)
+			}
+		}
+	}
+	if f.blockLinks && linkPattern.MatchString(text) {
+		add(FlagLink)
+	}
+	return flags
+}
+
+// tokenize metni küçük harfli kelimelere böler; harf ve rakam dışındaki karakterler ayraç sayılır
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
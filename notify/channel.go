@@ -0,0 +1,22 @@
+package notify
+
+import (
+	"api-steam/models"
+	"context"
+)
+
+// Kanal adları; kullanıcılar NotificationSettings.Channels ile bunlardan seçer
+const (
+	ChannelInbox   = "inbox"
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+)
+
+// DefaultChannels ayar yapmamış kullanıcılar için kullanılan kanallardır
+var DefaultChannels = []string{ChannelInbox, ChannelEmail}
+
+// Channel bir bildirimi kullanıcıya ileten takılabilir teslim yoludur
+type Channel interface {
+	Name() string
+	Deliver(ctx context.Context, user models.User, notification models.Notification) error
+}
@@ -0,0 +1,90 @@
+package notify
+
+import (
+	"api-steam/mail"
+	"api-steam/models"
+	"api-steam/repository"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// InboxChannel bildirimi uygulama içi gelen kutusuna (notifications koleksiyonu) yazar
+type InboxChannel struct {
+	Notifications repository.NotificationRepository
+}
+
+func (c *InboxChannel) Name() string { return ChannelInbox }
+
+// Deliver bildirimi kaydeder
+func (c *InboxChannel) Deliver(ctx context.Context, user models.User, notification models.Notification) error {
+	_, err := c.Notifications.Insert(ctx, notification)
+	return err
+}
+
+// EmailChannel bildirimi kullanıcının e-posta adresine gönderir
+type EmailChannel struct {
+	Mailer mail.Mailer
+}
+
+func (c *EmailChannel) Name() string { return ChannelEmail }
+
+// Deliver bildirimi e-posta olarak gönderir
+func (c *EmailChannel) Deliver(ctx context.Context, user models.User, notification models.Notification) error {
+	return c.Mailer.Send(ctx, mail.Message{To: user.Email, Subject: notification.Title, Body: notification.Body})
+}
+
+// HeaderSignature webhook gövdesinin HMAC-SHA256 imzasının gönderildiği başlıktır
+const HeaderSignature = "X-GamerHub-Signature"
+
+// WebhookChannel bildirimi kullanıcının kayıtlı webhook adresine JSON olarak POST eder.
+// Secret tanımlıysa gövde "sha256=<hex>" biçiminde imzalanır, alıcı isteğin bu API'den geldiğini doğrulayabilir.
+type WebhookChannel struct {
+	Client *http.Client
+	Secret string
+}
+
+// NewWebhookChannel verilen zaman aşımı ve imza sırrıyla bir webhook kanalı oluşturur; istemci iç ağ adreslerine
+// bağlanmaz ve yönlendirmeleri izlemez
+func NewWebhookChannel(timeout time.Duration, secret string) *WebhookChannel {
+	return &WebhookChannel{Client: newWebhookClient(timeout), Secret: secret}
+}
+
+func (c *WebhookChannel) Name() string { return ChannelWebhook }
+
+// Deliver bildirimi webhook adresine gönderir; 2xx dışındaki yanıtlar hata sayılır
+func (c *WebhookChannel) Deliver(ctx context.Context, user models.User, notification models.Notification) error {
+	if user.Notifications == nil || user.Notifications.WebhookURL == "" {
+		return errors.New("webhook adresi tanımlı değil")
+	}
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, user.Notifications.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(c.Secret))
+		mac.Write(body)
+		req.Header.Set(HeaderSignature, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %d durum kodu döndü", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package notify
+
+import (
+	"api-steam/models"
+	"context"
+	"log/slog"
+)
+
+// Dispatcher bildirimi kullanıcının seçtiği kanallardan teslim eder
+type Dispatcher struct {
+	Channels map[string]Channel
+	Log      *slog.Logger
+}
+
+// NewDispatcher verilen kanallarla bir Dispatcher oluşturur
+func NewDispatcher(logger *slog.Logger, channels ...Channel) *Dispatcher {
+	byName := make(map[string]Channel, len(channels))
+	for _, c := range channels {
+		byName[c.Name()] = c
+	}
+	return &Dispatcher{Channels: byName, Log: logger}
+}
+
+// Supports kanalın kayıtlı olup olmadığını döndürür
+func (d *Dispatcher) Supports(name string) bool {
+	_, ok := d.Channels[name]
+	return ok
+}
+
+// Dispatch bildirimi her kanala ayrı ayrı iletir; bir kanalın hatası diğerlerini engellemez
+func (d *Dispatcher) Dispatch(ctx context.Context, user models.User, notification models.Notification) {
+	channels := DefaultChannels
+	if user.Notifications != nil {
+		channels = user.Notifications.Channels
+	}
+	for _, name := range channels {
+		channel, ok := d.Channels[name]
+		if !ok {
+			continue
+		}
+		if err := channel.Deliver(ctx, user, notification); err != nil {
+			d.Log.WarnContext(ctx, "bildirim teslim edilemedi", "channel", name, "user_id", user.ID, "type", notification.Type, "error", err)
+			continue
+		}
+		d.Log.DebugContext(ctx, "bildirim teslim edildi", "channel", name, "user_id", user.ID, "type", notification.Type)
+	}
+}
@@ -0,0 +1,80 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+var (
+	ErrInvalidWebhookURL     = errors.New("webhook adresi geçerli bir http(s) adresi olmalıdır")
+	ErrBlockedWebhookAddress = errors.New("webhook adresi iç ağdaki bir adrese (loopback, özel ağ, link-local) çözümleniyor")
+)
+
+// sharedAddressSpace taşıyıcı seviyesi NAT için ayrılmış 100.64.0.0/10 bloğudur; dışarıdan erişilemeyen iç ağlarda kullanılır
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// blockedIP webhook isteklerinin gönderilemeyeceği adresleri tanır: loopback, özel ağlar, link-local (bulut metadata
+// servisi 169.254.169.254 dahil), belirtilmemiş ve multicast adresler
+func blockedIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		sharedAddressSpace.Contains(ip)
+}
+
+// CheckWebhookURL adresin http(s) olduğunu ve host'un yalnızca dış ağdaki adreslere çözümlendiğini doğrular. Çözümleme
+// kayıt anındaki durumu gösterir; gönderim sırasında aynı kontrol bağlantı kurulurken tekrar yapılır.
+func CheckWebhookURL(ctx context.Context, raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ErrInvalidWebhookURL
+	}
+	host := u.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		if blockedIP(ip) {
+			return ErrBlockedWebhookAddress
+		}
+		return nil
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return fmt.Errorf("%w: %s çözümlenemedi", ErrInvalidWebhookURL, host)
+	}
+	for _, addr := range addrs {
+		if blockedIP(addr.IP) {
+			return ErrBlockedWebhookAddress
+		}
+	}
+	return nil
+}
+
+// newWebhookClient iç ağa bağlanmayı reddeden bir HTTP istemcisi oluşturur. Kontrol DNS çözümlemesinden sonra, bağlanılan
+// IP üzerinde yapılır; böylece kayıttan sonra iç adrese çözümlenmeye başlayan alan adları (DNS rebinding) da engellenir.
+// Yönlendirmeler izlenmez (3xx yanıtı teslim hatası sayılır) ve ortamdaki proxy kullanılmaz.
+func newWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || blockedIP(ip) {
+				return fmt.Errorf("%w: %s", ErrBlockedWebhookAddress, host)
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{Proxy: nil, DialContext: dialer.DialContext, TLSHandshakeTimeout: timeout},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
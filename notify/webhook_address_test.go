@@ -0,0 +1,49 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCheckWebhookURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want error
+	}{
+		{"https://93.184.216.34/hook", nil},
+		{"http://[2606:4700::1111]:8080/hook", nil},
+		{"ftp://93.184.216.34/hook", ErrInvalidWebhookURL},
+		{"https:///hook", ErrInvalidWebhookURL},
+		{"http://127.0.0.1:9000/hook", ErrBlockedWebhookAddress},
+		{"http://[::1]/hook", ErrBlockedWebhookAddress},
+		{"http://10.0.0.5/hook", ErrBlockedWebhookAddress},
+		{"http://192.168.1.1/hook", ErrBlockedWebhookAddress},
+		{"http://169.254.169.254/latest/meta-data", ErrBlockedWebhookAddress},
+		{"http://100.64.0.1/hook", ErrBlockedWebhookAddress},
+		{"http://0.0.0.0/hook", ErrBlockedWebhookAddress},
+		{"http://[fd00::1]/hook", ErrBlockedWebhookAddress},
+		{"http://[::ffff:127.0.0.1]/hook", ErrBlockedWebhookAddress},
+		{"http://localhost/hook", ErrBlockedWebhookAddress},
+	}
+	for _, tt := range tests {
+		if err := CheckWebhookURL(context.Background(), tt.url); !errors.Is(err, tt.want) {
+			t.Errorf("CheckWebhookURL(%q) = %v, beklenen %v", tt.url, err, tt.want)
+		}
+	}
+}
+
+func TestWebhookClientRefusesInternalAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		t.Error("iç adrese istek ulaşmamalıydı")
+	}))
+	defer server.Close()
+
+	_, err := newWebhookClient(time.Second).Get(server.URL)
+	if !errors.Is(err, ErrBlockedWebhookAddress) {
+		t.Errorf("hata = %v, beklenen ErrBlockedWebhookAddress", err)
+	}
+}
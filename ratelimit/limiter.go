@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rule bir token bucket bütçesini tanımlar: Limit kadar token taşır ve Period boyunca sıfırdan tamamen dolar
+type Rule struct {
+	Name   string        // Bütçe adı: read, write, bulk
+	Limit  int           // Kovanın kapasitesi (ani istek sınırı)
+	Period time.Duration // Kovanın boştan tamamen dolma süresi
+}
+
+// rate saniye başına eklenen token sayısını döndürür
+func (r Rule) rate() float64 {
+	return float64(r.Limit) / r.Period.Seconds()
+}
+
+// Policy RateLimit-Policy başlığının değerini döndürür (ör. "120;w=60")
+func (r Rule) Policy() string {
+	return fmt.Sprintf("%d;w=%d", r.Limit, int(math.Ceil(r.Period.Seconds())))
+}
+
+// ParseRule "120/1m" biçimindeki bütçe tanımını ayrıştırır
+func ParseRule(name, spec string) (Rule, error) {
+	limit, period, ok := strings.Cut(strings.TrimSpace(spec), "/")
+	if !ok {
+		return Rule{}, fmt.Errorf("%s bütçesi '<limit>/<süre>' biçiminde olmalıdır: %q", name, spec)
+	}
+	n, err := strconv.Atoi(limit)
+	if err != nil || n <= 0 {
+		return Rule{}, fmt.Errorf("%s bütçesinin limiti pozitif bir sayı olmalıdır: %q", name, spec)
+	}
+	d, err := time.ParseDuration(period)
+	if err != nil || d <= 0 {
+		return Rule{}, fmt.Errorf("%s bütçesinin süresi geçersiz: %q", name, spec)
+	}
+	return Rule{Name: name, Limit: n, Period: d}, nil
+}
+
+// Result bir isteğin bütçeden düşülmesinin sonucudur
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration // Kova tamamen dolana kadar geçecek süre
+	RetryAfter time.Duration // Reddedilen istekte bir sonraki token'a kadar beklenecek süre
+}
+
+// Store token bucket durumunu tutar; Take kovadan atomik olarak bir token düşmeyi dener
+type Store interface {
+	Take(ctx context.Context, key string, rule Rule, now time.Time) (Result, error)
+}
+
+// newResult kovada kalan token miktarından istemciye dönülecek sonucu hesaplar
+func newResult(rule Rule, tokens float64, allowed bool) Result {
+	result := Result{
+		Allowed:   allowed,
+		Limit:     rule.Limit,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     time.Duration((float64(rule.Limit) - tokens) / rule.rate() * float64(time.Second)),
+	}
+	if !allowed {
+		result.RetryAfter = time.Duration((1 - tokens) / rule.rate() * float64(time.Second))
+	}
+	return result
+}
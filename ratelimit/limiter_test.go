@@ -0,0 +1,123 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    Rule
+		wantErr bool
+	}{
+		{"120/1m", Rule{Name: "read", Limit: 120, Period: time.Minute}, false},
+		{" 5/10s ", Rule{Name: "read", Limit: 5, Period: 10 * time.Second}, false},
+		{"120", Rule{}, true},
+		{"0/1m", Rule{}, true},
+		{"-1/1m", Rule{}, true},
+		{"x/1m", Rule{}, true},
+		{"10/0s", Rule{}, true},
+		{"10/abc", Rule{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseRule("read", tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("hata = %v, hata bekleniyor mu: %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("kural = %+v, beklenen %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreTake(t *testing.T) {
+	rule := Rule{Name: "write", Limit: 2, Period: 2 * time.Second} // saniyede 1 token
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	steps := []struct {
+		name          string
+		at            time.Duration
+		wantAllowed   bool
+		wantRemaining int
+		wantRetry     time.Duration
+	}{
+		{"ilk istek", 0, true, 1, 0},
+		{"ani ikinci istek", 0, true, 0, 0},
+		{"kova boş", 0, false, 0, time.Second},
+		{"yarım token", 500 * time.Millisecond, false, 0, 500 * time.Millisecond},
+		{"bir token doldu", time.Second, true, 0, 0},
+		{"kapasiteyi aşmaz", time.Minute, true, 1, 0},
+	}
+	store := NewMemoryStore()
+	for _, step := range steps {
+		got, err := store.Take(context.Background(), "ip:1.2.3.4", rule, start.Add(step.at))
+		if err != nil {
+			t.Fatalf("%s: beklenmeyen hata: %v", step.name, err)
+		}
+		if got.Allowed != step.wantAllowed || got.Remaining != step.wantRemaining || got.RetryAfter != step.wantRetry {
+			t.Errorf("%s: sonuç = %+v, beklenen izin=%v kalan=%d bekleme=%v",
+				step.name, got, step.wantAllowed, step.wantRemaining, step.wantRetry)
+		}
+		if got.Limit != rule.Limit {
+			t.Errorf("%s: limit = %d, beklenen %d", step.name, got.Limit, rule.Limit)
+		}
+	}
+
+	// Farklı anahtarlar ayrı kovalara sahiptir
+	if got, _ := store.Take(context.Background(), "ip:5.6.7.8", rule, start); !got.Allowed {
+		t.Error("yeni istemcinin kovası dolu başlamalı")
+	}
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	rule := Rule{Name: "read", Limit: 10, Period: 10 * time.Second}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.Take(context.Background(), "a", rule, start)
+	store.Take(context.Background(), "b", rule, start.Add(5*time.Second))
+
+	if n := store.Sweep(start.Add(500 * time.Millisecond)); n != 0 {
+		t.Errorf("dolmamış kovalar silindi: %d", n)
+	}
+	if n := store.Sweep(start.Add(time.Second)); n != 1 {
+		t.Errorf("silinen kova = %d, beklenen 1", n)
+	}
+	if n := store.Sweep(start.Add(6 * time.Second)); n != 1 {
+		t.Errorf("silinen kova = %d, beklenen 1", n)
+	}
+}
+
+func TestClientKey(t *testing.T) {
+	_, proxyNet, _ := net.ParseCIDR("10.0.0.0/8")
+	tests := []struct {
+		name      string
+		extractor echo.IPExtractor
+		remote    string
+		xff       string
+		want      string
+	}{
+		{"doğrudan bağlantı başlığı yok sayar", echo.ExtractIPDirect(), "203.0.113.7:5000", "9.9.9.9", "ip:203.0.113.7"},
+		{"güvenilmeyen proxy başlığı yok sayılır", echo.ExtractIPFromXFFHeader(echo.TrustLoopback(false), echo.TrustPrivateNet(false), echo.TrustLinkLocal(false)), "10.0.0.1:5000", "9.9.9.9", "ip:10.0.0.1"},
+		{"güvenilen proxy arkasındaki istemci", echo.ExtractIPFromXFFHeader(echo.TrustIPRange(proxyNet)), "10.0.0.1:5000", "9.9.9.9", "ip:9.9.9.9"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.IPExtractor = tt.extractor
+			req := httptest.NewRequest(http.MethodGet, "/api/game", nil)
+			req.RemoteAddr = tt.remote
+			req.Header.Set(echo.HeaderXForwardedFor, tt.xff)
+			if got := ClientKey(e.NewContext(req, httptest.NewRecorder())); got != tt.want {
+				t.Errorf("anahtar = %q, beklenen %q", got, tt.want)
+			}
+		})
+	}
+}
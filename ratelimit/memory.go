@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// MemoryStore kovaları süreç belleğinde tutar; tek instance'lı kurulumlar içindir
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	fullAt    time.Time // Kovanın tekrar tamamen dolacağı an; Sweep bu andan sonra kovayı siler
+}
+
+// NewMemoryStore boş bir bellek içi depo oluşturur
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: map[string]*bucket{}}
+}
+
+// Take kovayı geçen süreye göre doldurur ve bir token düşmeyi dener
+func (s *MemoryStore) Take(_ context.Context, key string, rule Rule, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(rule.Limit), updatedAt: now}
+		s.buckets[key] = b
+	}
+	elapsed := now.Sub(b.updatedAt).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(float64(rule.Limit), b.tokens+elapsed*rule.rate())
+		b.updatedAt = now
+	}
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	result := newResult(rule, b.tokens, allowed)
+	b.fullAt = now.Add(result.Reset)
+	return result, nil
+}
+
+// Sweep tamamen dolmuş kovaları siler; bellek kullanımını istemci sayısıyla sınırlı tutar
+func (s *MemoryStore) Sweep(now time.Time) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	removed := 0
+	for key, b := range s.buckets {
+		if !now.Before(b.fullAt) {
+			delete(s.buckets, key)
+			removed++
+		}
+	}
+	return removed
+}
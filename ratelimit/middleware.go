@@ -0,0 +1,102 @@
+package ratelimit
+
+import (
+	"api-steam/auth"
+	"api-steam/metrics"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Budgets okuma, yazma ve toplu işlem route'ları için ayrı bütçeleri tutar
+type Budgets struct {
+	Read  Rule
+	Write Rule
+	Bulk  Rule
+}
+
+// Limiter istekleri istemci başına token bucket bütçeleriyle sınırlar
+type Limiter struct {
+	Store      Store
+	Budgets    Budgets
+	BulkRoutes map[string]bool // Bulk bütçesine tabi route şablonları (ör. /api/games/bulk)
+	Log        *slog.Logger
+	now        func() time.Time
+}
+
+// NewLimiter verilen depo ve bütçelerle bir Limiter oluşturur
+func NewLimiter(store Store, budgets Budgets, bulkRoutes []string, logger *slog.Logger) *Limiter {
+	bulk := make(map[string]bool, len(bulkRoutes))
+	for _, route := range bulkRoutes {
+		bulk[route] = true
+	}
+	return &Limiter{Store: store, Budgets: budgets, BulkRoutes: bulk, Log: logger, now: time.Now}
+}
+
+// Middleware /api altındaki istekleri sınırlar ve RateLimit-* başlıklarını ekler; bütçe aşılırsa 429 ve Retry-After döner.
+// Authenticator middleware'inden sonra kullanılmalıdır, aksi halde tüm istekler IP'ye göre sayılır.
+// Depo hata verirse istek engellenmez (fail-open), yalnızca loglanır.
+func (l *Limiter) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !strings.HasPrefix(c.Request().URL.Path, "/api/") {
+				return next(c)
+			}
+			ctx := c.Request().Context()
+			rule := l.ruleFor(c)
+			client := ClientKey(c)
+			result, err := l.Store.Take(ctx, rule.Name+":"+client, rule, l.now())
+			if err != nil {
+				l.Log.WarnContext(ctx, "rate limit deposuna erişilemedi, istek sınırlanmadan geçiriliyor", "client", client, "error", err)
+				return next(c)
+			}
+
+			header := c.Response().Header()
+			header.Set("RateLimit-Policy", rule.Policy())
+			header.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+			header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			header.Set("RateLimit-Reset", strconv.Itoa(seconds(result.Reset)))
+			if !result.Allowed {
+				metrics.ObserveRateLimited(rule.Name)
+				header.Set("Retry-After", strconv.Itoa(seconds(result.RetryAfter)))
+				l.Log.InfoContext(ctx, "istek sınırı aşıldı", "client", client, "budget", rule.Name)
+				return c.JSON(http.StatusTooManyRequests, map[string]interface{}{"error": "İstek sınırı aşıldı, lütfen Retry-After süresi kadar bekleyin"})
+			}
+			return next(c)
+		}
+	}
+}
+
+// ruleFor isteğin hangi bütçeden düşüleceğini belirler
+func (l *Limiter) ruleFor(c echo.Context) Rule {
+	if l.BulkRoutes[c.Path()] {
+		return l.Budgets.Bulk
+	}
+	switch c.Request().Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return l.Budgets.Read
+	default:
+		return l.Budgets.Write
+	}
+}
+
+// ClientKey isteği yapan istemciyi tanımlar: API anahtarı, kullanıcı veya IP adresi
+func ClientKey(c echo.Context) string {
+	if principal, ok := auth.FromEcho(c); ok {
+		if principal.Kind == auth.KindService {
+			return "key:" + principal.Subject
+		}
+		return "user:" + principal.Subject
+	}
+	return "ip:" + c.RealIP()
+}
+
+// seconds süreyi başlıklarda kullanılmak üzere yukarı yuvarlanmış saniyeye çevirir
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
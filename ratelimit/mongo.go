@@ -0,0 +1,57 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoStore kovaları MongoDB'de tutar; birden fazla instance aynı bütçeyi paylaşır.
+// Doldurma ve düşme tek bir pipeline update ile yapıldığı için eşzamanlı isteklerde yarış oluşmaz.
+type MongoStore struct {
+	Collection *mongo.Collection
+}
+
+// NewMongoStore rate_limits koleksiyonu üzerinde bir depo oluşturur
+func NewMongoStore(collection *mongo.Collection) *MongoStore {
+	return &MongoStore{Collection: collection}
+}
+
+type bucketDocument struct {
+	Tokens  float64 `bson:"tokens"`
+	Allowed bool    `bson:"allowed"`
+}
+
+// Take kovayı sunucu tarafında doldurur ve bir token düşmeyi dener; kova yoksa dolu olarak oluşturulur
+func (s *MongoStore) Take(ctx context.Context, key string, rule Rule, now time.Time) (Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	limit := float64(rule.Limit)
+	perMillisecond := rule.rate() / 1000
+	refilled := bson.M{"$min": bson.A{limit, bson.M{"$add": bson.A{
+		bson.M{"$ifNull": bson.A{"$tokens", limit}},
+		bson.M{"$multiply": bson.A{
+			bson.M{"$max": bson.A{0, bson.M{"$subtract": bson.A{now, bson.M{"$ifNull": bson.A{"$updated_at", now}}}}}},
+			perMillisecond,
+		}},
+	}}}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"tokens": refilled, "updated_at": now}}},
+		{{Key: "$set", Value: bson.M{
+			"allowed": bson.M{"$gte": bson.A{"$tokens", 1}},
+			"tokens":  bson.M{"$cond": bson.A{bson.M{"$gte": bson.A{"$tokens", 1}}, bson.M{"$subtract": bson.A{"$tokens", 1}}, "$tokens"}},
+			// Kova dolduktan sonra belge gereksizdir; TTL indeksi onu siler
+			"expires_at": now.Add(rule.Period),
+		}}},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var doc bucketDocument
+	if err := s.Collection.FindOneAndUpdate(ctx, bson.M{"_id": key}, pipeline, opts).Decode(&doc); err != nil {
+		return Result{}, err
+	}
+	return newResult(rule, doc.Tokens, doc.Allowed), nil
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"api-steam/models"
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AchievementRepository başarım tanımları ve kullanıcıların açtığı başarımlar için gereken metodları tanımlar
+type AchievementRepository interface {
+	Insert(ctx context.Context, achievement models.Achievement) (models.Achievement, error)
+	GetByKey(ctx context.Context, gameID primitive.ObjectID, key string) (models.Achievement, error)
+	ListByGame(ctx context.Context, gameID primitive.ObjectID) ([]models.Achievement, error)
+	Update(ctx context.Context, gameID primitive.ObjectID, key string, achievement models.Achievement) (models.Achievement, error)
+	Delete(ctx context.Context, gameID primitive.ObjectID, key string) (models.Achievement, error)
+	InsertUnlock(ctx context.Context, unlock models.AchievementUnlock) (models.AchievementUnlock, error)
+	ListUnlocks(ctx context.Context, userID, gameID primitive.ObjectID) ([]models.AchievementUnlock, error)
+}
+
+// AchievementRepositoryDB tanımları achievements, açılan başarımları achievement_unlocks koleksiyonunda tutar
+type AchievementRepositoryDB struct {
+	Achievements *mongo.Collection
+	Unlocks      *mongo.Collection
+	Log          *slog.Logger
+}
+
+// NewAchievementRepository achievements ve achievement_unlocks koleksiyonları için repository oluşturur
+func NewAchievementRepository(achievements, unlocks *mongo.Collection, logger *slog.Logger) AchievementRepository {
+	return &AchievementRepositoryDB{Achievements: achievements, Unlocks: unlocks, Log: logger}
+}
+
+// Insert başarım tanımını ekler; oyunda aynı key varsa ErrDuplicate döner
+func (r *AchievementRepositoryDB) Insert(ctx context.Context, achievement models.Achievement) (models.Achievement, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	achievement.ID = primitive.NewObjectID()
+	achievement.UnlockCount = 0
+	achievement.CreatedAt = time.Now()
+	achievement.UpdatedAt = achievement.CreatedAt
+	if _, err := r.Achievements.InsertOne(ctx, achievement); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return models.Achievement{}, ErrDuplicate
+		}
+		r.Log.ErrorContext(ctx, "başarım eklenemedi", "game_id", achievement.GameID, "key", achievement.Key, "error", err)
+		return models.Achievement{}, err
+	}
+	return achievement, nil
+}
+
+// GetByKey oyunun verilen key'deki başarımını getirir
+func (r *AchievementRepositoryDB) GetByKey(ctx context.Context, gameID primitive.ObjectID, key string) (models.Achievement, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var achievement models.Achievement
+	if err := r.Achievements.FindOne(ctx, bson.M{"game_id": gameID, "key": key}).Decode(&achievement); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.Achievement{}, ErrNotFound
+		}
+		r.Log.ErrorContext(ctx, "başarım getirilemedi", "game_id", gameID, "key", key, "error", err)
+		return models.Achievement{}, err
+	}
+	return achievement, nil
+}
+
+// ListByGame oyunun başarımlarını oluşturulma sırasıyla döndürür
+func (r *AchievementRepositoryDB) ListByGame(ctx context.Context, gameID primitive.ObjectID) ([]models.Achievement, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	cursor, err := r.Achievements.Find(ctx, bson.M{"game_id": gameID}, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		r.Log.ErrorContext(ctx, "başarımlar getirilemedi", "game_id", gameID, "error", err)
+		return nil, err
+	}
+	achievements := []models.Achievement{}
+	if err := cursor.All(ctx, &achievements); err != nil {
+		r.Log.ErrorContext(ctx, "başarımlar çözümlenemedi", "game_id", gameID, "error", err)
+		return nil, err
+	}
+	return achievements, nil
+}
+
+// Update başarımın görünen alanlarını değiştirir; key ve açılma sayısı değişmez
+func (r *AchievementRepositoryDB) Update(ctx context.Context, gameID primitive.ObjectID, key string, achievement models.Achievement) (models.Achievement, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	update := bson.M{"$set": bson.M{
+		"name":        achievement.Name,
+		"description": achievement.Description,
+		"icon_url":    achievement.IconURL,
+		"hidden":      achievement.Hidden,
+		"points":      achievement.Points,
+		"updated_at":  time.Now(),
+	}}
+	var updated models.Achievement
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	if err := r.Achievements.FindOneAndUpdate(ctx, bson.M{"game_id": gameID, "key": key}, update, opts).Decode(&updated); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.Achievement{}, ErrNotFound
+		}
+		r.Log.ErrorContext(ctx, "başarım güncellenemedi", "game_id", gameID, "key", key, "error", err)
+		return models.Achievement{}, err
+	}
+	return updated, nil
+}
+
+// Delete başarım tanımını ve kullanıcıların bu başarımı açtığı kayıtları siler
+func (r *AchievementRepositoryDB) Delete(ctx context.Context, gameID primitive.ObjectID, key string) (models.Achievement, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var deleted models.Achievement
+	if err := r.Achievements.FindOneAndDelete(ctx, bson.M{"game_id": gameID, "key": key}).Decode(&deleted); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.Achievement{}, ErrNotFound
+		}
+		r.Log.ErrorContext(ctx, "başarım silinemedi", "game_id", gameID, "key", key, "error", err)
+		return models.Achievement{}, err
+	}
+	if _, err := r.Unlocks.DeleteMany(ctx, bson.M{"achievement_id": deleted.ID}); err != nil {
+		r.Log.ErrorContext(ctx, "başarımın açılma kayıtları silinemedi", "achievement_id", deleted.ID, "error", err)
+		return deleted, err
+	}
+	return deleted, nil
+}
+
+// InsertUnlock açılma kaydını ekler ve başarımın açılma sayısını artırır; kullanıcı başarımı zaten açtıysa ErrDuplicate döner
+func (r *AchievementRepositoryDB) InsertUnlock(ctx context.Context, unlock models.AchievementUnlock) (models.AchievementUnlock, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	unlock.ID = primitive.NewObjectID()
+	unlock.ReceivedAt = time.Now()
+	if _, err := r.Unlocks.InsertOne(ctx, unlock); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return models.AchievementUnlock{}, ErrDuplicate
+		}
+		r.Log.ErrorContext(ctx, "başarım açılışı kaydedilemedi", "user_id", unlock.UserID, "achievement_id", unlock.AchievementID, "error", err)
+		return models.AchievementUnlock{}, err
+	}
+	if _, err := r.Achievements.UpdateByID(ctx, unlock.AchievementID, bson.M{"$inc": bson.M{"unlock_count": 1}}); err != nil {
+		r.Log.ErrorContext(ctx, "başarımın açılma sayısı artırılamadı", "achievement_id", unlock.AchievementID, "error", err)
+		return unlock, err
+	}
+	return unlock, nil
+}
+
+// ListUnlocks kullanıcının oyunda açtığı başarımları döndürür
+func (r *AchievementRepositoryDB) ListUnlocks(ctx context.Context, userID, gameID primitive.ObjectID) ([]models.AchievementUnlock, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	cursor, err := r.Unlocks.Find(ctx, bson.M{"user_id": userID, "game_id": gameID}, options.Find().SetSort(bson.D{{Key: "unlocked_at", Value: 1}}))
+	if err != nil {
+		r.Log.ErrorContext(ctx, "açılan başarımlar getirilemedi", "user_id", userID, "game_id", gameID, "error", err)
+		return nil, err
+	}
+	unlocks := []models.AchievementUnlock{}
+	if err := cursor.All(ctx, &unlocks); err != nil {
+		r.Log.ErrorContext(ctx, "açılan başarımlar çözümlenemedi", "user_id", userID, "game_id", gameID, "error", err)
+		return nil, err
+	}
+	return unlocks, nil
+}
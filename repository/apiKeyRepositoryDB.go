@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"api-steam/models"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// APIKeyRepository API anahtarlarının saklanması için gereken metodları tanımlar
+type APIKeyRepository interface {
+	Insert(ctx context.Context, key models.APIKey) (models.APIKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (models.APIKey, error)
+	List(ctx context.Context) ([]models.APIKey, error)
+	Revoke(ctx context.Context, id primitive.ObjectID) (bool, error)
+	TouchLastUsed(ctx context.Context, id primitive.ObjectID) error
+}
+
+// APIKeyRepositoryDB API anahtarlarını api_keys koleksiyonunda tutar
+type APIKeyRepositoryDB struct {
+	Collection *mongo.Collection
+	Log        *slog.Logger
+}
+
+// NewAPIKeyRepository api_keys koleksiyonu için repository oluşturur
+func NewAPIKeyRepository(collection *mongo.Collection, logger *slog.Logger) APIKeyRepository {
+	return &APIKeyRepositoryDB{Collection: collection, Log: logger}
+}
+
+// Insert yeni bir API anahtarı kaydeder
+func (r *APIKeyRepositoryDB) Insert(ctx context.Context, key models.APIKey) (models.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	key.ID = primitive.NewObjectID()
+	key.CreatedAt = time.Now()
+	if _, err := r.Collection.InsertOne(ctx, key); err != nil {
+		r.Log.ErrorContext(ctx, "API anahtarı eklenemedi", "name", key.Name, "error", err)
+		return models.APIKey{}, err
+	}
+	r.Log.InfoContext(ctx, "API anahtarı oluşturuldu", "id", key.ID, "name", key.Name, "prefix", key.Prefix)
+	return key, nil
+}
+
+// GetByPrefix açık kısmına göre anahtarı getirir
+func (r *APIKeyRepositoryDB) GetByPrefix(ctx context.Context, prefix string) (models.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var key models.APIKey
+	err := r.Collection.FindOne(ctx, bson.M{"prefix": prefix}).Decode(&key)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.APIKey{}, ErrNotFound
+		}
+		r.Log.ErrorContext(ctx, "API anahtarı getirilemedi", "prefix", prefix, "error", err)
+		return models.APIKey{}, err
+	}
+	return key, nil
+}
+
+// List tüm API anahtarlarını oluşturulma tarihine göre getirir
+func (r *APIKeyRepositoryDB) List(ctx context.Context) ([]models.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	cursor, err := r.Collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		r.Log.ErrorContext(ctx, "API anahtarları listelenemedi", "error", err)
+		return nil, err
+	}
+	var keys []models.APIKey
+	if err := cursor.All(ctx, &keys); err != nil {
+		r.Log.ErrorContext(ctx, "sorgu sonuçları okunamadı", "error", err)
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Revoke anahtarı iptal eder; iptal edilen anahtarla kimlik doğrulanamaz
+func (r *APIKeyRepositoryDB) Revoke(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	result, err := r.Collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"revoked": true}})
+	if err != nil {
+		r.Log.ErrorContext(ctx, "API anahtarı iptal edilemedi", "id", id, "error", err)
+		return false, err
+	}
+	if result.MatchedCount <= 0 {
+		return false, nil
+	}
+	r.Log.InfoContext(ctx, "API anahtarı iptal edildi", "id", id)
+	return true, nil
+}
+
+// TouchLastUsed anahtarın son kullanım zamanını günceller
+func (r *APIKeyRepositoryDB) TouchLastUsed(ctx context.Context, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	_, err := r.Collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"last_used_at": time.Now()}})
+	if err != nil {
+		r.Log.WarnContext(ctx, "API anahtarının son kullanım zamanı güncellenemedi", "id", id, "error", err)
+	}
+	return err
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"api-steam/models"
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AuditQuery denetim kayıtlarını filtrelemek için kullanılır; boş alanlar filtrelenmez
+type AuditQuery struct {
+	Resource   string
+	ResourceID string
+	ActorID    string
+	Action     string
+	Limit      int64
+}
+
+// AuditRepository denetim kayıtları için gereken metodları tanımlar; kayıtlar yalnızca eklenir, değiştirilmez
+type AuditRepository interface {
+	Insert(ctx context.Context, entry models.AuditEntry) error
+	List(ctx context.Context, query AuditQuery) ([]models.AuditEntry, error)
+}
+
+// AuditRepositoryDB denetim kayıtlarını audit_log koleksiyonunda tutar
+type AuditRepositoryDB struct {
+	Collection *mongo.Collection
+	Log        *slog.Logger
+}
+
+// NewAuditRepository audit_log koleksiyonu için repository oluşturur
+func NewAuditRepository(collection *mongo.Collection, logger *slog.Logger) AuditRepository {
+	return &AuditRepositoryDB{Collection: collection, Log: logger}
+}
+
+// Insert yeni bir denetim kaydı ekler
+func (r *AuditRepositoryDB) Insert(ctx context.Context, entry models.AuditEntry) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	entry.ID = primitive.NewObjectID()
+	if _, err := r.Collection.InsertOne(ctx, entry); err != nil {
+		r.Log.ErrorContext(ctx, "denetim kaydı eklenemedi", "action", entry.Action, "resource_id", entry.ResourceID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// List denetim kayıtlarını en yeniden başlayarak döndürür
+func (r *AuditRepositoryDB) List(ctx context.Context, query AuditQuery) ([]models.AuditEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	filter := bson.M{}
+	for field, value := range map[string]string{"resource": query.Resource, "resource_id": query.ResourceID, "actor_id": query.ActorID, "action": query.Action} {
+		if value != "" {
+			filter[field] = value
+		}
+	}
+	cursor, err := r.Collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "at", Value: -1}}).SetLimit(query.Limit))
+	if err != nil {
+		r.Log.ErrorContext(ctx, "denetim kayıtları getirilemedi", "error", err)
+		return nil, err
+	}
+	entries := []models.AuditEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		r.Log.ErrorContext(ctx, "denetim kayıtları çözümlenemedi", "error", err)
+		return nil, err
+	}
+	return entries, nil
+}
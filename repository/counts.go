@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// countByGame filtreye uyan belgeleri game_id alanına göre sayar; grafiklerin hız hesaplarında kullanılır
+func countByGame(ctx context.Context, collection *mongo.Collection, filter bson.M) (map[primitive.ObjectID]int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{"_id": "$game_id", "count": bson.M{"$sum": 1}}}},
+	}
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var rows []struct {
+		GameID primitive.ObjectID `bson:"_id"`
+		Count  int                `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+	counts := make(map[primitive.ObjectID]int, len(rows))
+	for _, row := range rows {
+		counts[row.GameID] = row.Count
+	}
+	return counts, nil
+}
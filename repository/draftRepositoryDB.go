@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"api-steam/models"
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DraftRepository oyun taslakları ve yayın geçmişi (revizyonlar) için gereken metodları tanımlar
+type DraftRepository interface {
+	Create(ctx context.Context, draft models.GameDraft) (models.GameDraft, error)
+	GetByGame(ctx context.Context, gameID primitive.ObjectID) (models.GameDraft, error)
+	List(ctx context.Context, state string) ([]models.GameDraft, error)
+	ListDue(ctx context.Context, now time.Time) ([]models.GameDraft, error)
+	Save(ctx context.Context, draft models.GameDraft, expectedStates ...string) (bool, error) //Taslağın durumu expectedStates'ten biriyse değiştirir
+	Delete(ctx context.Context, id primitive.ObjectID) error
+	InsertRevision(ctx context.Context, revision models.GameRevision) (models.GameRevision, error)
+	ListRevisions(ctx context.Context, gameID primitive.ObjectID) ([]models.GameRevision, error)
+}
+
+// DraftRepositoryDB taslakları game_drafts, revizyonları game_revisions koleksiyonunda tutar
+type DraftRepositoryDB struct {
+	Drafts    *mongo.Collection
+	Revisions *mongo.Collection
+	Log       *slog.Logger
+}
+
+// NewDraftRepository taslak ve revizyon koleksiyonları için repository oluşturur
+func NewDraftRepository(drafts, revisions *mongo.Collection, logger *slog.Logger) DraftRepository {
+	return &DraftRepositoryDB{Drafts: drafts, Revisions: revisions, Log: logger}
+}
+
+// Create taslağı ekler; oyunun zaten bir taslağı varsa ErrDuplicate döner
+func (r *DraftRepositoryDB) Create(ctx context.Context, draft models.GameDraft) (models.GameDraft, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	draft.ID = primitive.NewObjectID()
+	if _, err := r.Drafts.InsertOne(ctx, draft); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return models.GameDraft{}, ErrDuplicate
+		}
+		r.Log.ErrorContext(ctx, "taslak eklenemedi", "game_id", draft.GameID, "error", err)
+		return models.GameDraft{}, err
+	}
+	return draft, nil
+}
+
+// GetByGame oyunun taslağını getirir; taslak yoksa ErrNotFound döner
+func (r *DraftRepositoryDB) GetByGame(ctx context.Context, gameID primitive.ObjectID) (models.GameDraft, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var draft models.GameDraft
+	if err := r.Drafts.FindOne(ctx, bson.M{"game_id": gameID}).Decode(&draft); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.GameDraft{}, ErrNotFound
+		}
+		r.Log.ErrorContext(ctx, "taslak getirilemedi", "game_id", gameID, "error", err)
+		return models.GameDraft{}, err
+	}
+	return draft, nil
+}
+
+// List taslakları son güncellenenden başlayarak getirir; state boşsa tüm taslaklar döner
+func (r *DraftRepositoryDB) List(ctx context.Context, state string) ([]models.GameDraft, error) {
+	filter := bson.M{}
+	if state != "" {
+		filter["state"] = state
+	}
+	return r.find(ctx, filter, bson.D{{Key: "updated_at", Value: -1}})
+}
+
+// ListDue yayın zamanı gelmiş zamanlanmış taslakları yayın zamanına göre sıralı getirir
+func (r *DraftRepositoryDB) ListDue(ctx context.Context, now time.Time) ([]models.GameDraft, error) {
+	return r.find(ctx, bson.M{"state": models.DraftScheduled, "publish_at": bson.M{"$lte": now}}, bson.D{{Key: "publish_at", Value: 1}})
+}
+
+func (r *DraftRepositoryDB) find(ctx context.Context, filter bson.M, sort bson.D) ([]models.GameDraft, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	cursor, err := r.Drafts.Find(ctx, filter, options.Find().SetSort(sort))
+	if err != nil {
+		r.Log.ErrorContext(ctx, "taslaklar getirilemedi", "error", err)
+		return nil, err
+	}
+	drafts := []models.GameDraft{}
+	if err := cursor.All(ctx, &drafts); err != nil {
+		r.Log.ErrorContext(ctx, "taslaklar okunamadı", "error", err)
+		return nil, err
+	}
+	return drafts, nil
+}
+
+// Save taslağı yalnızca veritabanındaki durumu expectedStates'ten biriyse değiştirir; böylece aynı taslak üzerindeki
+// eşzamanlı onay, düzenleme ve yayınlar birbirini ezmez. Taslak yoksa ya da durumu değişmişse false döner.
+func (r *DraftRepositoryDB) Save(ctx context.Context, draft models.GameDraft, expectedStates ...string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	filter := bson.M{"_id": draft.ID}
+	if len(expectedStates) > 0 {
+		filter["state"] = bson.M{"$in": expectedStates}
+	}
+	draft.UpdatedAt = time.Now()
+	result, err := r.Drafts.ReplaceOne(ctx, filter, draft)
+	if err != nil {
+		r.Log.ErrorContext(ctx, "taslak kaydedilemedi", "id", draft.ID, "error", err)
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// Delete taslağı siler; taslak yoksa ErrNotFound döner
+func (r *DraftRepositoryDB) Delete(ctx context.Context, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	result, err := r.Drafts.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		r.Log.ErrorContext(ctx, "taslak silinemedi", "id", id, "error", err)
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// InsertRevision revizyonu oyunun son revizyon numarasının bir fazlasıyla ekler. Aynı numarayı eşzamanlı alan ikinci
+// yazma benzersiz indekse takılırsa numara yeniden hesaplanıp bir kez daha denenir.
+func (r *DraftRepositoryDB) InsertRevision(ctx context.Context, revision models.GameRevision) (models.GameRevision, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	revision.ID = primitive.NewObjectID()
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		var last models.GameRevision
+		err = r.Revisions.FindOne(ctx, bson.M{"game_id": revision.GameID}, options.FindOne().SetSort(bson.D{{Key: "number", Value: -1}}).SetProjection(bson.M{"number": 1})).Decode(&last)
+		if err != nil && err != mongo.ErrNoDocuments {
+			break
+		}
+		revision.Number = last.Number + 1
+		if _, err = r.Revisions.InsertOne(ctx, revision); err == nil || !mongo.IsDuplicateKeyError(err) {
+			break
+		}
+	}
+	if err != nil {
+		r.Log.ErrorContext(ctx, "revizyon kaydedilemedi", "game_id", revision.GameID, "error", err)
+		return models.GameRevision{}, err
+	}
+	return revision, nil
+}
+
+// ListRevisions oyunun revizyonlarını en yeniden başlayarak getirir
+func (r *DraftRepositoryDB) ListRevisions(ctx context.Context, gameID primitive.ObjectID) ([]models.GameRevision, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	cursor, err := r.Revisions.Find(ctx, bson.M{"game_id": gameID}, options.Find().SetSort(bson.D{{Key: "number", Value: -1}}))
+	if err != nil {
+		r.Log.ErrorContext(ctx, "revizyonlar getirilemedi", "game_id", gameID, "error", err)
+		return nil, err
+	}
+	revisions := []models.GameRevision{}
+	if err := cursor.All(ctx, &revisions); err != nil {
+		r.Log.ErrorContext(ctx, "revizyonlar okunamadı", "error", err)
+		return nil, err
+	}
+	return revisions, nil
+}
@@ -0,0 +1,10 @@
+package repository
+
+import "errors"
+
+var (
+	// ErrNotFound aranan kayıt veritabanında yoksa döner
+	ErrNotFound = errors.New("kayıt bulunamadı")
+	// ErrDuplicate benzersiz indeks ihlalinde (ör. aynı e-posta ile ikinci kayıt) döner
+	ErrDuplicate = errors.New("kayıt zaten mevcut")
+)
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"api-steam/events"
+	"api-steam/models"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// eventedProductRepository fiyat ve durum değişikliklerini olay olarak yayınlar; diğer metodlar olduğu gibi iletilir
+type eventedProductRepository struct {
+	ProductRepository
+	publisher events.Publisher
+}
+
+// NewEventedProductRepository Update, Patch, SetStatus ve ReplaceIfVersion sonrasında oyunun önceki ve sonraki halini karşılaştırıp olay yayınlayan bir katman ekler
+func NewEventedProductRepository(next ProductRepository, publisher events.Publisher) ProductRepository {
+	return &eventedProductRepository{ProductRepository: next, publisher: publisher}
+}
+
+func (r *eventedProductRepository) Update(ctx context.Context, id primitive.ObjectID, game models.Game) (bool, error) {
+	before, _ := r.ProductRepository.GetByID(ctx, id)
+	ok, err := r.ProductRepository.Update(ctx, id, game)
+	if ok && err == nil {
+		r.publishChanges(ctx, id, before)
+	}
+	return ok, err
+}
+
+func (r *eventedProductRepository) Patch(ctx context.Context, id primitive.ObjectID, updates map[string]interface{}) (bool, error) {
+	before, _ := r.ProductRepository.GetByID(ctx, id)
+	ok, err := r.ProductRepository.Patch(ctx, id, updates)
+	if ok && err == nil {
+		r.publishChanges(ctx, id, before)
+	}
+	return ok, err
+}
+
+func (r *eventedProductRepository) SetStatus(ctx context.Context, id primitive.ObjectID, change models.StatusChange) (bool, error) {
+	before, _ := r.ProductRepository.GetByID(ctx, id)
+	ok, err := r.ProductRepository.SetStatus(ctx, id, change)
+	if ok && err == nil {
+		r.publishChanges(ctx, id, before)
+	}
+	return ok, err
+}
+
+func (r *eventedProductRepository) ReplaceIfVersion(ctx context.Context, id primitive.ObjectID, game models.Game, version time.Time) (bool, error) {
+	before, _ := r.ProductRepository.GetByID(ctx, id)
+	ok, err := r.ProductRepository.ReplaceIfVersion(ctx, id, game, version)
+	if ok && err == nil {
+		r.publishChanges(ctx, id, before)
+	}
+	return ok, err
+}
+
+// publishChanges oyunun güncel halini okuyup önceki halle arasındaki değişiklikleri yayınlar
+func (r *eventedProductRepository) publishChanges(ctx context.Context, id primitive.ObjectID, before models.Game) {
+	after, err := r.ProductRepository.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+	for _, event := range events.GameChanges(before, after, time.Now()) {
+		r.publisher.Publish(ctx, event)
+	}
+}
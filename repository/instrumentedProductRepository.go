@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"api-steam/metrics"
+	"api-steam/models"
+	"api-steam/telemetry"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = telemetry.Tracer("api-steam/repository")
+
+// instrumentedProductRepository her ProductRepository metodunun MongoDB süresini Prometheus'a kaydeder ve bir span açar
+type instrumentedProductRepository struct {
+	next ProductRepository
+}
+
+// NewInstrumentedProductRepository verilen repository'yi metrik ve trace toplayan bir katmanla sarar
+func NewInstrumentedProductRepository(next ProductRepository) ProductRepository {
+	return &instrumentedProductRepository{next: next}
+}
+
+const productRepositoryName = "ProductRepository"
+
+// begin metod için "ProductRepositoryDB.<Metod>" span'ini açar; dönen fonksiyon süreyi kaydedip span'i kapatır
+func (r *instrumentedProductRepository) begin(ctx context.Context, method string) (context.Context, func(error)) {
+	start := time.Now()
+	ctx, span := tracer.Start(ctx, "ProductRepositoryDB."+method, trace.WithSpanKind(trace.SpanKindInternal))
+	return ctx, func(err error) {
+		metrics.ObserveRepository(productRepositoryName, method, start, err)
+		telemetry.EndSpan(span, err)
+	}
+}
+
+func (r *instrumentedProductRepository) Insert(ctx context.Context, game models.Game) (bool, error) {
+	ctx, done := r.begin(ctx, "Insert")
+	ok, err := r.next.Insert(ctx, game)
+	done(err)
+	return ok, err
+}
+
+func (r *instrumentedProductRepository) GetAll(ctx context.Context) ([]models.Game, error) {
+	ctx, done := r.begin(ctx, "GetAll")
+	games, err := r.next.GetAll(ctx)
+	done(err)
+	return games, err
+}
+
+func (r *instrumentedProductRepository) Delete(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	ctx, done := r.begin(ctx, "Delete")
+	ok, err := r.next.Delete(ctx, id)
+	done(err)
+	return ok, err
+}
+
+func (r *instrumentedProductRepository) Update(ctx context.Context, id primitive.ObjectID, game models.Game) (bool, error) {
+	ctx, done := r.begin(ctx, "Update")
+	ok, err := r.next.Update(ctx, id, game)
+	done(err)
+	return ok, err
+}
+
+func (r *instrumentedProductRepository) Patch(ctx context.Context, id primitive.ObjectID, updates map[string]interface{}) (bool, error) {
+	ctx, done := r.begin(ctx, "Patch")
+	ok, err := r.next.Patch(ctx, id, updates)
+	done(err)
+	return ok, err
+}
+
+func (r *instrumentedProductRepository) GetByID(ctx context.Context, id primitive.ObjectID) (models.Game, error) {
+	ctx, done := r.begin(ctx, "GetByID")
+	game, err := r.next.GetByID(ctx, id)
+	done(err)
+	return game, err
+}
+
+func (r *instrumentedProductRepository) GetByIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.Game, error) {
+	ctx, done := r.begin(ctx, "GetByIDs")
+	games, err := r.next.GetByIDs(ctx, ids)
+	done(err)
+	return games, err
+}
+
+func (r *instrumentedProductRepository) GetAndSorted(ctx context.Context, sortField string, order int) ([]models.Game, error) {
+	ctx, done := r.begin(ctx, "GetAndSorted")
+	games, err := r.next.GetAndSorted(ctx, sortField, order)
+	done(err)
+	return games, err
+}
+
+func (r *instrumentedProductRepository) GetByExactName(ctx context.Context, name string) ([]models.Game, error) {
+	ctx, done := r.begin(ctx, "GetByExactName")
+	games, err := r.next.GetByExactName(ctx, name)
+	done(err)
+	return games, err
+}
+
+func (r *instrumentedProductRepository) GetByPartialName(ctx context.Context, name string) ([]models.Game, error) {
+	ctx, done := r.begin(ctx, "GetByPartialName")
+	games, err := r.next.GetByPartialName(ctx, name)
+	done(err)
+	return games, err
+}
+
+func (r *instrumentedProductRepository) InsertMany(ctx context.Context, games []models.Game) (bool, error) {
+	ctx, done := r.begin(ctx, "InsertMany")
+	ok, err := r.next.InsertMany(ctx, games)
+	done(err)
+	return ok, err
+}
+
+func (r *instrumentedProductRepository) GetByPriceRange(ctx context.Context, minPrice float64, maxPrice float64) ([]models.Game, error) {
+	ctx, done := r.begin(ctx, "GetByPriceRange")
+	games, err := r.next.GetByPriceRange(ctx, minPrice, maxPrice)
+	done(err)
+	return games, err
+}
+
+func (r *instrumentedProductRepository) CountByStatus(ctx context.Context) (map[string]int64, error) {
+	ctx, done := r.begin(ctx, "CountByStatus")
+	counts, err := r.next.CountByStatus(ctx)
+	done(err)
+	return counts, err
+}
+
+func (r *instrumentedProductRepository) CountOnSale(ctx context.Context) (int64, error) {
+	ctx, done := r.begin(ctx, "CountOnSale")
+	count, err := r.next.CountOnSale(ctx)
+	done(err)
+	return count, err
+}
+
+func (r *instrumentedProductRepository) ApplyRatingDelta(ctx context.Context, id primitive.ObjectID, delta models.RatingTotals) error {
+	ctx, done := r.begin(ctx, "ApplyRatingDelta")
+	err := r.next.ApplyRatingDelta(ctx, id, delta)
+	done(err)
+	return err
+}
+
+func (r *instrumentedProductRepository) SetRatingTotals(ctx context.Context, id primitive.ObjectID, totals models.RatingTotals) error {
+	ctx, done := r.begin(ctx, "SetRatingTotals")
+	err := r.next.SetRatingTotals(ctx, id, totals)
+	done(err)
+	return err
+}
+
+func (r *instrumentedProductRepository) SetPlaytimeStats(ctx context.Context, stats map[primitive.ObjectID]models.PlaytimeStats) error {
+	ctx, done := r.begin(ctx, "SetPlaytimeStats")
+	err := r.next.SetPlaytimeStats(ctx, stats)
+	done(err)
+	return err
+}
+
+func (r *instrumentedProductRepository) GetChildren(ctx context.Context, parentID primitive.ObjectID) ([]models.Game, error) {
+	ctx, done := r.begin(ctx, "GetChildren")
+	games, err := r.next.GetChildren(ctx, parentID)
+	done(err)
+	return games, err
+}
+
+func (r *instrumentedProductRepository) GetReleases(ctx context.Context, query ReleaseQuery) ([]models.Game, error) {
+	ctx, done := r.begin(ctx, "GetReleases")
+	games, err := r.next.GetReleases(ctx, query)
+	done(err)
+	return games, err
+}
+
+func (r *instrumentedProductRepository) SetStatus(ctx context.Context, id primitive.ObjectID, change models.StatusChange) (bool, error) {
+	ctx, done := r.begin(ctx, "SetStatus")
+	ok, err := r.next.SetStatus(ctx, id, change)
+	done(err)
+	return ok, err
+}
+
+func (r *instrumentedProductRepository) ReplaceIfVersion(ctx context.Context, id primitive.ObjectID, game models.Game, version time.Time) (bool, error) {
+	ctx, done := r.begin(ctx, "ReplaceIfVersion")
+	ok, err := r.next.ReplaceIfVersion(ctx, id, game, version)
+	done(err)
+	return ok, err
+}
+
+func (r *instrumentedProductRepository) SetRequirementSpecs(ctx context.Context, id primitive.ObjectID, minimum, recommended *models.HardwareSpec) error {
+	ctx, done := r.begin(ctx, "SetRequirementSpecs")
+	err := r.next.SetRequirementSpecs(ctx, id, minimum, recommended)
+	done(err)
+	return err
+}
+
+func (r *instrumentedProductRepository) GetBySpec(ctx context.Context, name string, spec models.HardwareSpec) ([]models.Game, error) {
+	ctx, done := r.begin(ctx, "GetBySpec")
+	games, err := r.next.GetBySpec(ctx, name, spec)
+	done(err)
+	return games, err
+}
+
+func (r *instrumentedProductRepository) SetTranslation(ctx context.Context, id primitive.ObjectID, translation models.Translation) error {
+	ctx, done := r.begin(ctx, "SetTranslation")
+	err := r.next.SetTranslation(ctx, id, translation)
+	done(err)
+	return err
+}
+
+func (r *instrumentedProductRepository) RemoveTranslation(ctx context.Context, id primitive.ObjectID, locale string) error {
+	ctx, done := r.begin(ctx, "RemoveTranslation")
+	err := r.next.RemoveTranslation(ctx, id, locale)
+	done(err)
+	return err
+}
+
+func (r *instrumentedProductRepository) GetBundlesContaining(ctx context.Context, id primitive.ObjectID) ([]models.Game, error) {
+	ctx, done := r.begin(ctx, "GetBundlesContaining")
+	games, err := r.next.GetBundlesContaining(ctx, id)
+	done(err)
+	return games, err
+}
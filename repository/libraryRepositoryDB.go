@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"api-steam/models"
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LibraryQuery kütüphane listesinin filtre, sıralama ve sayfalama seçenekleridir.
+// Title ve Sort oyunun güncel başlığına bağlı olduğu için servis tarafından uygulanır; repository yalnızca ilk üç alanı kullanır.
+type LibraryQuery struct {
+	Source       string    // Boşsa tüm edinme yolları
+	AcquiredFrom time.Time // Sıfırsa alt sınır yok
+	AcquiredTo   time.Time // Sıfırsa üst sınır yok
+	Title        string    // Başlıkta geçen metin (büyük/küçük harf duyarsız)
+	Sort         string    // "acquired" (varsayılan, en yeni başta), "title" veya "price"
+	Skip         int64
+	Limit        int64
+}
+
+// LibraryRepository kullanıcıların sahip olduğu oyunların kayıtları için gereken metodları tanımlar
+type LibraryRepository interface {
+	Grant(ctx context.Context, entry models.LibraryEntry) (models.LibraryEntry, error)
+	Get(ctx context.Context, userID, gameID primitive.ObjectID) (models.LibraryEntry, error)
+	ListByUser(ctx context.Context, userID primitive.ObjectID, query LibraryQuery) ([]models.LibraryEntry, error)
+	Revoke(ctx context.Context, userID, gameID primitive.ObjectID) (models.LibraryEntry, error)
+	CountByGame(ctx context.Context, gameID primitive.ObjectID) (int64, error)
+	CountPurchasesSince(ctx context.Context, since time.Time) (map[primitive.ObjectID]int, error)
+}
+
+// LibraryRepositoryDB sahiplik kayıtlarını library koleksiyonunda (kullanıcı+oyun başına bir belge) tutar
+type LibraryRepositoryDB struct {
+	Collection *mongo.Collection
+	Log        *slog.Logger
+}
+
+// NewLibraryRepository library koleksiyonu için repository oluşturur
+func NewLibraryRepository(collection *mongo.Collection, logger *slog.Logger) LibraryRepository {
+	return &LibraryRepositoryDB{Collection: collection, Log: logger}
+}
+
+// Grant oyunu kullanıcının kütüphanesine ekler; kullanıcı oyuna zaten sahipse ErrDuplicate döner
+func (r *LibraryRepositoryDB) Grant(ctx context.Context, entry models.LibraryEntry) (models.LibraryEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	entry.ID = primitive.NewObjectID()
+	entry.CreatedAt = time.Now()
+	if entry.AcquiredAt.IsZero() {
+		entry.AcquiredAt = entry.CreatedAt
+	}
+	if _, err := r.Collection.InsertOne(ctx, entry); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return models.LibraryEntry{}, ErrDuplicate
+		}
+		r.Log.ErrorContext(ctx, "kütüphaneye eklenemedi", "user_id", entry.UserID, "game_id", entry.GameID, "error", err)
+		return models.LibraryEntry{}, err
+	}
+	return entry, nil
+}
+
+// Get kullanıcının oyun için sahiplik kaydını getirir; sahip değilse ErrNotFound döner
+func (r *LibraryRepositoryDB) Get(ctx context.Context, userID, gameID primitive.ObjectID) (models.LibraryEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var entry models.LibraryEntry
+	if err := r.Collection.FindOne(ctx, bson.M{"user_id": userID, "game_id": gameID}).Decode(&entry); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.LibraryEntry{}, ErrNotFound
+		}
+		r.Log.ErrorContext(ctx, "sahiplik kaydı getirilemedi", "user_id", userID, "game_id", gameID, "error", err)
+		return models.LibraryEntry{}, err
+	}
+	return entry, nil
+}
+
+// ListByUser kullanıcının kütüphanesini en son edinilenden başlayarak döndürür
+func (r *LibraryRepositoryDB) ListByUser(ctx context.Context, userID primitive.ObjectID, query LibraryQuery) ([]models.LibraryEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	filter := bson.M{"user_id": userID}
+	if query.Source != "" {
+		filter["source"] = query.Source
+	}
+	acquired := bson.M{}
+	if !query.AcquiredFrom.IsZero() {
+		acquired["$gte"] = query.AcquiredFrom
+	}
+	if !query.AcquiredTo.IsZero() {
+		acquired["$lte"] = query.AcquiredTo
+	}
+	if len(acquired) > 0 {
+		filter["acquired_at"] = acquired
+	}
+	cursor, err := r.Collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "acquired_at", Value: -1}}))
+	if err != nil {
+		r.Log.ErrorContext(ctx, "kütüphane getirilemedi", "user_id", userID, "error", err)
+		return nil, err
+	}
+	entries := []models.LibraryEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		r.Log.ErrorContext(ctx, "kütüphane çözümlenemedi", "user_id", userID, "error", err)
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Revoke sahiplik kaydını siler (ör. iade) ve silinen kaydı döndürür
+func (r *LibraryRepositoryDB) Revoke(ctx context.Context, userID, gameID primitive.ObjectID) (models.LibraryEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var entry models.LibraryEntry
+	if err := r.Collection.FindOneAndDelete(ctx, bson.M{"user_id": userID, "game_id": gameID}).Decode(&entry); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.LibraryEntry{}, ErrNotFound
+		}
+		r.Log.ErrorContext(ctx, "sahiplik kaydı silinemedi", "user_id", userID, "game_id", gameID, "error", err)
+		return models.LibraryEntry{}, err
+	}
+	return entry, nil
+}
+
+// CountByGame oyuna sahip kullanıcı sayısını döndürür
+func (r *LibraryRepositoryDB) CountByGame(ctx context.Context, gameID primitive.ObjectID) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	count, err := r.Collection.CountDocuments(ctx, bson.M{"game_id": gameID})
+	if err != nil {
+		r.Log.ErrorContext(ctx, "oyunun sahipleri sayılamadı", "game_id", gameID, "error", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// CountPurchasesSince verilen zamandan sonraki satın almaları (hediye ve anahtarlar hariç) oyuna göre sayar
+func (r *LibraryRepositoryDB) CountPurchasesSince(ctx context.Context, since time.Time) (map[primitive.ObjectID]int, error) {
+	counts, err := countByGame(ctx, r.Collection, bson.M{"source": models.AcquiredPurchase, "acquired_at": bson.M{"$gte": since}})
+	if err != nil {
+		r.Log.ErrorContext(ctx, "satın almalar sayılamadı", "since", since, "error", err)
+		return nil, err
+	}
+	return counts, nil
+}
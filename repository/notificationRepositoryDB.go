@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"api-steam/models"
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// NotificationRepository uygulama içi gelen kutusu için gereken metodları tanımlar
+type NotificationRepository interface {
+	Insert(ctx context.Context, notification models.Notification) (models.Notification, error)
+	ListByUser(ctx context.Context, userID primitive.ObjectID, unreadOnly bool, limit int64) ([]models.Notification, error)
+	MarkRead(ctx context.Context, userID, id primitive.ObjectID) error
+}
+
+// NotificationRepositoryDB bildirimleri notifications koleksiyonunda tutar
+type NotificationRepositoryDB struct {
+	Collection *mongo.Collection
+	Log        *slog.Logger
+}
+
+// NewNotificationRepository notifications koleksiyonu için repository oluşturur
+func NewNotificationRepository(collection *mongo.Collection, logger *slog.Logger) NotificationRepository {
+	return &NotificationRepositoryDB{Collection: collection, Log: logger}
+}
+
+// Insert yeni bir bildirim kaydeder
+func (r *NotificationRepositoryDB) Insert(ctx context.Context, notification models.Notification) (models.Notification, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	notification.ID = primitive.NewObjectID()
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = time.Now()
+	}
+	if _, err := r.Collection.InsertOne(ctx, notification); err != nil {
+		r.Log.ErrorContext(ctx, "bildirim kaydedilemedi", "user_id", notification.UserID, "error", err)
+		return models.Notification{}, err
+	}
+	return notification, nil
+}
+
+// ListByUser kullanıcının bildirimlerini en yeniden başlayarak döndürür
+func (r *NotificationRepositoryDB) ListByUser(ctx context.Context, userID primitive.ObjectID, unreadOnly bool, limit int64) ([]models.Notification, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	filter := bson.M{"user_id": userID}
+	if unreadOnly {
+		filter["read_at"] = bson.M{"$exists": false}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(limit)
+	cursor, err := r.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.Log.ErrorContext(ctx, "bildirimler getirilemedi", "user_id", userID, "error", err)
+		return nil, err
+	}
+	notifications := []models.Notification{}
+	if err := cursor.All(ctx, &notifications); err != nil {
+		r.Log.ErrorContext(ctx, "bildirimler çözümlenemedi", "user_id", userID, "error", err)
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// MarkRead kullanıcının bildirimini okundu olarak işaretler
+func (r *NotificationRepositoryDB) MarkRead(ctx context.Context, userID, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	result, err := r.Collection.UpdateOne(ctx, bson.M{"_id": id, "user_id": userID}, bson.M{"$set": bson.M{"read_at": time.Now()}})
+	if err != nil {
+		r.Log.ErrorContext(ctx, "bildirim okundu işaretlenemedi", "id", id, "error", err)
+		return err
+	}
+	if result.MatchedCount <= 0 {
+		return ErrNotFound
+	}
+	return nil
+}
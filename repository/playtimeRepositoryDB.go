@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"api-steam/models"
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PlaytimeRepository oynama oturumları ve kullanıcı başına oynama istatistikleri için gereken metodları tanımlar
+type PlaytimeRepository interface {
+	InsertSession(ctx context.Context, session models.PlaySession) (models.PlaySession, error)
+	AddToStats(ctx context.Context, session models.PlaySession) error
+	GetStats(ctx context.Context, userID, gameID primitive.ObjectID) (models.PlayStats, error)
+	ListStats(ctx context.Context, userID primitive.ObjectID, sort string, skip, limit int64) ([]models.PlayStats, error)
+	ListSessions(ctx context.Context, userID, gameID primitive.ObjectID, limit int64) ([]models.PlaySession, error)
+	ForEachGame(ctx context.Context, fn func(gameID primitive.ObjectID, totals []int64) error) error
+}
+
+// PlaytimeRepositoryDB oturumları play_sessions, kullanıcı+oyun toplamlarını play_stats koleksiyonunda tutar
+type PlaytimeRepositoryDB struct {
+	Sessions *mongo.Collection
+	Stats    *mongo.Collection
+	Log      *slog.Logger
+}
+
+// NewPlaytimeRepository play_sessions ve play_stats koleksiyonları için repository oluşturur
+func NewPlaytimeRepository(sessions, stats *mongo.Collection, logger *slog.Logger) PlaytimeRepository {
+	return &PlaytimeRepositoryDB{Sessions: sessions, Stats: stats, Log: logger}
+}
+
+// InsertSession oturumu kaydeder; aynı kullanıcı, oyun ve başlangıç zamanıyla gelen oturum ErrDuplicate döner (istemci tekrar gönderebilir)
+func (r *PlaytimeRepositoryDB) InsertSession(ctx context.Context, session models.PlaySession) (models.PlaySession, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	session.ID = primitive.NewObjectID()
+	session.ReceivedAt = time.Now()
+	if _, err := r.Sessions.InsertOne(ctx, session); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return models.PlaySession{}, ErrDuplicate
+		}
+		r.Log.ErrorContext(ctx, "oynama oturumu kaydedilemedi", "user_id", session.UserID, "game_id", session.GameID, "error", err)
+		return models.PlaySession{}, err
+	}
+	return session, nil
+}
+
+// AddToStats oturumu kullanıcının oyun toplamına ekler; kayıt yoksa oluşturur
+func (r *PlaytimeRepositoryDB) AddToStats(ctx context.Context, session models.PlaySession) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	update := bson.M{
+		"$inc": bson.M{"total_seconds": session.DurationSeconds, "session_count": 1},
+		"$min": bson.M{"first_played_at": session.StartedAt},
+		"$max": bson.M{"last_played_at": session.EndedAt},
+	}
+	filter := bson.M{"user_id": session.UserID, "game_id": session.GameID}
+	if _, err := r.Stats.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		r.Log.ErrorContext(ctx, "oynama istatistiği güncellenemedi", "user_id", session.UserID, "game_id", session.GameID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// GetStats kullanıcının oyundaki toplamını getirir; hiç oturum yoksa ErrNotFound döner
+func (r *PlaytimeRepositoryDB) GetStats(ctx context.Context, userID, gameID primitive.ObjectID) (models.PlayStats, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var stats models.PlayStats
+	if err := r.Stats.FindOne(ctx, bson.M{"user_id": userID, "game_id": gameID}).Decode(&stats); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.PlayStats{}, ErrNotFound
+		}
+		r.Log.ErrorContext(ctx, "oynama istatistiği getirilemedi", "user_id", userID, "game_id", gameID, "error", err)
+		return models.PlayStats{}, err
+	}
+	return stats, nil
+}
+
+// ListStats kullanıcının oyun başına toplamlarını döndürür; sort "total" ise en çok oynanan, değilse en son oynanan başta olur
+func (r *PlaytimeRepositoryDB) ListStats(ctx context.Context, userID primitive.ObjectID, sort string, skip, limit int64) ([]models.PlayStats, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	order := bson.D{{Key: "last_played_at", Value: -1}}
+	if sort == "total" {
+		order = bson.D{{Key: "total_seconds", Value: -1}, {Key: "last_played_at", Value: -1}}
+	}
+	cursor, err := r.Stats.Find(ctx, bson.M{"user_id": userID}, options.Find().SetSort(order).SetSkip(skip).SetLimit(limit))
+	if err != nil {
+		r.Log.ErrorContext(ctx, "oynama istatistikleri getirilemedi", "user_id", userID, "error", err)
+		return nil, err
+	}
+	stats := []models.PlayStats{}
+	if err := cursor.All(ctx, &stats); err != nil {
+		r.Log.ErrorContext(ctx, "oynama istatistikleri çözümlenemedi", "user_id", userID, "error", err)
+		return nil, err
+	}
+	return stats, nil
+}
+
+// ListSessions kullanıcının oyundaki son oturumlarını en yeniden başlayarak döndürür
+func (r *PlaytimeRepositoryDB) ListSessions(ctx context.Context, userID, gameID primitive.ObjectID, limit int64) ([]models.PlaySession, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	opts := options.Find().SetSort(bson.D{{Key: "started_at", Value: -1}}).SetLimit(limit)
+	cursor, err := r.Sessions.Find(ctx, bson.M{"user_id": userID, "game_id": gameID}, opts)
+	if err != nil {
+		r.Log.ErrorContext(ctx, "oynama oturumları getirilemedi", "user_id", userID, "game_id", gameID, "error", err)
+		return nil, err
+	}
+	sessions := []models.PlaySession{}
+	if err := cursor.All(ctx, &sessions); err != nil {
+		r.Log.ErrorContext(ctx, "oynama oturumları çözümlenemedi", "user_id", userID, "game_id", gameID, "error", err)
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// ForEachGame play_stats'ı oyuna ve toplam süreye göre sıralı okur ve her oyun için oyuncu toplamlarını (saniye, artan sırada) fn'e verir.
+// Bellekte aynı anda yalnızca tek oyunun toplamları tutulur.
+func (r *PlaytimeRepositoryDB) ForEachGame(ctx context.Context, fn func(gameID primitive.ObjectID, totals []int64) error) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+	opts := options.Find().
+		SetSort(bson.D{{Key: "game_id", Value: 1}, {Key: "total_seconds", Value: 1}}).
+		SetProjection(bson.M{"_id": 0, "game_id": 1, "total_seconds": 1})
+	cursor, err := r.Stats.Find(ctx, bson.M{"total_seconds": bson.M{"$gt": 0}}, opts)
+	if err != nil {
+		r.Log.ErrorContext(ctx, "oynama istatistikleri taranamadı", "error", err)
+		return err
+	}
+	defer cursor.Close(ctx)
+	var current primitive.ObjectID
+	var totals []int64
+	for cursor.Next(ctx) {
+		var row models.PlayStats
+		if err := cursor.Decode(&row); err != nil {
+			return err
+		}
+		if row.GameID != current && len(totals) > 0 {
+			if err := fn(current, totals); err != nil {
+				return err
+			}
+			totals = totals[:0]
+		}
+		current = row.GameID
+		totals = append(totals, row.TotalSeconds)
+	}
+	if err := cursor.Err(); err != nil {
+		r.Log.ErrorContext(ctx, "oynama istatistikleri okunamadı", "error", err)
+		return err
+	}
+	if len(totals) > 0 {
+		return fn(current, totals)
+	}
+	return nil
+}
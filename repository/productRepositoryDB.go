@@ -3,8 +3,8 @@ package repository
 import (
 	"api-steam/models"
 	"context"
-	"fmt"
-	"log"
+	"log/slog"
+	"regexp"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -15,46 +15,70 @@ import (
 
 // ProductRepository arayüzü, ürün işlemleri için gereken metodları tanımlar
 type ProductRepository interface {
-	Insert(game models.Game) (bool, error)
-	GetAll() ([]models.Game, error)
-	Delete(id primitive.ObjectID) (bool, error) //Mongo db deki verimi primitive.ObjectID olduğu için primitive.ObjectID tipinde yolamam gerekiyor
-	Update(id primitive.ObjectID, game models.Game) (bool, error)
-	Patch(id primitive.ObjectID, updates map[string]interface{}) (bool, error)
-	GetByID(id primitive.ObjectID) (models.Game, error)              // "*" eklendi
-	GetAndSorted(sortField string, order int) ([]models.Game, error) //sortField string, order int   sortField=sıralamanın neye göre olcağı  order=+1 artana göre -1 azalana göre sıralalr
-	GetByExactName(name string) ([]models.Game, error)
-	GetByPartialName(name string) ([]models.Game, error)
-	InsertMany(games []models.Game) (bool, error)
-	GetByPriceRange(minPrice float64, maxPrice float64) ([]models.Game, error)
+	Insert(ctx context.Context, game models.Game) (bool, error)
+	GetAll(ctx context.Context) ([]models.Game, error)
+	Delete(ctx context.Context, id primitive.ObjectID) (bool, error) //Mongo db deki verimi primitive.ObjectID olduğu için primitive.ObjectID tipinde yolamam gerekiyor
+	Update(ctx context.Context, id primitive.ObjectID, game models.Game) (bool, error)
+	Patch(ctx context.Context, id primitive.ObjectID, updates map[string]interface{}) (bool, error)
+	GetByID(ctx context.Context, id primitive.ObjectID) (models.Game, error)              // "*" eklendi
+	GetByIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.Game, error)        //Verilen ID'lerdeki oyunları getirir; bulunamayanlar atlanır
+	GetAndSorted(ctx context.Context, sortField string, order int) ([]models.Game, error) //sortField string, order int   sortField=sıralamanın neye göre olcağı  order=+1 artana göre -1 azalana göre sıralalr
+	GetByExactName(ctx context.Context, name string) ([]models.Game, error)
+	GetByPartialName(ctx context.Context, name string) ([]models.Game, error)
+	InsertMany(ctx context.Context, games []models.Game) (bool, error)
+	GetByPriceRange(ctx context.Context, minPrice float64, maxPrice float64) ([]models.Game, error)
+	CountByStatus(ctx context.Context) (map[string]int64, error)
+	CountOnSale(ctx context.Context) (int64, error)
+	ApplyRatingDelta(ctx context.Context, id primitive.ObjectID, delta models.RatingTotals) error                    //Yorum eklenince/düzenlenince/silinince toplamları fark kadar değiştirir
+	SetRatingTotals(ctx context.Context, id primitive.ObjectID, totals models.RatingTotals) error                    //Toplamları yorumlardan yeniden hesaplanmış değerlerle değiştirir
+	SetPlaytimeStats(ctx context.Context, stats map[primitive.ObjectID]models.PlaytimeStats) error                   //Oturumlardan hesaplanan oynama sürelerini toplu olarak yazar
+	GetChildren(ctx context.Context, parentID primitive.ObjectID) ([]models.Game, error)                             //Ana oyuna bağlı DLC ve sürümleri getirir
+	GetBundlesContaining(ctx context.Context, id primitive.ObjectID) ([]models.Game, error)                          //Oyunu içeren paketleri getirir
+	GetReleases(ctx context.Context, query ReleaseQuery) ([]models.Game, error)                                      //Çıkış tarihi aralıktaki oyunları tarihe göre sıralı getirir
+	SetStatus(ctx context.Context, id primitive.ObjectID, change models.StatusChange) (bool, error)                  //Durum hâlâ change.From ise geçişi uygular ve geçmişe ekler
+	ReplaceIfVersion(ctx context.Context, id primitive.ObjectID, game models.Game, version time.Time) (bool, error)  //updated_at hâlâ version ise oyunu tamamen değiştirir
+	SetRequirementSpecs(ctx context.Context, id primitive.ObjectID, minimum, recommended *models.HardwareSpec) error //Gereksinim metinlerinden hesaplanan donanım değerlerini yazar
+	GetBySpec(ctx context.Context, name string, spec models.HardwareSpec) ([]models.Game, error)                     //Minimum gereksinimleri verilen donanımı aşmayan oyunları getirir
+	SetTranslation(ctx context.Context, id primitive.ObjectID, translation models.Translation) error                 //Oyunun bir dildeki çevirisini ekler ya da değiştirir
+	RemoveTranslation(ctx context.Context, id primitive.ObjectID, locale string) error                               //Oyunun bir dildeki çevirisini siler
+}
+
+// ReleaseQuery çıkış takvimi sorgusunun seçenekleridir. Oyunun kendi tarihi ya da platformlarından birinin tarihi
+// [From, To) aralığındaysa oyun döner; To sıfırsa üst sınır yoktur.
+type ReleaseQuery struct {
+	From, To       time.Time
+	Platform       string // Boş değilse yalnızca bu platformdaki oyunlar (büyük/küçük harf duyarsız)
+	IncludeUndated bool   // Tarihi olmayan "coming_soon" durumundaki oyunlar da dahil edilir
 }
 
 // ProductRepositoryDB, MongoDB işlemleri için collection(BAĞLANTI-DATABASE) ÇOK ALGILAYAMADIM
 type ProductRepositoryDB struct {
 	TodoCollection *mongo.Collection //mongo.Collection: MongoDB'deki bir koleksiyonu temsil eder (SQL'deki tabloya benzer)
+	Log            *slog.Logger
 }
 
 // TodoCollection ile MongoDB koleksiyonuna erişim sağlayan repository nesnesini oluşturur
-func NewProductRepository(dbClient *mongo.Collection) ProductRepository {
-	return &ProductRepositoryDB{TodoCollection: dbClient}
+func NewProductRepository(dbClient *mongo.Collection, logger *slog.Logger) ProductRepository {
+	return &ProductRepositoryDB{TodoCollection: dbClient, Log: logger}
 }
 
 // Veritabanına tek bir oyun ekler ve başarı durumunu döndürür
-func (t *ProductRepositoryDB) Insert(game models.Game) (bool, error) { //t *ProductRepositoryDB bağlantı için reciver ettik
+func (t *ProductRepositoryDB) Insert(ctx context.Context, game models.Game) (bool, error) { //t *ProductRepositoryDB bağlantı için reciver ettik
 	// Gerekli alanları doldur
 	game.ID = primitive.NewObjectID() //Mongo db nin kendi id si hariç bizim filtereememiz için benzersiz bir ıd atamada kulandık
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel() // Fonksiyon bittiğinde context iptal edilir
 	result, err := t.TodoCollection.InsertOne(ctx, game)
 	if err != nil {
-		log.Printf("Repository: Veritabanına oyun eklenirken hata: %v", err)
+		t.Log.ErrorContext(ctx, "oyun eklenemedi", "error", err)
 		return false, err
 	}
-	log.Printf("Repository: MongoDB'ye ekleme başarılı, ID: %v", result.InsertedID)
+	t.Log.InfoContext(ctx, "oyun eklendi", "id", result.InsertedID)
 	return true, nil
 }
 
 // Veritabanına birden fazla oyun toplu olarak ekler ve başarı durumunu döndürür
-func (t *ProductRepositoryDB) InsertMany(games []models.Game) (bool, error) { //t *ProductRepositoryDB bağlantı için reciver ettik
+func (t *ProductRepositoryDB) InsertMany(ctx context.Context, games []models.Game) (bool, error) { //t *ProductRepositoryDB bağlantı için reciver ettik
 	var gamelist []interface{} //interface{} yapıyoruz ve yeni bir dizi oluşturuyoruz çünkü Insertmany interface{} istiyor
 	for i := range games {
 		games[i].ID = primitive.NewObjectID()
@@ -62,181 +86,186 @@ func (t *ProductRepositoryDB) InsertMany(games []models.Game) (bool, error) { //
 		games[i].UpdatedAt = time.Now()
 		gamelist = append(gamelist, games[i])
 	} //Mongo db nin kendi id si hariç bizim filtereememiz için benzersiz bir ıd atamada kulandık
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel() // Fonksiyon bittiğinde context iptal edilir
 	result, err := t.TodoCollection.InsertMany(ctx, gamelist)
 	if err != nil {
-		log.Printf("Repository: Toplu oyun eklenirken hata: %v", err)
+		t.Log.ErrorContext(ctx, "toplu oyun eklenemedi", "count", len(games), "error", err)
 		return false, err
 	}
-	log.Printf("Repository: MongoDB'ye ekleme başarılı, ID: %v", len(result.InsertedIDs))
+	t.Log.InfoContext(ctx, "oyunlar toplu eklendi", "count", len(result.InsertedIDs))
 	return true, nil
 }
 
 // Veritabanındaki tüm oyunları bir dizi olarak getirir
-func (t *ProductRepositoryDB) GetAll() ([]models.Game, error) { //t *ProductRepositoryDB bağlantı için reciver ettik
-	var game models.Game
-	var games []models.Game
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (t *ProductRepositoryDB) GetAll(ctx context.Context) ([]models.Game, error) { //t *ProductRepositoryDB bağlantı için reciver ettik
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()                                      // Fonksiyon bittiğinde context iptal edilir
 	result, err := t.TodoCollection.Find(ctx, bson.M{}) //collection contextinden .find veri çekmek için kulanılır örnek dökümanı getrir
 	if err != nil {
-		log.Printf("Repository: Veritabanına oyun çekerken hata: %v", err)
+		t.Log.ErrorContext(ctx, "oyunlar okunamadı", "error", err)
 		return nil, err
 	}
-	for result.Next(ctx) { //decode parça parça gelen veride gezinmek içn .Next() kulanılı pythondaki gibi44
-		if err := result.Decode(&game); err != nil {
-			log.Printf("Repository: Veritabanına oyun çekerken hata: %v", err)
-			return nil, err
-		}
-		games = append(games, game)
+	games, err := decodeGames(ctx, result) //decode parça parça gelen veride gezinmek içn .Next() kulanılı pythondaki gibi44
+	if err != nil {
+		t.Log.ErrorContext(ctx, "oyunlar okunamadı", "error", err)
+		return nil, err
 	}
 	return games, nil
 }
 
 // Belirtilen ID'ye sahip oyunu veritabanından siler
-func (t *ProductRepositoryDB) Delete(id primitive.ObjectID) (bool, error) { //t *ProductRepositoryDB bağlantı için reciver ettik
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (t *ProductRepositoryDB) Delete(ctx context.Context, id primitive.ObjectID) (bool, error) { //t *ProductRepositoryDB bağlantı için reciver ettik
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()                                                    // Fonksiyon bittiğinde context iptal edilir
 	result, err := t.TodoCollection.DeleteOne(ctx, bson.M{"_id": id}) //colectionda bir nesne silmek için talep
 	if err != nil || result.DeletedCount <= 0 {
-		log.Printf("Repository: Veritabanına oyun çekerken hata: %v", err)
+		t.Log.WarnContext(ctx, "oyun silinemedi", "id", id, "error", err)
 		return false, err
 	}
 	return true, nil
 }
 
 // Belirtilen ID'ye sahip oyunu tamamen günceller (PUT)
-func (t *ProductRepositoryDB) Update(id primitive.ObjectID, game models.Game) (bool, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (t *ProductRepositoryDB) Update(ctx context.Context, id primitive.ObjectID, game models.Game) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 	game.ID = id                                                             //Güncelenecek objenin ıd si değişmemeli
 	game.UpdatedAt = time.Now()                                              // Güncelleme zamanını güncelle
 	result, err := t.TodoCollection.ReplaceOne(ctx, bson.M{"_id": id}, game) //ReplaceOne ile belgenin tamamını güncele
 	if err != nil {
-		log.Printf("Repository: Veritabanında oyun güncellenirken hata: %v", err)
+		t.Log.ErrorContext(ctx, "oyun güncellenemedi", "id", id, "error", err)
 		return false, err
 	}
 	if result.MatchedCount <= 0 { //gÜNCELENEN BELGE SAYISI KONTROLÜ
-		log.Printf("Repository: Güncellenecek oyun bulunamadı, ID: %v", id)
+		t.Log.WarnContext(ctx, "güncellenecek oyun bulunamadı", "id", id)
 		return false, nil
 	}
 	return true, nil
 }
 
 // Belirtilen ID'ye sahip oyunun sadece belirli alanlarını günceller (PATCH)
-func (t *ProductRepositoryDB) Patch(id primitive.ObjectID, updates map[string]interface{}) (bool, error) {
+func (t *ProductRepositoryDB) Patch(ctx context.Context, id primitive.ObjectID, updates map[string]interface{}) (bool, error) {
 	// Context tanımlama eklendi
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
-	updates["updatedAt"] = time.Now()                                                          //güncelenme tarihini değişirmek için
+	updates["updated_at"] = time.Now()                                                         //güncelenme tarihini değişirmek için
 	result, err := t.TodoCollection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": updates}) //patch işlemi için Updateone komutunu kulandık
 	if err != nil {
-		log.Printf("Repository: Veritabanında oyun güncellenirken hata: %v", err)
+		t.Log.ErrorContext(ctx, "oyun güncellenemedi", "id", id, "error", err)
 		return false, err
 	}
 	if result.MatchedCount <= 0 {
-		log.Printf("Repository: Güncellenecek oyun bulunamadı, ID: %v", id)
+		t.Log.WarnContext(ctx, "güncellenecek oyun bulunamadı", "id", id)
 		return false, nil
 	}
-	log.Printf("Repository: MongoDB'de kısmi güncelleme başarılı, ID: %v", id)
+	t.Log.DebugContext(ctx, "oyun kısmi güncellendi", "id", id)
 	return true, nil
 }
 
 // Belirtilen ID'ye göre tek bir oyun verisini getirir
-func (t *ProductRepositoryDB) GetByID(id primitive.ObjectID) (models.Game, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (t *ProductRepositoryDB) GetByID(ctx context.Context, id primitive.ObjectID) (models.Game, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 	var game models.Game
 	err := t.TodoCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&game) //FindOne verilen id ye göre bulur ve decode ile tanımlanan game in referans adresine atar
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			log.Printf("Repository: ID'si %v olan oyun bulunamadı", id)
-			return models.Game{}, fmt.Errorf("oyun bulunamadı") // Boş game ve hata döndür
+			t.Log.DebugContext(ctx, "oyun bulunamadı", "id", id)
+			return models.Game{}, ErrNotFound // Boş game ve hata döndür
 		}
-		log.Printf("Repository: ID'si %v olan oyun getirilirken hata: %v", id, err)
+		t.Log.ErrorContext(ctx, "oyun getirilemedi", "id", id, "error", err)
 		return models.Game{}, err // Boş game ve hata döndür
 	}
-	log.Printf("Repository: ID'si %v olan oyun başarıyla getirildi", id)
 	return game, nil // Game ve nil hata döndür
 }
 
+// GetByIDs verilen ID'lerdeki oyunları tek sorguda getirir; silinmiş oyunlar sonuçta yer almaz, sıra korunmaz
+func (t *ProductRepositoryDB) GetByIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.Game, error) {
+	games := []models.Game{}
+	if len(ids) == 0 {
+		return games, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	cursor, err := t.TodoCollection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		t.Log.ErrorContext(ctx, "oyunlar ID listesiyle getirilemedi", "count", len(ids), "error", err)
+		return nil, err
+	}
+	if err := cursor.All(ctx, &games); err != nil {
+		t.Log.ErrorContext(ctx, "oyunlar okunamadı", "error", err)
+		return nil, err
+	}
+	return games, nil
+}
+
 // Oyunları belirtilen alana göre artana veya azalana sıralayarak getirir
-func (t *ProductRepositoryDB) GetAndSorted(sortField string, order int) ([]models.Game, error) { //sortField string, order int   sortField=sıralamanın neye göre olcağı  order=+1 artana göre -1 azalana göre sıralalr
-	var game models.Game
-	var games []models.Game
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (t *ProductRepositoryDB) GetAndSorted(ctx context.Context, sortField string, order int) ([]models.Game, error) { //sortField string, order int   sortField=sıralamanın neye göre olcağı  order=+1 artana göre -1 azalana göre sıralalr
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 	opts := options.Find().SetSort(bson.D{{Key: sortField, Value: order}}) //options.Find() sorgu yaparken sıralama yapabileceğimiz ekseçenekler sunar SetSort=parametreye göre sıralama  sortField string, order int   sortField=sıralamanın neye göre olcağı  order=+1 artana göre -1 azalana göre sıralalr
 	result, err := t.TodoCollection.Find(ctx, bson.M{}, opts)
 	if err != nil {
-		log.Printf("Repository: Verileri sıralarken hata: %v", err)
+		t.Log.ErrorContext(ctx, "oyunlar sıralanamadı", "field", sortField, "error", err)
 		return nil, err
 	}
-	for result.Next(ctx) { //result ile next ile nesnelerde
-		if err := result.Decode(&game); err != nil { //referansına atama
-			log.Printf("Repository: Veritabanına oyun çekerken hata: %v", err)
-			return nil, err
-		}
-		games = append(games, game)
+	games, err := decodeGames(ctx, result) //result ile next ile nesnelerde
+	if err != nil {
+		t.Log.ErrorContext(ctx, "oyunlar okunamadı", "error", err)
+		return nil, err
 	}
 	return games, nil
 }
 
-// Tam olarak eşleşen isme sahip oyunları getirir
-func (t *ProductRepositoryDB) GetByExactName(name string) ([]models.Game, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// Tam olarak eşleşen isme sahip oyunları getirir; isim herhangi bir dildeki başlık olabilir
+func (t *ProductRepositoryDB) GetByExactName(ctx context.Context, name string) ([]models.Game, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
-	filter := bson.M{"title": name} //MongoDB sorguları oluşturmak için kullanılan bir filtre nesnesidir
+	filter := bson.M{"$or": titleMatch(name)} //MongoDB sorguları oluşturmak için kullanılan bir filtre nesnesidir
 	result, err := t.TodoCollection.Find(ctx, filter)
 	if err != nil {
-		log.Printf("Repository: Tam isim ile sorgu sırasında hata: %v", err)
+		t.Log.ErrorContext(ctx, "tam isim sorgusu başarısız", "error", err)
 		return nil, err
 	}
 	var games []models.Game
 	if err = result.All(ctx, &games); err != nil {
-		log.Printf("Repository: Sonuçları okurken hata: %v", err)
+		t.Log.ErrorContext(ctx, "sorgu sonuçları okunamadı", "error", err)
 		return nil, err
 	}
 	return games, nil
 }
 
-// İsmin bir kısmıyla eşleşen oyunları getirir (regex kullanarak)
-func (t *ProductRepositoryDB) GetByPartialName(name string) ([]models.Game, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// İsmin bir kısmıyla eşleşen oyunları getirir (regex kullanarak); tüm dillerdeki başlıklarda aranır
+func (t *ProductRepositoryDB) GetByPartialName(ctx context.Context, name string) ([]models.Game, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
-	var game models.Game
-	var games []models.Game
 	//YAPAY ZEKA
-	// Kısmi eşleşme için regex kullan
+	// Kısmi eşleşme için regex kullan; aranan metin düz metin olarak eşlenir, kullanıcının regex'i çalıştırılmaz
 	regexPattern := primitive.Regex{
-		Pattern: name,
+		Pattern: regexp.QuoteMeta(name),
 		Options: "i", // i = case insensitive (büyük/küçük harf duyarsız)
 	}
 	//YAPAY ZEKA
-	filter := bson.M{"title": regexPattern}
+	filter := bson.M{"$or": titleMatch(regexPattern)}
 	result, err := t.TodoCollection.Find(ctx, filter)
 	if err != nil {
-		log.Printf("Repository: Kısmi isim ile sorgu sırasında hata: %v", err)
+		t.Log.ErrorContext(ctx, "kısmi isim sorgusu başarısız", "error", err)
 		return nil, err
 	}
-	for result.Next(ctx) { //result a gelen nesnelerde next ile gezindik
-		if err := result.Decode(&game); err != nil { //Decode ile game değişkenin referansına atadık
-			log.Printf("Repository: Veritabanına oyun çekerken hata: %v", err)
-			return nil, err
-		}
-		games = append(games, game)
+	games, err := decodeGames(ctx, result) //result a gelen nesnelerde next ile gezindik
+	if err != nil {
+		t.Log.ErrorContext(ctx, "oyunlar okunamadı", "error", err)
+		return nil, err
 	}
 	return games, nil
 }
 
 // fiyat aralığına göre filtreleme
-func (t *ProductRepositoryDB) GetByPriceRange(minPrice float64, maxPrice float64) ([]models.Game, error) {
+func (t *ProductRepositoryDB) GetByPriceRange(ctx context.Context, minPrice float64, maxPrice float64) ([]models.Game, error) {
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
-	var games []models.Game
-	var game models.Game
 	//Yapay Zeka
 	filter := bson.M{
 		"price.amount": bson.M{
@@ -247,19 +276,101 @@ func (t *ProductRepositoryDB) GetByPriceRange(minPrice float64, maxPrice float64
 	//Yappay Zeka
 	result, err := t.TodoCollection.Find(ctx, filter)
 	if err != nil {
-		log.Printf("Repository: Fiyat aralığına göre sorgulama hatası: %v", err)
+		t.Log.ErrorContext(ctx, "fiyat aralığı sorgusu başarısız", "min", minPrice, "max", maxPrice, "error", err)
 		return nil, err
 	}
-	for result.Next(ctx) {
-		if result.Decode(&game); err != nil {
-			log.Printf("Repository: Oyun verisi çözümlenirken hata: %v", err)
-			return nil, err
-		}
-		games = append(games, game)
+	games, err := decodeGames(ctx, result)
+	if err != nil {
+		t.Log.ErrorContext(ctx, "oyun verisi çözümlenemedi", "error", err)
+		return nil, err
 	}
 	return games, nil
 }
 
+// Oyun sayılarını duruma göre gruplayarak getirir (metrikler için)
+func (t *ProductRepositoryDB) CountByStatus(ctx context.Context) (map[string]int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	pipeline := mongo.Pipeline{{{Key: "$group", Value: bson.M{"_id": "$status", "count": bson.M{"$sum": 1}}}}}
+	result, err := t.TodoCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		t.Log.ErrorContext(ctx, "duruma göre oyunlar sayılamadı", "error", err)
+		return nil, err
+	}
+	var rows []struct {
+		Status string `bson:"_id"`
+		Count  int64  `bson:"count"`
+	}
+	if err := result.All(ctx, &rows); err != nil {
+		t.Log.ErrorContext(ctx, "sorgu sonuçları okunamadı", "error", err)
+		return nil, err
+	}
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// İndirimde olan oyunların sayısını getirir
+func (t *ProductRepositoryDB) CountOnSale(ctx context.Context) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	count, err := t.TodoCollection.CountDocuments(ctx, bson.M{"price.on_sale": true})
+	if err != nil {
+		t.Log.ErrorContext(ctx, "indirimdeki oyunlar sayılamadı", "error", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// Yorum toplamlarını fark kadar değiştirir; ortalama ve yüzde aynı atomik güncellemede yeniden hesaplanır
+func (t *ProductRepositoryDB) ApplyRatingDelta(ctx context.Context, id primitive.ObjectID, delta models.RatingTotals) error {
+	return t.updateRating(ctx, id, delta, true)
+}
+
+// Yorum toplamlarını verilen değerlerle değiştirir (sapma onarımı ve moderasyon sonrası yeniden sayım için)
+func (t *ProductRepositoryDB) SetRatingTotals(ctx context.Context, id primitive.ObjectID, totals models.RatingTotals) error {
+	return t.updateRating(ctx, id, totals, false)
+}
+
+// updateRating rating.total_reviews, score_sum ve positive_count alanlarını günceller, ardından
+// average_score ve positive_percentage alanlarını bu toplamlardan türetir. Tek bir pipeline update kullanıldığı için
+// eşzamanlı yorumlar birbirinin güncellemesini ezmez.
+func (t *ProductRepositoryDB) updateRating(ctx context.Context, id primitive.ObjectID, totals models.RatingTotals, incremental bool) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	field := func(name string, value interface{}) interface{} {
+		if !incremental {
+			return value
+		}
+		return bson.M{"$max": bson.A{0, bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$rating." + name, 0}}, value}}}}
+	}
+	hasReviews := bson.M{"$gt": bson.A{"$rating.total_reviews", 0}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"rating.total_reviews":  field("total_reviews", totals.Count),
+			"rating.score_sum":      field("score_sum", totals.ScoreSum),
+			"rating.positive_count": field("positive_count", totals.Positive),
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"rating.average_score": bson.M{"$cond": bson.A{hasReviews,
+				bson.M{"$round": bson.A{bson.M{"$divide": bson.A{"$rating.score_sum", "$rating.total_reviews"}}, 2}}, 0}},
+			"rating.positive_percentage": bson.M{"$cond": bson.A{hasReviews,
+				bson.M{"$toInt": bson.M{"$round": bson.A{bson.M{"$multiply": bson.A{bson.M{"$divide": bson.A{"$rating.positive_count", "$rating.total_reviews"}}, 100}}, 0}}}, 0}},
+		}}},
+	}
+	result, err := t.TodoCollection.UpdateOne(ctx, bson.M{"_id": id}, pipeline)
+	if err != nil {
+		t.Log.ErrorContext(ctx, "oyun puanı güncellenemedi", "id", id, "error", err)
+		return err
+	}
+	if result.MatchedCount <= 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
 //InsertOne() mongodb de 1 tane veri eklemek için
 //Find() veri çekmek için
 //DeleteOne() veri silmek için
@@ -267,7 +378,7 @@ func (t *ProductRepositoryDB) GetByPriceRange(minPrice float64, maxPrice float64
 //UpdateOne Patch işleminde tek veri güncelmek için
 //FindOne() 1 tane veri çekmek için
 //Find().SetSort(bson.D{{Key: sortField, Value: order}}) find ile gelen verileri  sortField sıralanack parametre Value sıralama tipi
-/*Context (Bağlam) ve ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second):
+/*Context (Bağlam) ve ctx, cancel := context.WithTimeout(ctx, 10*time.Second):
 Context, Go'da işlemleri kontrol etmek, iptal etmek veya zaman aşımına uğratmak için kullanılan bir yapıdır. Özellikle:
 context.Background(): Boş bir ana context oluşturur
 context.WithTimeout(): Belirtilen süre sonunda otomatik iptal olacak bir context oluşturur
@@ -310,3 +421,261 @@ var x int = 10
 var p *int = &x  // p, x'in bellek adresini tutar
 Burada &x, x değişkeninin bellek adresini te
 */
+
+// SetPlaytimeStats her oyun için playtime_stats alanını ve ortalamayı total_playtime alanına tek bir toplu yazma ile kaydeder
+func (t *ProductRepositoryDB) SetPlaytimeStats(ctx context.Context, stats map[primitive.ObjectID]models.PlaytimeStats) error {
+	if len(stats) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	writes := make([]mongo.WriteModel, 0, len(stats))
+	for id, s := range stats {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": id}).
+			SetUpdate(bson.M{"$set": bson.M{"total_playtime": s.AverageMinutes, "playtime_stats": s}}))
+	}
+	result, err := t.TodoCollection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		t.Log.ErrorContext(ctx, "oynama süreleri yazılamadı", "games", len(stats), "error", err)
+		return err
+	}
+	t.Log.InfoContext(ctx, "oynama süreleri güncellendi", "games", len(stats), "modified", result.ModifiedCount)
+	return nil
+}
+
+// GetChildren parent_id alanı verilen oyunu gösteren DLC ve sürümleri başlığa göre sıralı getirir
+func (t *ProductRepositoryDB) GetChildren(ctx context.Context, parentID primitive.ObjectID) ([]models.Game, error) {
+	return t.findRelated(ctx, bson.M{"parent_id": parentID})
+}
+
+// GetBundlesContaining bundle_items listesinde verilen oyun bulunan paketleri başlığa göre sıralı getirir
+func (t *ProductRepositoryDB) GetBundlesContaining(ctx context.Context, id primitive.ObjectID) ([]models.Game, error) {
+	return t.findRelated(ctx, bson.M{"kind": models.GameKindBundle, "bundle_items": id})
+}
+
+// GetReleases mağazada görünen (coming_soon, early_access, active ve durumu yazılmamış eski) oyunlardan çıkış tarihi
+// sorgu aralığına düşenleri çıkış tarihine göre sıralı getirir
+func (t *ProductRepositoryDB) GetReleases(ctx context.Context, query ReleaseQuery) ([]models.Game, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	dateRange := bson.M{"$gte": query.From}
+	if !query.To.IsZero() {
+		dateRange["$lt"] = query.To
+	}
+	or := bson.A{bson.M{"release_date": dateRange}, bson.M{"platforms.release_date": dateRange}}
+	if query.IncludeUndated {
+		or = append(or, bson.M{"status": models.GameStatusComingSoon})
+	}
+	visible := bson.A{"", nil}
+	for _, status := range models.StorefrontStatuses {
+		visible = append(visible, status)
+	}
+	filter := bson.M{"status": bson.M{"$in": visible}, "$or": or}
+	if query.Platform != "" {
+		filter["platforms.name"] = primitive.Regex{Pattern: "^" + regexp.QuoteMeta(query.Platform) + "$", Options: "i"}
+	}
+	cursor, err := t.TodoCollection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "release_date", Value: 1}, {Key: "title", Value: 1}}))
+	if err != nil {
+		t.Log.ErrorContext(ctx, "çıkış takvimi sorgusu başarısız", "from", query.From, "to", query.To, "platform", query.Platform, "error", err)
+		return nil, err
+	}
+	games := []models.Game{}
+	if err := cursor.All(ctx, &games); err != nil {
+		t.Log.ErrorContext(ctx, "çıkış takvimi sonuçları okunamadı", "error", err)
+		return nil, err
+	}
+	return games, nil
+}
+
+// SetStatus oyunun durumu change.From ise durumu change.To yapar, geçişi status_history'ye ekler ve is_early_access'i
+// yeni durumdan türetir. Oyun yoksa ya da durumu bu arada değişmişse false döner.
+func (t *ProductRepositoryDB) SetStatus(ctx context.Context, id primitive.ObjectID, change models.StatusChange) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	filter := bson.M{"_id": id, "status": change.From}
+	if change.From == "" {
+		filter["status"] = bson.M{"$in": bson.A{nil, ""}} // durumu hiç yazılmamış eski kayıtlar
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":            change.To,
+			"status_changed_at": change.At,
+			"is_early_access":   change.To == models.GameStatusEarlyAccess,
+			"updated_at":        change.At,
+		},
+		"$push": bson.M{"status_history": change},
+	}
+	result, err := t.TodoCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		t.Log.ErrorContext(ctx, "oyun durumu değiştirilemedi", "id", id, "from", change.From, "to", change.To, "error", err)
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// ReplaceIfVersion oyunu yalnızca updated_at alanı version ise tamamen değiştirir (iyimser eşzamanlılık); oyun yoksa ya
+// da bu arada değişmişse false döner. updated_at'i hiç yazılmamış eski kayıtlar için version sıfır zamandır.
+func (t *ProductRepositoryDB) ReplaceIfVersion(ctx context.Context, id primitive.ObjectID, game models.Game, version time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	filter := bson.M{"_id": id, "updated_at": version}
+	if version.IsZero() {
+		filter["updated_at"] = bson.M{"$in": bson.A{version, nil}}
+	}
+	game.ID = id
+	game.UpdatedAt = time.Now()
+	result, err := t.TodoCollection.ReplaceOne(ctx, filter, game)
+	if err != nil {
+		t.Log.ErrorContext(ctx, "oyun sürüm kontrolüyle güncellenemedi", "id", id, "error", err)
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// SetRequirementSpecs gereksinim metinlerinden hesaplanan donanım değerlerini yazar; nil olan set kaldırılır. Hesaplanan
+// bir alan olduğu için updated_at değişmez.
+func (t *ProductRepositoryDB) SetRequirementSpecs(ctx context.Context, id primitive.ObjectID, minimum, recommended *models.HardwareSpec) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	set, unset := bson.M{}, bson.M{}
+	for field, spec := range map[string]*models.HardwareSpec{"minimum": minimum, "recommended": recommended} {
+		if spec != nil {
+			set["system_requirements."+field+".spec"] = spec
+		} else {
+			unset["system_requirements."+field+".spec"] = ""
+		}
+	}
+	update := bson.M{}
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	result, err := t.TodoCollection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		t.Log.ErrorContext(ctx, "donanım gereksinimleri yazılamadı", "id", id, "error", err)
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// GetBySpec minimum gereksinimleri verilen donanımı aşmayan oyunları başlığa göre sıralı getirir. Oyunda belirtilmemiş
+// (okunamamış) gereksinimler ve spec'te verilmemiş bileşenler elemez; name boş değilse herhangi bir dildeki başlıkta
+// geçmelidir.
+func (t *ProductRepositoryDB) GetBySpec(ctx context.Context, name string, spec models.HardwareSpec) ([]models.Game, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	const prefix = "system_requirements.minimum.spec."
+	filter := bson.M{"status": bson.M{"$ne": models.GameStatusRemoved}}
+	if name != "" {
+		filter["$or"] = titleMatch(primitive.Regex{Pattern: regexp.QuoteMeta(name), Options: "i"})
+	}
+	if spec.OS != "" {
+		filter[prefix+"os"] = bson.M{"$in": bson.A{spec.OS, nil}}
+	}
+	notAbove := func(field string, have float64) {
+		if have > 0 {
+			filter[prefix+field] = bson.M{"$not": bson.M{"$gt": have}} // alan yoksa da eşleşir
+		}
+	}
+	notAbove("cpu_tier", float64(spec.CPUTier))
+	notAbove("gpu_tier", float64(spec.GPUTier))
+	notAbove("memory_gb", spec.MemoryGB)
+	notAbove("storage_gb", spec.StorageGB)
+	notAbove("directx", float64(spec.DirectX))
+	cursor, err := t.TodoCollection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "title", Value: 1}}))
+	if err != nil {
+		t.Log.ErrorContext(ctx, "donanım sorgusu başarısız", "name", name, "error", err)
+		return nil, err
+	}
+	games := []models.Game{}
+	if err := cursor.All(ctx, &games); err != nil {
+		t.Log.ErrorContext(ctx, "donanım sorgusu sonuçları okunamadı", "error", err)
+		return nil, err
+	}
+	return games, nil
+}
+
+// SetTranslation oyunun translation.Locale dilindeki çevirisini değiştirir, yoksa ekler. Taslakların yayınlanmasını
+// engellememesi için updated_at değişmez; çeviriler PUT ve taslak yayınında korunur.
+func (t *ProductRepositoryDB) SetTranslation(ctx context.Context, id primitive.ObjectID, translation models.Translation) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	// önce var olan çeviri değiştirilir; yoksa eklenir. Arada aynı dil başka bir istekle eklenmişse ikinci denemede değiştirilir
+	for attempt := 0; attempt < 2; attempt++ {
+		result, err := t.TodoCollection.UpdateOne(ctx, bson.M{"_id": id, "translations.locale": translation.Locale}, bson.M{"$set": bson.M{"translations.$": translation}})
+		if err != nil {
+			t.Log.ErrorContext(ctx, "çeviri güncellenemedi", "id", id, "locale", translation.Locale, "error", err)
+			return err
+		}
+		if result.MatchedCount > 0 {
+			return nil
+		}
+		result, err = t.TodoCollection.UpdateOne(ctx, bson.M{"_id": id, "translations.locale": bson.M{"$ne": translation.Locale}}, bson.M{"$push": bson.M{"translations": translation}})
+		if err != nil {
+			t.Log.ErrorContext(ctx, "çeviri eklenemedi", "id", id, "locale", translation.Locale, "error", err)
+			return err
+		}
+		if result.MatchedCount > 0 {
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+// RemoveTranslation oyunun locale dilindeki çevirisini siler; oyun ya da çeviri yoksa ErrNotFound döner
+func (t *ProductRepositoryDB) RemoveTranslation(ctx context.Context, id primitive.ObjectID, locale string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	result, err := t.TodoCollection.UpdateOne(ctx, bson.M{"_id": id, "translations.locale": locale}, bson.M{"$pull": bson.M{"translations": bson.M{"locale": locale}}})
+	if err != nil {
+		t.Log.ErrorContext(ctx, "çeviri silinemedi", "id", id, "locale", locale, "error", err)
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// titleMatch başlığın varsayılan dilde ya da herhangi bir çevirisinde eşleşmesi için $or koşullarını oluşturur
+func titleMatch(match interface{}) bson.A {
+	return bson.A{bson.M{"title": match}, bson.M{"translations.title": match}}
+}
+
+func (t *ProductRepositoryDB) findRelated(ctx context.Context, filter bson.M) ([]models.Game, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	cursor, err := t.TodoCollection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "title", Value: 1}}))
+	if err != nil {
+		t.Log.ErrorContext(ctx, "ilişkili oyunlar getirilemedi", "error", err)
+		return nil, err
+	}
+	games := []models.Game{}
+	if err := cursor.All(ctx, &games); err != nil {
+		t.Log.ErrorContext(ctx, "ilişkili oyunlar okunamadı", "error", err)
+		return nil, err
+	}
+	return games, nil
+}
+
+// decodeGames imleçteki belgeleri sırayla okur. Her belge boş bir değere çözülür; sürücü hedefi sıfırlamadığından tek bir
+// değişken kullanılırsa belgede olmayan alanlar (translations, rating.min_age, kind, parent_id, bundle_items) önceki
+// oyundan taşınır.
+func decodeGames(ctx context.Context, cursor *mongo.Cursor) ([]models.Game, error) {
+	defer cursor.Close(ctx)
+	var games []models.Game
+	for cursor.Next(ctx) {
+		var game models.Game
+		if err := cursor.Decode(&game); err != nil {
+			return nil, err
+		}
+		games = append(games, game)
+	}
+	return games, cursor.Err()
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"api-steam/models"
+	"context"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestDecodeGamesStartsEachRowEmpty(t *testing.T) {
+	parent, dlc, bundle := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	tests := []struct {
+		name string
+		rows []interface{}
+		want []models.Game
+	}{
+		{
+			"çeviri ve yaş sınırı taşınmaz",
+			[]interface{}{
+				bson.M{"title": "A", "translations": bson.A{bson.M{"locale": "en", "title": "A (en)"}}, "rating": bson.M{"pegi": "18", "min_age": 18}},
+				bson.M{"title": "B"},
+			},
+			[]models.Game{
+				{Title: "A", Translations: []models.Translation{{Locale: "en", Title: "A (en)"}}, Rating: models.Rating{PEGI: "18", MinAge: 18}},
+				{Title: "B"},
+			},
+		},
+		{
+			"DLC'den sonra gelen ana oyun DLC olmaz",
+			[]interface{}{
+				bson.M{"_id": dlc, "title": "DLC", "kind": models.GameKindDLC, "parent_id": parent},
+				bson.M{"_id": bundle, "title": "Paket", "kind": models.GameKindBundle, "bundle_items": bson.A{parent, dlc}},
+				bson.M{"_id": parent, "title": "Ana oyun"},
+			},
+			[]models.Game{
+				{ID: dlc, Title: "DLC", Kind: models.GameKindDLC, ParentID: &parent},
+				{ID: bundle, Title: "Paket", Kind: models.GameKindBundle, BundleItems: []primitive.ObjectID{parent, dlc}},
+				{ID: parent, Title: "Ana oyun"},
+			},
+		},
+		{"boş imleç", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := mongo.NewCursorFromDocuments(tt.rows, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			games, err := decodeGames(context.Background(), cursor)
+			if err != nil {
+				t.Fatalf("beklenmeyen hata: %v", err)
+			}
+			if !reflect.DeepEqual(games, tt.want) {
+				t.Errorf("oyunlar = %+v, beklenen %+v", games, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeGamesReturnsDecodeError(t *testing.T) {
+	cursor, err := mongo.NewCursorFromDocuments([]interface{}{bson.M{"title": "A"}, bson.M{"title": bson.A{"liste"}}}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if games, err := decodeGames(context.Background(), cursor); err == nil {
+		t.Errorf("hata bekleniyordu, oyunlar = %+v", games)
+	}
+}
@@ -0,0 +1,443 @@
+package repository
+
+import (
+	"api-steam/models"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ReviewQuery bir oyunun yorumlarını listelerken kullanılan filtre ve sayfalama seçenekleridir
+type ReviewQuery struct {
+	Statuses []string // Boşsa yalnızca yayınlanmış yorumlar
+	Sort     string   // "helpful" veya "recent" (varsayılan)
+	Verified bool     // Yalnızca oyuna sahip kullanıcıların yorumları
+	Skip     int64
+	Limit    int64
+}
+
+// ReviewChanges bir yorumda değiştirilecek alanlardır; nil alanlar olduğu gibi kalır
+type ReviewChanges struct {
+	Score           *int
+	Recommended     *bool
+	Text            *string
+	PlaytimeMinutes *int
+	Status          *string
+	VerifiedOwner   *bool
+	// Moderasyon alanları
+	Flags            *[]string
+	ReportCount      *int
+	ModerationReason *string
+	ModeratedBy      *string
+	ModeratedAt      *time.Time
+}
+
+// set değişiklikleri $set belgesine çevirir
+func (c ReviewChanges) set() bson.M {
+	set := bson.M{"updated_at": time.Now()}
+	if c.Score != nil {
+		set["score"] = *c.Score
+	}
+	if c.Recommended != nil {
+		set["recommended"] = *c.Recommended
+	}
+	if c.Text != nil {
+		set["text"] = *c.Text
+	}
+	if c.PlaytimeMinutes != nil {
+		set["playtime_minutes"] = *c.PlaytimeMinutes
+	}
+	if c.Status != nil {
+		set["status"] = *c.Status
+	}
+	if c.VerifiedOwner != nil {
+		set["verified_owner"] = *c.VerifiedOwner
+	}
+	if c.Flags != nil {
+		set["flags"] = *c.Flags
+	}
+	if c.ReportCount != nil {
+		set["report_count"] = *c.ReportCount
+	}
+	if c.ModerationReason != nil {
+		set["moderation_reason"] = *c.ModerationReason
+	}
+	if c.ModeratedBy != nil {
+		set["moderated_by"] = *c.ModeratedBy
+	}
+	if c.ModeratedAt != nil {
+		set["moderated_at"] = *c.ModeratedAt
+	}
+	return set
+}
+
+// apply değişiklikleri yorumun bellekteki kopyasına uygular
+func (c ReviewChanges) apply(review models.Review, at time.Time) models.Review {
+	if c.Score != nil {
+		review.Score = *c.Score
+	}
+	if c.Recommended != nil {
+		review.Recommended = *c.Recommended
+	}
+	if c.Text != nil {
+		review.Text = *c.Text
+	}
+	if c.PlaytimeMinutes != nil {
+		review.PlaytimeMinutes = *c.PlaytimeMinutes
+	}
+	if c.Status != nil {
+		review.Status = *c.Status
+	}
+	if c.VerifiedOwner != nil {
+		review.VerifiedOwner = *c.VerifiedOwner
+	}
+	if c.Flags != nil {
+		review.Flags = *c.Flags
+	}
+	if c.ReportCount != nil {
+		review.ReportCount = *c.ReportCount
+	}
+	if c.ModerationReason != nil {
+		review.ModerationReason = *c.ModerationReason
+	}
+	if c.ModeratedBy != nil {
+		review.ModeratedBy = *c.ModeratedBy
+	}
+	if c.ModeratedAt != nil {
+		review.ModeratedAt = c.ModeratedAt
+	}
+	review.UpdatedAt = at
+	return review
+}
+
+// ReviewRepository yorumlar ve yorum oyları için gereken metodları tanımlar
+type ReviewRepository interface {
+	Insert(ctx context.Context, review models.Review) (models.Review, error)
+	GetByID(ctx context.Context, id primitive.ObjectID) (models.Review, error)
+	GetByUserAndGame(ctx context.Context, userID, gameID primitive.ObjectID) (models.Review, error)
+	ListByGame(ctx context.Context, gameID primitive.ObjectID, query ReviewQuery) ([]models.Review, error)
+	Update(ctx context.Context, id primitive.ObjectID, changes ReviewChanges) (before, after models.Review, err error)
+	Delete(ctx context.Context, id primitive.ObjectID) (models.Review, error)
+	Totals(ctx context.Context, gameID primitive.ObjectID) (models.RatingTotals, error)
+	Vote(ctx context.Context, vote models.ReviewVote) (previous *models.ReviewVote, err error)
+	Unvote(ctx context.Context, reviewID, userID primitive.ObjectID) (*models.ReviewVote, error)
+	AdjustVotes(ctx context.Context, id primitive.ObjectID, helpful, notHelpful int) error
+	ListForModeration(ctx context.Context, statuses []string, skip, limit int64) ([]models.Review, error)
+	InsertReport(ctx context.Context, report models.ReviewReport) (models.Review, error)
+	ListReports(ctx context.Context, reviewID primitive.ObjectID) ([]models.ReviewReport, error)
+	ResolveReports(ctx context.Context, reviewID primitive.ObjectID) error
+	SetVerifiedOwner(ctx context.Context, userID, gameID primitive.ObjectID, verified bool) error
+	ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.Review, error)
+	CountPublishedSince(ctx context.Context, since time.Time) (map[primitive.ObjectID]int, error)
+}
+
+// ReviewRepositoryDB yorumları reviews, oyları review_votes, şikayetleri review_reports koleksiyonunda tutar
+type ReviewRepositoryDB struct {
+	Reviews *mongo.Collection
+	Votes   *mongo.Collection
+	Reports *mongo.Collection
+	Log     *slog.Logger
+}
+
+// NewReviewRepository yorum koleksiyonları için repository oluşturur
+func NewReviewRepository(reviews, votes, reports *mongo.Collection, logger *slog.Logger) ReviewRepository {
+	return &ReviewRepositoryDB{Reviews: reviews, Votes: votes, Reports: reports, Log: logger}
+}
+
+// Insert yeni yorum ekler; kullanıcının bu oyuna yorumu varsa ErrDuplicate döner
+func (r *ReviewRepositoryDB) Insert(ctx context.Context, review models.Review) (models.Review, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	review.ID = primitive.NewObjectID()
+	review.CreatedAt = time.Now()
+	review.UpdatedAt = review.CreatedAt
+	if _, err := r.Reviews.InsertOne(ctx, review); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return models.Review{}, ErrDuplicate
+		}
+		r.Log.ErrorContext(ctx, "yorum eklenemedi", "game_id", review.GameID, "user_id", review.UserID, "error", err)
+		return models.Review{}, err
+	}
+	return review, nil
+}
+
+// GetByID ID'ye göre yorumu getirir
+func (r *ReviewRepositoryDB) GetByID(ctx context.Context, id primitive.ObjectID) (models.Review, error) {
+	return r.findOne(ctx, bson.M{"_id": id})
+}
+
+// GetByUserAndGame kullanıcının oyuna yazdığı yorumu getirir
+func (r *ReviewRepositoryDB) GetByUserAndGame(ctx context.Context, userID, gameID primitive.ObjectID) (models.Review, error) {
+	return r.findOne(ctx, bson.M{"user_id": userID, "game_id": gameID})
+}
+
+// ListByGame oyunun yorumlarını verilen duruma, sıralamaya ve sayfaya göre getirir
+func (r *ReviewRepositoryDB) ListByGame(ctx context.Context, gameID primitive.ObjectID, query ReviewQuery) ([]models.Review, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	statuses := query.Statuses
+	if len(statuses) == 0 {
+		statuses = []string{models.ReviewPublished}
+	}
+	sort := bson.D{{Key: "created_at", Value: -1}}
+	if query.Sort == "helpful" {
+		sort = bson.D{{Key: "helpful_count", Value: -1}, {Key: "created_at", Value: -1}}
+	}
+	opts := options.Find().SetSort(sort).SetSkip(query.Skip).SetLimit(query.Limit)
+	filter := bson.M{"game_id": gameID, "status": bson.M{"$in": statuses}}
+	if query.Verified {
+		filter["verified_owner"] = true
+	}
+	cursor, err := r.Reviews.Find(ctx, filter, opts)
+	if err != nil {
+		r.Log.ErrorContext(ctx, "yorumlar getirilemedi", "game_id", gameID, "error", err)
+		return nil, err
+	}
+	reviews := []models.Review{}
+	if err := cursor.All(ctx, &reviews); err != nil {
+		r.Log.ErrorContext(ctx, "yorumlar çözümlenemedi", "game_id", gameID, "error", err)
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// Update yorumun verilen alanlarını değiştirir ve yorumun güncelleme öncesi ile sonrası halini döndürür.
+// Önceki hal aynı atomik işlemden geldiği için Rating farkı eşzamanlı düzenlemelerde de doğru hesaplanır.
+func (r *ReviewRepositoryDB) Update(ctx context.Context, id primitive.ObjectID, changes ReviewChanges) (models.Review, models.Review, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	set := changes.set()
+	var before models.Review
+	err := r.Reviews.FindOneAndUpdate(ctx, bson.M{"_id": id}, bson.M{"$set": set}, options.FindOneAndUpdate().SetReturnDocument(options.Before)).Decode(&before)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.Review{}, models.Review{}, ErrNotFound
+		}
+		r.Log.ErrorContext(ctx, "yorum güncellenemedi", "id", id, "error", err)
+		return models.Review{}, models.Review{}, err
+	}
+	return before, changes.apply(before, set["updated_at"].(time.Time)), nil
+}
+
+// Delete yorumu ve oylarını siler, silinen yorumu döndürür
+func (r *ReviewRepositoryDB) Delete(ctx context.Context, id primitive.ObjectID) (models.Review, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var review models.Review
+	if err := r.Reviews.FindOneAndDelete(ctx, bson.M{"_id": id}).Decode(&review); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.Review{}, ErrNotFound
+		}
+		r.Log.ErrorContext(ctx, "yorum silinemedi", "id", id, "error", err)
+		return models.Review{}, err
+	}
+	if _, err := r.Votes.DeleteMany(ctx, bson.M{"review_id": id}); err != nil {
+		r.Log.WarnContext(ctx, "yorum oyları silinemedi", "id", id, "error", err)
+	}
+	return review, nil
+}
+
+// Totals oyunun yayınlanmış yorumlarından Rating toplamlarını baştan hesaplar
+func (r *ReviewRepositoryDB) Totals(ctx context.Context, gameID primitive.ObjectID) (models.RatingTotals, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"game_id": gameID, "status": models.ReviewPublished}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":       nil,
+			"count":     bson.M{"$sum": 1},
+			"score_sum": bson.M{"$sum": "$score"},
+			"positive":  bson.M{"$sum": bson.M{"$cond": bson.A{"$recommended", 1, 0}}},
+		}}},
+	}
+	cursor, err := r.Reviews.Aggregate(ctx, pipeline)
+	if err != nil {
+		r.Log.ErrorContext(ctx, "yorum toplamları hesaplanamadı", "game_id", gameID, "error", err)
+		return models.RatingTotals{}, err
+	}
+	var rows []models.RatingTotals
+	if err := cursor.All(ctx, &rows); err != nil {
+		return models.RatingTotals{}, err
+	}
+	if len(rows) == 0 {
+		return models.RatingTotals{}, nil
+	}
+	return rows[0], nil
+}
+
+// Vote kullanıcının oyunu kaydeder veya değiştirir; önceki oyu (yoksa nil) döndürür
+func (r *ReviewRepositoryDB) Vote(ctx context.Context, vote models.ReviewVote) (*models.ReviewVote, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	update := bson.M{
+		"$set":         bson.M{"helpful": vote.Helpful},
+		"$setOnInsert": bson.M{"created_at": time.Now()},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before)
+	var previous models.ReviewVote
+	err := r.Votes.FindOneAndUpdate(ctx, bson.M{"review_id": vote.ReviewID, "user_id": vote.UserID}, update, opts).Decode(&previous)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		r.Log.ErrorContext(ctx, "yorum oyu kaydedilemedi", "review_id", vote.ReviewID, "error", err)
+		return nil, err
+	}
+	return &previous, nil
+}
+
+// Unvote kullanıcının oyunu siler; silinen oyu (yoksa nil) döndürür
+func (r *ReviewRepositoryDB) Unvote(ctx context.Context, reviewID, userID primitive.ObjectID) (*models.ReviewVote, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var previous models.ReviewVote
+	err := r.Votes.FindOneAndDelete(ctx, bson.M{"review_id": reviewID, "user_id": userID}).Decode(&previous)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		r.Log.ErrorContext(ctx, "yorum oyu silinemedi", "review_id", reviewID, "error", err)
+		return nil, err
+	}
+	return &previous, nil
+}
+
+// AdjustVotes yorumun oy sayaçlarını fark kadar değiştirir
+func (r *ReviewRepositoryDB) AdjustVotes(ctx context.Context, id primitive.ObjectID, helpful, notHelpful int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	_, err := r.Reviews.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$inc": bson.M{"helpful_count": helpful, "not_helpful_count": notHelpful}})
+	if err != nil {
+		r.Log.ErrorContext(ctx, "yorum oy sayaçları güncellenemedi", "id", id, "error", err)
+	}
+	return err
+}
+
+// ListForModeration moderasyon kuyruğunu en eski yorumdan başlayarak döndürür
+func (r *ReviewRepositoryDB) ListForModeration(ctx context.Context, statuses []string, skip, limit int64) ([]models.Review, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	opts := options.Find().SetSort(bson.D{{Key: "report_count", Value: -1}, {Key: "created_at", Value: 1}}).SetSkip(skip).SetLimit(limit)
+	cursor, err := r.Reviews.Find(ctx, bson.M{"status": bson.M{"$in": statuses}}, opts)
+	if err != nil {
+		r.Log.ErrorContext(ctx, "moderasyon kuyruğu getirilemedi", "error", err)
+		return nil, err
+	}
+	reviews := []models.Review{}
+	if err := cursor.All(ctx, &reviews); err != nil {
+		r.Log.ErrorContext(ctx, "moderasyon kuyruğu çözümlenemedi", "error", err)
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// InsertReport şikayeti kaydeder ve yorumun şikayet sayacını artırır; aynı kullanıcı aynı yorumu ikinci kez şikayet ederse ErrDuplicate döner
+func (r *ReviewRepositoryDB) InsertReport(ctx context.Context, report models.ReviewReport) (models.Review, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	report.ID = primitive.NewObjectID()
+	report.CreatedAt = time.Now()
+	if _, err := r.Reports.InsertOne(ctx, report); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return models.Review{}, ErrDuplicate
+		}
+		r.Log.ErrorContext(ctx, "şikayet kaydedilemedi", "review_id", report.ReviewID, "error", err)
+		return models.Review{}, err
+	}
+	var review models.Review
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	if err := r.Reviews.FindOneAndUpdate(ctx, bson.M{"_id": report.ReviewID}, bson.M{"$inc": bson.M{"report_count": 1}}, opts).Decode(&review); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.Review{}, ErrNotFound
+		}
+		r.Log.ErrorContext(ctx, "şikayet sayacı artırılamadı", "review_id", report.ReviewID, "error", err)
+		return models.Review{}, err
+	}
+	return review, nil
+}
+
+// ListReports yorumun şikayetlerini en yeniden başlayarak döndürür
+func (r *ReviewRepositoryDB) ListReports(ctx context.Context, reviewID primitive.ObjectID) ([]models.ReviewReport, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	cursor, err := r.Reports.Find(ctx, bson.M{"review_id": reviewID}, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		r.Log.ErrorContext(ctx, "şikayetler getirilemedi", "review_id", reviewID, "error", err)
+		return nil, err
+	}
+	reports := []models.ReviewReport{}
+	if err := cursor.All(ctx, &reports); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// ResolveReports yorumun açık şikayetlerini çözüldü olarak işaretler
+func (r *ReviewRepositoryDB) ResolveReports(ctx context.Context, reviewID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	_, err := r.Reports.UpdateMany(ctx, bson.M{"review_id": reviewID, "resolved_at": bson.M{"$exists": false}}, bson.M{"$set": bson.M{"resolved_at": time.Now()}})
+	if err != nil {
+		r.Log.ErrorContext(ctx, "şikayetler çözülemedi", "review_id", reviewID, "error", err)
+	}
+	return err
+}
+
+func (r *ReviewRepositoryDB) findOne(ctx context.Context, filter bson.M) (models.Review, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var review models.Review
+	if err := r.Reviews.FindOne(ctx, filter).Decode(&review); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.Review{}, ErrNotFound
+		}
+		r.Log.ErrorContext(ctx, "yorum getirilemedi", "error", err)
+		return models.Review{}, err
+	}
+	return review, nil
+}
+
+// SetVerifiedOwner kullanıcının oyuna yazdığı yorumun (varsa) "oyuna sahip" işaretini günceller
+func (r *ReviewRepositoryDB) SetVerifiedOwner(ctx context.Context, userID, gameID primitive.ObjectID, verified bool) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	filter := bson.M{"user_id": userID, "game_id": gameID, "verified_owner": bson.M{"$ne": verified}}
+	if _, err := r.Reviews.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"verified_owner": verified}}); err != nil {
+		r.Log.ErrorContext(ctx, "yorumun sahiplik işareti güncellenemedi", "user_id", userID, "game_id", gameID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// ListByUser kullanıcının moderasyon durumundan bağımsız tüm yorumlarını en yeniden başlayarak getirir
+func (r *ReviewRepositoryDB) ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.Review, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	cursor, err := r.Reviews.Find(ctx, bson.M{"user_id": userID}, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		r.Log.ErrorContext(ctx, "kullanıcının yorumları getirilemedi", "user_id", userID, "error", err)
+		return nil, err
+	}
+	reviews := []models.Review{}
+	if err := cursor.All(ctx, &reviews); err != nil {
+		r.Log.ErrorContext(ctx, "kullanıcının yorumları çözümlenemedi", "user_id", userID, "error", err)
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// CountPublishedSince verilen zamandan sonra yazılmış ve yayında olan yorumları oyuna göre sayar
+func (r *ReviewRepositoryDB) CountPublishedSince(ctx context.Context, since time.Time) (map[primitive.ObjectID]int, error) {
+	counts, err := countByGame(ctx, r.Reviews, bson.M{"status": models.ReviewPublished, "created_at": bson.M{"$gte": since}})
+	if err != nil {
+		r.Log.ErrorContext(ctx, "son yorumlar sayılamadı", "since", since, "error", err)
+		return nil, err
+	}
+	return counts, nil
+}
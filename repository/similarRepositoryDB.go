@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"api-steam/models"
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SimilarRepository oyunların hesaplanan benzer oyunları ve editör sabitlemeleri için gereken metodları tanımlar
+type SimilarRepository interface {
+	Get(ctx context.Context, gameID primitive.ObjectID) (models.SimilarGames, error)
+	SaveComputed(ctx context.Context, computed map[primitive.ObjectID][]models.SimilarScore, at time.Time) error
+	DeleteComputedBefore(ctx context.Context, at time.Time) (int64, error)
+	SetCuration(ctx context.Context, gameID primitive.ObjectID, pinned, excluded []primitive.ObjectID) (models.SimilarGames, error)
+}
+
+// SimilarRepositoryDB oyun başına benzer oyun kayıtlarını similar_games koleksiyonunda tutar
+type SimilarRepositoryDB struct {
+	Collection *mongo.Collection
+	Log        *slog.Logger
+}
+
+// NewSimilarRepository similar_games koleksiyonu için repository oluşturur
+func NewSimilarRepository(collection *mongo.Collection, logger *slog.Logger) SimilarRepository {
+	return &SimilarRepositoryDB{Collection: collection, Log: logger}
+}
+
+// Get oyunun benzer oyun kaydını getirir; henüz hesaplanmamış ve editör değişikliği yoksa ErrNotFound döner
+func (r *SimilarRepositoryDB) Get(ctx context.Context, gameID primitive.ObjectID) (models.SimilarGames, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var similar models.SimilarGames
+	if err := r.Collection.FindOne(ctx, bson.M{"game_id": gameID}).Decode(&similar); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.SimilarGames{}, ErrNotFound
+		}
+		r.Log.ErrorContext(ctx, "benzer oyunlar getirilemedi", "game_id", gameID, "error", err)
+		return models.SimilarGames{}, err
+	}
+	return similar, nil
+}
+
+// SaveComputed her oyunun hesaplanan listesini tek bir toplu yazma ile kaydeder; editörün sabitlemeleri korunur
+func (r *SimilarRepositoryDB) SaveComputed(ctx context.Context, computed map[primitive.ObjectID][]models.SimilarScore, at time.Time) error {
+	if len(computed) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	writes := make([]mongo.WriteModel, 0, len(computed))
+	for gameID, scores := range computed {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"game_id": gameID}).
+			SetUpdate(bson.M{
+				"$set":         bson.M{"computed": scores, "computed_at": at},
+				"$setOnInsert": bson.M{"pinned": bson.A{}, "excluded": bson.A{}},
+			}).
+			SetUpsert(true))
+	}
+	if _, err := r.Collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+		r.Log.ErrorContext(ctx, "benzer oyunlar kaydedilemedi", "games", len(computed), "error", err)
+		return err
+	}
+	return nil
+}
+
+// DeleteComputedBefore son hesaplamada yer almayan (silinmiş) oyunların kayıtlarını siler; hiç hesaplanmamış kayıtlara dokunmaz
+func (r *SimilarRepositoryDB) DeleteComputedBefore(ctx context.Context, at time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	result, err := r.Collection.DeleteMany(ctx, bson.M{"computed_at": bson.M{"$lt": at}})
+	if err != nil {
+		r.Log.ErrorContext(ctx, "eski benzer oyun kayıtları silinemedi", "error", err)
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+// SetCuration oyunun sabitlenen ve çıkarılan oyun listelerini değiştirir; kayıt yoksa oluşturur
+func (r *SimilarRepositoryDB) SetCuration(ctx context.Context, gameID primitive.ObjectID, pinned, excluded []primitive.ObjectID) (models.SimilarGames, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	update := bson.M{
+		"$set":         bson.M{"pinned": pinned, "excluded": excluded, "updated_at": time.Now()},
+		"$setOnInsert": bson.M{"computed": bson.A{}},
+	}
+	var similar models.SimilarGames
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	if err := r.Collection.FindOneAndUpdate(ctx, bson.M{"game_id": gameID}, update, opts).Decode(&similar); err != nil {
+		r.Log.ErrorContext(ctx, "benzer oyun düzenlemesi kaydedilemedi", "game_id", gameID, "error", err)
+		return models.SimilarGames{}, err
+	}
+	return similar, nil
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"api-steam/models"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TokenRepository yenileme ve şifre sıfırlama token'larını saklar
+type TokenRepository interface {
+	InsertRefresh(ctx context.Context, token models.RefreshToken) error
+	GetRefreshByHash(ctx context.Context, hash string) (models.RefreshToken, error)
+	RevokeRefresh(ctx context.Context, id primitive.ObjectID) (bool, error)
+	RevokeAllRefresh(ctx context.Context, userID primitive.ObjectID) error
+	InsertReset(ctx context.Context, token models.PasswordResetToken) error
+	ConsumeReset(ctx context.Context, hash string) (models.PasswordResetToken, error)
+}
+
+// TokenRepositoryDB token'ları refresh_tokens ve password_resets koleksiyonlarında tutar
+type TokenRepositoryDB struct {
+	RefreshTokens *mongo.Collection
+	ResetTokens   *mongo.Collection
+	Log           *slog.Logger
+}
+
+// NewTokenRepository token koleksiyonları için repository oluşturur
+func NewTokenRepository(refreshTokens, resetTokens *mongo.Collection, logger *slog.Logger) TokenRepository {
+	return &TokenRepositoryDB{RefreshTokens: refreshTokens, ResetTokens: resetTokens, Log: logger}
+}
+
+// InsertRefresh yeni bir yenileme token'ı kaydeder
+func (r *TokenRepositoryDB) InsertRefresh(ctx context.Context, token models.RefreshToken) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	token.ID = primitive.NewObjectID()
+	token.CreatedAt = time.Now()
+	if _, err := r.RefreshTokens.InsertOne(ctx, token); err != nil {
+		r.Log.ErrorContext(ctx, "yenileme token'ı eklenemedi", "user_id", token.UserID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// GetRefreshByHash özetine göre yenileme token'ını getirir
+func (r *TokenRepositoryDB) GetRefreshByHash(ctx context.Context, hash string) (models.RefreshToken, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var token models.RefreshToken
+	if err := r.RefreshTokens.FindOne(ctx, bson.M{"hash": hash}).Decode(&token); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.RefreshToken{}, ErrNotFound
+		}
+		r.Log.ErrorContext(ctx, "yenileme token'ı getirilemedi", "error", err)
+		return models.RefreshToken{}, err
+	}
+	return token, nil
+}
+
+// RevokeRefresh henüz iptal edilmemiş token'ı iptal eder; token zaten iptal edilmişse false döner
+func (r *TokenRepositoryDB) RevokeRefresh(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	result, err := r.RefreshTokens.UpdateOne(ctx,
+		bson.M{"_id": id, "revoked_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"revoked_at": time.Now()}})
+	if err != nil {
+		r.Log.ErrorContext(ctx, "yenileme token'ı iptal edilemedi", "id", id, "error", err)
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// RevokeAllRefresh kullanıcının tüm açık oturumlarını kapatır
+func (r *TokenRepositoryDB) RevokeAllRefresh(ctx context.Context, userID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	_, err := r.RefreshTokens.UpdateMany(ctx,
+		bson.M{"user_id": userID, "revoked_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"revoked_at": time.Now()}})
+	if err != nil {
+		r.Log.ErrorContext(ctx, "kullanıcının oturumları kapatılamadı", "user_id", userID, "error", err)
+	}
+	return err
+}
+
+// InsertReset yeni bir şifre sıfırlama token'ı kaydeder
+func (r *TokenRepositoryDB) InsertReset(ctx context.Context, token models.PasswordResetToken) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	token.ID = primitive.NewObjectID()
+	token.CreatedAt = time.Now()
+	if _, err := r.ResetTokens.InsertOne(ctx, token); err != nil {
+		r.Log.ErrorContext(ctx, "şifre sıfırlama token'ı eklenemedi", "user_id", token.UserID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// ConsumeReset kullanılmamış ve süresi dolmamış token'ı tek adımda kullanıldı olarak işaretler;
+// aynı token ikinci kez kullanılamaz
+func (r *TokenRepositoryDB) ConsumeReset(ctx context.Context, hash string) (models.PasswordResetToken, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	now := time.Now()
+	var token models.PasswordResetToken
+	err := r.ResetTokens.FindOneAndUpdate(ctx,
+		bson.M{"hash": hash, "used_at": bson.M{"$exists": false}, "expires_at": bson.M{"$gt": now}},
+		bson.M{"$set": bson.M{"used_at": now}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&token)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.PasswordResetToken{}, ErrNotFound
+		}
+		r.Log.ErrorContext(ctx, "şifre sıfırlama token'ı kullanılamadı", "error", err)
+		return models.PasswordResetToken{}, err
+	}
+	return token, nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"api-steam/models"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// UserRepository kullanıcı hesapları için gereken metodları tanımlar
+type UserRepository interface {
+	Insert(ctx context.Context, user models.User) (models.User, error)
+	GetByID(ctx context.Context, id primitive.ObjectID) (models.User, error)
+	GetByEmail(ctx context.Context, email string) (models.User, error)
+	UpdatePassword(ctx context.Context, id primitive.ObjectID, passwordHash string) error
+	TouchLogin(ctx context.Context, id primitive.ObjectID) error
+	UpdateNotificationSettings(ctx context.Context, id primitive.ObjectID, settings models.NotificationSettings) error
+	UpdateContentSettings(ctx context.Context, id primitive.ObjectID, settings models.ContentSettings) error
+}
+
+// UserRepositoryDB kullanıcıları users koleksiyonunda tutar
+type UserRepositoryDB struct {
+	Collection *mongo.Collection
+	Log        *slog.Logger
+}
+
+// NewUserRepository users koleksiyonu için repository oluşturur
+func NewUserRepository(collection *mongo.Collection, logger *slog.Logger) UserRepository {
+	return &UserRepositoryDB{Collection: collection, Log: logger}
+}
+
+// Insert yeni kullanıcı ekler; e-posta kayıtlıysa ErrDuplicate döner
+func (r *UserRepositoryDB) Insert(ctx context.Context, user models.User) (models.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	user.ID = primitive.NewObjectID()
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = user.CreatedAt
+	if _, err := r.Collection.InsertOne(ctx, user); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return models.User{}, ErrDuplicate
+		}
+		r.Log.ErrorContext(ctx, "kullanıcı eklenemedi", "error", err)
+		return models.User{}, err
+	}
+	r.Log.InfoContext(ctx, "kullanıcı kaydedildi", "id", user.ID)
+	return user, nil
+}
+
+// GetByID ID'ye göre kullanıcıyı getirir
+func (r *UserRepositoryDB) GetByID(ctx context.Context, id primitive.ObjectID) (models.User, error) {
+	return r.findOne(ctx, bson.M{"_id": id})
+}
+
+// GetByEmail e-postaya göre kullanıcıyı getirir
+func (r *UserRepositoryDB) GetByEmail(ctx context.Context, email string) (models.User, error) {
+	return r.findOne(ctx, bson.M{"email": email})
+}
+
+// UpdatePassword kullanıcının şifre özetini değiştirir
+func (r *UserRepositoryDB) UpdatePassword(ctx context.Context, id primitive.ObjectID, passwordHash string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	result, err := r.Collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"password_hash": passwordHash, "updated_at": time.Now()}})
+	if err != nil {
+		r.Log.ErrorContext(ctx, "şifre güncellenemedi", "id", id, "error", err)
+		return err
+	}
+	if result.MatchedCount <= 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// TouchLogin son giriş zamanını günceller
+func (r *UserRepositoryDB) TouchLogin(ctx context.Context, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	_, err := r.Collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"last_login_at": time.Now()}})
+	if err != nil {
+		r.Log.WarnContext(ctx, "son giriş zamanı güncellenemedi", "id", id, "error", err)
+	}
+	return err
+}
+
+// UpdateNotificationSettings kullanıcının bildirim kanallarını değiştirir
+func (r *UserRepositoryDB) UpdateNotificationSettings(ctx context.Context, id primitive.ObjectID, settings models.NotificationSettings) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	result, err := r.Collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"notifications": settings, "updated_at": time.Now()}})
+	if err != nil {
+		r.Log.ErrorContext(ctx, "bildirim ayarları güncellenemedi", "id", id, "error", err)
+		return err
+	}
+	if result.MatchedCount <= 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// UpdateContentSettings kullanıcının yaş derecesi sınırlarını değiştirir
+func (r *UserRepositoryDB) UpdateContentSettings(ctx context.Context, id primitive.ObjectID, settings models.ContentSettings) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	result, err := r.Collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"content": settings, "updated_at": time.Now()}})
+	if err != nil {
+		r.Log.ErrorContext(ctx, "içerik ayarları güncellenemedi", "id", id, "error", err)
+		return err
+	}
+	if result.MatchedCount <= 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *UserRepositoryDB) findOne(ctx context.Context, filter bson.M) (models.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var user models.User
+	if err := r.Collection.FindOne(ctx, filter).Decode(&user); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.User{}, ErrNotFound
+		}
+		r.Log.ErrorContext(ctx, "kullanıcı getirilemedi", "error", err)
+		return models.User{}, err
+	}
+	return user, nil
+}
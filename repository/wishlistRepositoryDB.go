@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"api-steam/models"
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WishlistRepository istek listesi kayıtları için gereken metodları tanımlar
+type WishlistRepository interface {
+	Add(ctx context.Context, item models.WishlistItem) (models.WishlistItem, error)
+	ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.WishlistItem, error)
+	ListByGame(ctx context.Context, gameID primitive.ObjectID) ([]models.WishlistItem, error)
+	UpdatePreferences(ctx context.Context, userID, gameID primitive.ObjectID, notifyOnSale, notifyOnRelease bool) (models.WishlistItem, error)
+	Remove(ctx context.Context, userID, gameID primitive.ObjectID) error
+	CountAddedSince(ctx context.Context, since time.Time) (map[primitive.ObjectID]int, error)
+}
+
+// WishlistRepositoryDB istek listelerini wishlists koleksiyonunda (kullanıcı+oyun başına bir belge) tutar
+type WishlistRepositoryDB struct {
+	Collection *mongo.Collection
+	Log        *slog.Logger
+}
+
+// NewWishlistRepository wishlists koleksiyonu için repository oluşturur
+func NewWishlistRepository(collection *mongo.Collection, logger *slog.Logger) WishlistRepository {
+	return &WishlistRepositoryDB{Collection: collection, Log: logger}
+}
+
+// Add oyunu kullanıcının listesine ekler; oyun zaten listedeyse ErrDuplicate döner
+func (r *WishlistRepositoryDB) Add(ctx context.Context, item models.WishlistItem) (models.WishlistItem, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	item.ID = primitive.NewObjectID()
+	item.AddedAt = time.Now()
+	item.UpdatedAt = item.AddedAt
+	if _, err := r.Collection.InsertOne(ctx, item); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return models.WishlistItem{}, ErrDuplicate
+		}
+		r.Log.ErrorContext(ctx, "istek listesine eklenemedi", "user_id", item.UserID, "game_id", item.GameID, "error", err)
+		return models.WishlistItem{}, err
+	}
+	return item, nil
+}
+
+// ListByUser kullanıcının listesini en son eklenenden başlayarak döndürür
+func (r *WishlistRepositoryDB) ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.WishlistItem, error) {
+	return r.find(ctx, bson.M{"user_id": userID}, options.Find().SetSort(bson.D{{Key: "added_at", Value: -1}}))
+}
+
+// ListByGame oyunu listesine eklemiş tüm kullanıcıların kayıtlarını döndürür
+func (r *WishlistRepositoryDB) ListByGame(ctx context.Context, gameID primitive.ObjectID) ([]models.WishlistItem, error) {
+	return r.find(ctx, bson.M{"game_id": gameID}, options.Find())
+}
+
+// UpdatePreferences listedeki oyunun bildirim tercihlerini değiştirir
+func (r *WishlistRepositoryDB) UpdatePreferences(ctx context.Context, userID, gameID primitive.ObjectID, notifyOnSale, notifyOnRelease bool) (models.WishlistItem, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	update := bson.M{"$set": bson.M{"notify_on_sale": notifyOnSale, "notify_on_release": notifyOnRelease, "updated_at": time.Now()}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var item models.WishlistItem
+	if err := r.Collection.FindOneAndUpdate(ctx, bson.M{"user_id": userID, "game_id": gameID}, update, opts).Decode(&item); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.WishlistItem{}, ErrNotFound
+		}
+		r.Log.ErrorContext(ctx, "istek listesi tercihleri güncellenemedi", "user_id", userID, "game_id", gameID, "error", err)
+		return models.WishlistItem{}, err
+	}
+	return item, nil
+}
+
+// Remove oyunu kullanıcının listesinden çıkarır
+func (r *WishlistRepositoryDB) Remove(ctx context.Context, userID, gameID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	result, err := r.Collection.DeleteOne(ctx, bson.M{"user_id": userID, "game_id": gameID})
+	if err != nil {
+		r.Log.ErrorContext(ctx, "istek listesinden çıkarılamadı", "user_id", userID, "game_id", gameID, "error", err)
+		return err
+	}
+	if result.DeletedCount <= 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *WishlistRepositoryDB) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]models.WishlistItem, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	cursor, err := r.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.Log.ErrorContext(ctx, "istek listesi getirilemedi", "error", err)
+		return nil, err
+	}
+	items := []models.WishlistItem{}
+	if err := cursor.All(ctx, &items); err != nil {
+		r.Log.ErrorContext(ctx, "istek listesi çözümlenemedi", "error", err)
+		return nil, err
+	}
+	return items, nil
+}
+
+// CountAddedSince verilen zamandan sonra istek listelerine yapılan eklemeleri oyuna göre sayar
+func (r *WishlistRepositoryDB) CountAddedSince(ctx context.Context, since time.Time) (map[primitive.ObjectID]int, error) {
+	counts, err := countByGame(ctx, r.Collection, bson.M{"added_at": bson.M{"$gte": since}})
+	if err != nil {
+		r.Log.ErrorContext(ctx, "istek listesi eklemeleri sayılamadı", "since", since, "error", err)
+		return nil, err
+	}
+	return counts, nil
+}
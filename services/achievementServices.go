@@ -0,0 +1,250 @@
+package services
+
+import (
+	"api-steam/dto"
+	"api-steam/models"
+	"api-steam/repository"
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrInvalidAchievement     = errors.New("geçersiz başarım: key (a-z, 0-9, _ . -) ve ad zorunludur, puan 0-1000 arasında olmalıdır")
+	ErrAchievementExists      = errors.New("bu oyunda aynı key ile bir başarım var")
+	ErrAchievementNotFound    = errors.New("başarım bulunamadı")
+	ErrAchievementNotOwned    = errors.New("başarım yalnızca oyuna sahip kullanıcılar için kaydedilebilir")
+	ErrInvalidAchievementUser = errors.New("geçersiz user_id")
+)
+
+const (
+	maxAchievementPoints  = 1000
+	hiddenAchievementName = "Gizli başarım"
+)
+
+var achievementKeyPattern = regexp.MustCompile(`^[a-z0-9_.-]{1,64}$`)
+
+// AchievementService oyunların başarım tanımlarını ve kullanıcıların açtığı başarımları yönetir
+type AchievementService interface {
+	AchievementList(ctx context.Context, gameID primitive.ObjectID) ([]models.Achievement, error)                                         //Açılma yüzdeleriyle; gizliler maskelenir
+	AchievementCreate(ctx context.Context, gameID primitive.ObjectID, req dto.AchievementRequest) (models.Achievement, error)             //Yeni tanım
+	AchievementUpdate(ctx context.Context, gameID primitive.ObjectID, key string, req dto.AchievementRequest) (models.Achievement, error) //Tanımı değiştirir
+	AchievementDelete(ctx context.Context, gameID primitive.ObjectID, key string) error                                                   //Tanımı ve açılma kayıtlarını siler
+	AchievementUnlock(ctx context.Context, req dto.AchievementUnlockRequest) (unlock models.AchievementUnlock, created bool, err error)   //Açılışı kaydeder; tekrar gönderim zararsızdır
+	AchievementProgress(ctx context.Context, userID, gameID primitive.ObjectID) (dto.AchievementProgress, error)                          //Kullanıcının oyundaki ilerlemesi
+}
+
+// DefaultAchievementService tanımları ve açılışları AchievementRepository'de tutar; yüzdeler oyunun kütüphane sahiplerine göre hesaplanır
+type DefaultAchievementService struct {
+	Repo    repository.AchievementRepository
+	Games   repository.ProductRepository
+	Library repository.LibraryRepository
+	Log     *slog.Logger
+}
+
+// AchievementList oyunun başarımlarını açılma yüzdeleriyle döndürür; gizli başarımların adı ve açıklaması gösterilmez
+func (s *DefaultAchievementService) AchievementList(ctx context.Context, gameID primitive.ObjectID) ([]models.Achievement, error) {
+	ctx, span := tracer.Start(ctx, "AchievementService.AchievementList")
+	defer span.End()
+	achievements, err := s.withPercentages(ctx, gameID)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	for i := range achievements {
+		if achievements[i].Hidden {
+			achievements[i] = maskAchievement(achievements[i])
+		}
+	}
+	return achievements, nil
+}
+
+// AchievementCreate oyuna yeni bir başarım tanımı ekler
+func (s *DefaultAchievementService) AchievementCreate(ctx context.Context, gameID primitive.ObjectID, req dto.AchievementRequest) (models.Achievement, error) {
+	ctx, span := tracer.Start(ctx, "AchievementService.AchievementCreate")
+	defer span.End()
+	req.Key = strings.ToLower(strings.TrimSpace(req.Key))
+	if err := validateAchievement(req); err != nil {
+		return models.Achievement{}, err
+	}
+	if _, err := s.Games.GetByID(ctx, gameID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.Achievement{}, ErrGameNotFound
+		}
+		return models.Achievement{}, recordError(span, err)
+	}
+	achievement, err := s.Repo.Insert(ctx, models.Achievement{
+		GameID:      gameID,
+		Key:         req.Key,
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+		IconURL:     req.IconURL,
+		Hidden:      req.Hidden,
+		Points:      req.Points,
+	})
+	if errors.Is(err, repository.ErrDuplicate) {
+		return models.Achievement{}, ErrAchievementExists
+	}
+	if err != nil {
+		return models.Achievement{}, recordError(span, err)
+	}
+	return achievement, nil
+}
+
+// AchievementUpdate başarımın adını, açıklamasını, simgesini, gizliliğini ve puanını değiştirir
+func (s *DefaultAchievementService) AchievementUpdate(ctx context.Context, gameID primitive.ObjectID, key string, req dto.AchievementRequest) (models.Achievement, error) {
+	ctx, span := tracer.Start(ctx, "AchievementService.AchievementUpdate")
+	defer span.End()
+	req.Key = key
+	if err := validateAchievement(req); err != nil {
+		return models.Achievement{}, err
+	}
+	achievement, err := s.Repo.Update(ctx, gameID, key, models.Achievement{
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+		IconURL:     req.IconURL,
+		Hidden:      req.Hidden,
+		Points:      req.Points,
+	})
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.Achievement{}, ErrAchievementNotFound
+	}
+	if err != nil {
+		return models.Achievement{}, recordError(span, err)
+	}
+	return achievement, nil
+}
+
+// AchievementDelete başarım tanımını ve açılma kayıtlarını siler
+func (s *DefaultAchievementService) AchievementDelete(ctx context.Context, gameID primitive.ObjectID, key string) error {
+	ctx, span := tracer.Start(ctx, "AchievementService.AchievementDelete")
+	defer span.End()
+	deleted, err := s.Repo.Delete(ctx, gameID, key)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrAchievementNotFound
+	}
+	if err != nil {
+		return recordError(span, err)
+	}
+	s.Log.InfoContext(ctx, "başarım silindi", "game_id", gameID, "key", key, "unlock_count", deleted.UnlockCount)
+	return nil
+}
+
+// AchievementUnlock kullanıcının başarımı açtığını kaydeder. Kullanıcı oyuna sahip olmalıdır; aynı açılış tekrar gelirse
+// created=false döner ve sayaç artmaz.
+func (s *DefaultAchievementService) AchievementUnlock(ctx context.Context, req dto.AchievementUnlockRequest) (models.AchievementUnlock, bool, error) {
+	ctx, span := tracer.Start(ctx, "AchievementService.AchievementUnlock")
+	defer span.End()
+	userID, err := primitive.ObjectIDFromHex(req.UserID)
+	if err != nil {
+		return models.AchievementUnlock{}, false, ErrInvalidAchievementUser
+	}
+	gameID, err := primitive.ObjectIDFromHex(req.GameID)
+	if err != nil {
+		return models.AchievementUnlock{}, false, ErrGameNotFound
+	}
+	achievement, err := s.Repo.GetByKey(ctx, gameID, strings.ToLower(strings.TrimSpace(req.Key)))
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.AchievementUnlock{}, false, ErrAchievementNotFound
+	}
+	if err != nil {
+		return models.AchievementUnlock{}, false, recordError(span, err)
+	}
+	if _, err := s.Library.Get(ctx, userID, gameID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.AchievementUnlock{}, false, ErrAchievementNotOwned
+		}
+		return models.AchievementUnlock{}, false, recordError(span, err)
+	}
+	unlock := models.AchievementUnlock{UserID: userID, GameID: gameID, AchievementID: achievement.ID, Key: achievement.Key, UnlockedAt: time.Now()}
+	if req.UnlockedAt != nil && !req.UnlockedAt.IsZero() && req.UnlockedAt.Before(unlock.UnlockedAt) {
+		unlock.UnlockedAt = req.UnlockedAt.UTC()
+	}
+	created, err := s.Repo.InsertUnlock(ctx, unlock)
+	if errors.Is(err, repository.ErrDuplicate) {
+		return unlock, false, nil
+	}
+	if err != nil {
+		return models.AchievementUnlock{}, false, recordError(span, err)
+	}
+	return created, true, nil
+}
+
+// AchievementProgress kullanıcının oyundaki başarımlarını açılma durumlarıyla döndürür; açılmamış gizli başarımlar maskelenir
+func (s *DefaultAchievementService) AchievementProgress(ctx context.Context, userID, gameID primitive.ObjectID) (dto.AchievementProgress, error) {
+	ctx, span := tracer.Start(ctx, "AchievementService.AchievementProgress")
+	defer span.End()
+	achievements, err := s.withPercentages(ctx, gameID)
+	if err != nil {
+		return dto.AchievementProgress{}, recordError(span, err)
+	}
+	unlocks, err := s.Repo.ListUnlocks(ctx, userID, gameID)
+	if err != nil {
+		return dto.AchievementProgress{}, recordError(span, err)
+	}
+	unlockedAt := make(map[primitive.ObjectID]time.Time, len(unlocks))
+	for _, u := range unlocks {
+		unlockedAt[u.AchievementID] = u.UnlockedAt
+	}
+	progress := dto.AchievementProgress{GameID: gameID.Hex(), Total: len(achievements), Achievements: make([]dto.AchievementStatus, 0, len(achievements))}
+	for _, a := range achievements {
+		progress.TotalPoints += a.Points
+		status := dto.AchievementStatus{Achievement: a}
+		if at, ok := unlockedAt[a.ID]; ok {
+			status.UnlockedAt = &at
+			progress.Unlocked++
+			progress.Points += a.Points
+		} else if a.Hidden {
+			status.Achievement = maskAchievement(a)
+		}
+		progress.Achievements = append(progress.Achievements, status)
+	}
+	return progress, nil
+}
+
+// withPercentages oyunun başarımlarını getirir ve her birinin oyunun sahipleri arasındaki açılma yüzdesini doldurur
+func (s *DefaultAchievementService) withPercentages(ctx context.Context, gameID primitive.ObjectID) ([]models.Achievement, error) {
+	achievements, err := s.Repo.ListByGame(ctx, gameID)
+	if err != nil || len(achievements) == 0 {
+		return achievements, err
+	}
+	owners, err := s.Library.CountByGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range achievements {
+		achievements[i].UnlockPercent = unlockPercent(achievements[i].UnlockCount, owners)
+	}
+	return achievements, nil
+}
+
+// unlockPercent açılma yüzdesini bir ondalık basamağa yuvarlar; sahipliği sonradan kaldırılan kullanıcılar yüzünden 100'ü aşamaz
+func unlockPercent(unlocks, owners int64) float64 {
+	if owners <= 0 {
+		return 0
+	}
+	return math.Min(100, math.Round(float64(unlocks)/float64(owners)*1000)/10)
+}
+
+// maskAchievement gizli başarımın adını, açıklamasını ve simgesini gizler; puan ve yüzde görünür kalır
+func maskAchievement(a models.Achievement) models.Achievement {
+	a.Name, a.Description, a.IconURL = hiddenAchievementName, "", ""
+	return a
+}
+
+func validateAchievement(req dto.AchievementRequest) error {
+	if !achievementKeyPattern.MatchString(req.Key) || strings.TrimSpace(req.Name) == "" || req.Points < 0 || req.Points > maxAchievementPoints {
+		return ErrInvalidAchievement
+	}
+	return nil
+}
+
+// NewAchievementService başarım servisini oluşturur
+func NewAchievementService(repo repository.AchievementRepository, games repository.ProductRepository, library repository.LibraryRepository, logger *slog.Logger) AchievementService {
+	return &DefaultAchievementService{Repo: repo, Games: games, Library: library, Log: logger}
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"api-steam/auth"
+	"api-steam/dto"
+	"api-steam/models"
+	"api-steam/repository"
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// APIKeyService servisler arası çağrılar için API anahtarlarını yönetir
+type APIKeyService interface {
+	APIKeyCreate(ctx context.Context, req dto.CreateAPIKeyRequest, createdBy string) (*dto.CreatedAPIKeyDTO, error) //Yeni anahtar üretir
+	APIKeyList(ctx context.Context) ([]models.APIKey, error)                                                        //Anahtarları listeler
+	APIKeyRevoke(ctx context.Context, id primitive.ObjectID) (bool, error)                                          //Anahtarı iptal eder
+}
+
+// DefaultAPIKeyService anahtarları APIKeyRepository üzerinden saklar
+type DefaultAPIKeyService struct {
+	Repo repository.APIKeyRepository
+	Log  *slog.Logger
+}
+
+// APIKeyCreate yeni bir anahtar üretir; düz metin anahtar yalnızca bu çağrının sonucunda döner
+func (s *DefaultAPIKeyService) APIKeyCreate(ctx context.Context, req dto.CreateAPIKeyRequest, createdBy string) (*dto.CreatedAPIKeyDTO, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyService.APIKeyCreate")
+	defer span.End()
+	key, prefix, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	stored, err := s.Repo.Insert(ctx, models.APIKey{Name: req.Name, Prefix: prefix, Hash: hash, Roles: req.Roles, CreatedBy: createdBy})
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	return &dto.CreatedAPIKeyDTO{Key: key, APIKey: stored}, nil
+}
+
+// APIKeyList tüm anahtarları (özetler olmadan) getirir
+func (s *DefaultAPIKeyService) APIKeyList(ctx context.Context) ([]models.APIKey, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyService.APIKeyList")
+	defer span.End()
+	keys, err := s.Repo.List(ctx)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	return keys, nil
+}
+
+// APIKeyRevoke anahtarı iptal eder
+func (s *DefaultAPIKeyService) APIKeyRevoke(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyService.APIKeyRevoke")
+	defer span.End()
+	result, err := s.Repo.Revoke(ctx, id)
+	if err != nil {
+		return false, recordError(span, err)
+	}
+	return result, nil
+}
+
+// NewAPIKeyService API anahtarı servisini oluşturur
+func NewAPIKeyService(repo repository.APIKeyRepository, logger *slog.Logger) APIKeyService {
+	return &DefaultAPIKeyService{Repo: repo, Log: logger}
+}
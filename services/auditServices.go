@@ -0,0 +1,75 @@
+package services
+
+import (
+	"api-steam/auth"
+	"api-steam/logging"
+	"api-steam/models"
+	"api-steam/repository"
+	"context"
+	"log/slog"
+	"time"
+)
+
+// ActorSystem otomatik işlemlerde (ör. şikayet eşiği) denetim kaydının yapanı olarak kullanılır
+const ActorSystem = "system"
+
+// Denetim kaydı listelerinde varsayılan ve en fazla sayfa boyutu
+const (
+	defaultAuditPageSize = 100
+	maxAuditPageSize     = 500
+)
+
+// AuditService yönetimsel işlemleri denetim kaydına yazar ve listeler
+type AuditService interface {
+	AuditRecord(ctx context.Context, entry models.AuditEntry) error                          //İşlemi kaydeder; yapan boşsa context'teki çağıran kullanılır
+	AuditList(ctx context.Context, query repository.AuditQuery) ([]models.AuditEntry, error) //Kayıtları filtreleyerek listeler
+}
+
+// DefaultAuditService denetim kayıtlarını AuditRepository üzerinden tutar
+type DefaultAuditService struct {
+	Repo repository.AuditRepository
+	Log  *slog.Logger
+}
+
+// AuditRecord kaydı zaman, çağıran ve request_id ile tamamlayıp ekler
+func (s *DefaultAuditService) AuditRecord(ctx context.Context, entry models.AuditEntry) error {
+	ctx, span := tracer.Start(ctx, "AuditService.AuditRecord")
+	defer span.End()
+	if entry.At.IsZero() {
+		entry.At = time.Now()
+	}
+	if entry.ActorID == "" {
+		entry.ActorID, entry.ActorKind = ActorSystem, ActorSystem
+		if principal, ok := auth.FromContext(ctx); ok {
+			entry.ActorID, entry.ActorKind, entry.ActorName = principal.Subject, principal.Kind, principal.Name
+		}
+	}
+	entry.RequestID = logging.RequestID(ctx)
+	if err := s.Repo.Insert(ctx, entry); err != nil {
+		return recordError(span, err)
+	}
+	s.Log.InfoContext(ctx, "denetim kaydı", "action", entry.Action, "resource", entry.Resource, "resource_id", entry.ResourceID, "actor_id", entry.ActorID)
+	return nil
+}
+
+// AuditList denetim kayıtlarını en yeniden başlayarak döndürür
+func (s *DefaultAuditService) AuditList(ctx context.Context, query repository.AuditQuery) ([]models.AuditEntry, error) {
+	ctx, span := tracer.Start(ctx, "AuditService.AuditList")
+	defer span.End()
+	switch {
+	case query.Limit <= 0:
+		query.Limit = defaultAuditPageSize
+	case query.Limit > maxAuditPageSize:
+		query.Limit = maxAuditPageSize
+	}
+	entries, err := s.Repo.List(ctx, query)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	return entries, nil
+}
+
+// NewAuditService denetim servisini oluşturur
+func NewAuditService(repo repository.AuditRepository, logger *slog.Logger) AuditService {
+	return &DefaultAuditService{Repo: repo, Log: logger}
+}
@@ -0,0 +1,238 @@
+package services
+
+import (
+	"api-steam/models"
+	"api-steam/repository"
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	ErrUnknownChart  = errors.New("böyle bir grafik yok")
+	ErrChartNotReady = errors.New("grafikler henüz hesaplanmadı, lütfen biraz sonra tekrar deneyin")
+)
+
+const (
+	chartVelocityWindow = 7 * 24 * time.Hour  // Çok satanlar ve trendler bu süredeki etkinliğe göre hesaplanır
+	chartNewReleaseAge  = 90 * 24 * time.Hour // Bu süreden eski oyunlar yeni çıkanlarda yer almaz
+)
+
+// ChartService vitrin grafiklerini arka planda hesaplar ve bellekten sunar
+type ChartService interface {
+	ChartList(ctx context.Context) ([]models.Chart, error)                      //Grafiklerin adları ve hesaplanma zamanları (oyunlar olmadan)
+	ChartGet(ctx context.Context, name string, limit int) (models.Chart, error) //Tek grafik
+	ChartRefresh(ctx context.Context) (int, error)                              //Tüm grafikleri yeniden hesaplar
+}
+
+// DefaultChartService grafikleri katalog, yorum, istek listesi ve kütüphane verisinden hesaplayıp bellekte tutar
+type DefaultChartService struct {
+	Games      repository.ProductRepository
+	Reviews    repository.ReviewRepository
+	Wishlists  repository.WishlistRepository
+	Library    repository.LibraryRepository
+	Log        *slog.Logger
+	Size       int // Grafik başına tutulan en fazla oyun
+	MinReviews int // Bayes ortalamasındaki m değeri
+
+	mu     sync.RWMutex
+	charts map[string]models.Chart
+}
+
+// ChartList grafiklerin adlarını ve son hesaplanma zamanlarını döndürür
+func (s *DefaultChartService) ChartList(ctx context.Context) ([]models.Chart, error) {
+	_, span := tracer.Start(ctx, "ChartService.ChartList")
+	defer span.End()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.charts == nil {
+		return nil, ErrChartNotReady
+	}
+	list := make([]models.Chart, 0, len(models.ChartNames))
+	for _, name := range models.ChartNames {
+		chart := s.charts[name]
+		list = append(list, models.Chart{Name: name, GeneratedAt: chart.GeneratedAt, Entries: []models.ChartEntry{}})
+	}
+	return list, nil
+}
+
+// ChartGet grafiği son hesaplanmış haliyle döndürür; limit verilmişse ilk limit oyunla sınırlanır
+func (s *DefaultChartService) ChartGet(ctx context.Context, name string, limit int) (models.Chart, error) {
+	_, span := tracer.Start(ctx, "ChartService.ChartGet")
+	defer span.End()
+	known := false
+	for _, n := range models.ChartNames {
+		known = known || n == name
+	}
+	if !known {
+		return models.Chart{}, ErrUnknownChart
+	}
+	s.mu.RLock()
+	chart, ok := s.charts[name]
+	s.mu.RUnlock()
+	if !ok {
+		return models.Chart{}, ErrChartNotReady
+	}
+	if _, limited := ContentLimit(ctx); limited {
+		entries := make([]models.ChartEntry, 0, len(chart.Entries))
+		for _, entry := range chart.Entries {
+			if contentAllowed(ctx, entry.Game.MinAge) {
+				entry.Rank = len(entries) + 1
+				entries = append(entries, entry)
+			}
+		}
+		chart.Entries = entries
+	}
+	if limit > 0 && limit < len(chart.Entries) {
+		chart.Entries = chart.Entries[:limit]
+	}
+	if _, ok := Locale(ctx); ok {
+		entries := make([]models.ChartEntry, len(chart.Entries)) // önbellekteki kayıtlar değiştirilmez
+		for i, entry := range chart.Entries {
+			entry.Game = localizeSummary(ctx, entry.Game)
+			entries[i] = entry
+		}
+		chart.Entries = entries
+	}
+	return chart, nil
+}
+
+// ChartRefresh tüm grafikleri yeniden hesaplar ve önbelleği tek seferde değiştirir; hesaplama başarısız olursa eski
+// grafikler sunulmaya devam eder. Hesaplanan grafik sayısını döndürür.
+func (s *DefaultChartService) ChartRefresh(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "ChartService.ChartRefresh")
+	defer span.End()
+	now := time.Now()
+	since := now.Add(-chartVelocityWindow)
+	all, err := s.Games.GetAll(ctx)
+	if err != nil {
+		return 0, recordError(span, err)
+	}
+	purchases, err := s.Library.CountPurchasesSince(ctx, since)
+	if err != nil {
+		return 0, recordError(span, err)
+	}
+	reviews, err := s.Reviews.CountPublishedSince(ctx, since)
+	if err != nil {
+		return 0, recordError(span, err)
+	}
+	wishlists, err := s.Wishlists.CountAddedSince(ctx, since)
+	if err != nil {
+		return 0, recordError(span, err)
+	}
+	games := make([]models.Game, 0, len(all))
+	for _, game := range all {
+		if game.Status != models.GameStatusRemoved {
+			games = append(games, game)
+		}
+	}
+	meanScore := meanReviewScore(games)
+	days := chartVelocityWindow.Hours() / 24
+	scorers := map[string]func(models.Game) (float64, bool){
+		models.ChartTopSellers: func(g models.Game) (float64, bool) {
+			return float64(purchases[g.ID]), purchases[g.ID] > 0
+		},
+		models.ChartTopRated: func(g models.Game) (float64, bool) {
+			return bayesianScore(g.Rating, meanScore, s.MinReviews), g.Rating.TotalReviews > 0
+		},
+		models.ChartMostReviewed: func(g models.Game) (float64, bool) {
+			return float64(g.Rating.TotalReviews), g.Rating.TotalReviews > 0
+		},
+		models.ChartTrending: func(g models.Game) (float64, bool) {
+			activity := reviews[g.ID] + wishlists[g.ID]
+			return roundPrice(float64(activity) / days), activity > 0 // günlük yorum + istek listesi eklemesi
+		},
+		models.ChartNewReleases: func(g models.Game) (float64, bool) {
+			age := now.Sub(g.ReleaseDate)
+			return -math.Floor(age.Hours() / 24), !g.ReleaseDate.IsZero() && age >= 0 && age <= chartNewReleaseAge && g.Status != models.GameStatusComingSoon
+		},
+		models.ChartUpcoming: func(g models.Game) (float64, bool) {
+			return -math.Ceil(g.ReleaseDate.Sub(now).Hours() / 24), g.ReleaseDate.After(now)
+		},
+		models.ChartDiscounts: func(g models.Game) (float64, bool) {
+			active := g.Price.OnSale && g.Price.Discount > 0 && (g.Price.SaleEndDate.IsZero() || g.Price.SaleEndDate.After(now))
+			return math.Round(g.Price.Discount * 100), active
+		},
+	}
+	charts := make(map[string]models.Chart, len(scorers))
+	for name, scorer := range scorers {
+		charts[name] = s.rank(name, games, scorer, now)
+	}
+	s.mu.Lock()
+	s.charts = charts
+	s.mu.Unlock()
+	s.Log.InfoContext(ctx, "grafikler yeniden hesaplandı", "charts", len(charts), "games", len(games), "duration", time.Since(now).String())
+	return len(charts), nil
+}
+
+// rank scorer'ın kabul ettiği oyunları puana göre azalan sırada (eşitlikte başlığa göre) ilk Size oyunla sıralar.
+// Yeni çıkanlar ve yakında çıkacaklar için puan gün farkının negatifidir, böylece en yakın tarih başa gelir; yanıtta
+// gün farkı pozitif gösterilir.
+func (s *DefaultChartService) rank(name string, games []models.Game, scorer func(models.Game) (float64, bool), at time.Time) models.Chart {
+	type scored struct {
+		game  models.Game
+		score float64
+	}
+	var list []scored
+	for _, game := range games {
+		if score, ok := scorer(game); ok {
+			list = append(list, scored{game, score})
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].score != list[j].score {
+			return list[i].score > list[j].score
+		}
+		return list[i].game.Title < list[j].game.Title
+	})
+	if len(list) > s.Size {
+		list = list[:s.Size]
+	}
+	chart := models.Chart{Name: name, GeneratedAt: at, Entries: make([]models.ChartEntry, 0, len(list))}
+	for i, item := range list {
+		score := item.score
+		if name == models.ChartNewReleases || name == models.ChartUpcoming {
+			score = math.Abs(score)
+		}
+		chart.Entries = append(chart.Entries, models.ChartEntry{Rank: i + 1, Game: relatedSummary(item.game), Score: score})
+	}
+	return chart
+}
+
+// meanReviewScore yorumu olan oyunların tüm yorumları üzerinden ortalama puanı döndürür (Bayes ortalamasındaki C)
+func meanReviewScore(games []models.Game) float64 {
+	var sum float64
+	var count int
+	for _, game := range games {
+		if game.Rating.TotalReviews > 0 {
+			sum += game.Rating.AverageScore * float64(game.Rating.TotalReviews)
+			count += game.Rating.TotalReviews
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return sum / float64(count)
+}
+
+// bayesianScore oyunun ortalamasını yorum sayısı minReviews'e ulaşana kadar genel ortalamaya doğru çeker:
+// (v*R + m*C) / (v + m). Böylece tek yorumlu oyunlar listenin başına yerleşemez.
+func bayesianScore(rating models.Rating, mean float64, minReviews int) float64 {
+	v, m := float64(rating.TotalReviews), float64(minReviews)
+	if v+m == 0 {
+		return 0
+	}
+	return roundPrice((v*rating.AverageScore + m*mean) / (v + m))
+}
+
+// NewChartService grafik servisini oluşturur; grafikler ilk ChartRefresh çağrısına kadar boştur
+func NewChartService(games repository.ProductRepository, reviews repository.ReviewRepository, wishlists repository.WishlistRepository, library repository.LibraryRepository, logger *slog.Logger, size, minReviews int) ChartService {
+	if size <= 0 {
+		size = 50
+	}
+	return &DefaultChartService{Games: games, Reviews: reviews, Wishlists: wishlists, Library: library, Log: logger, Size: size, MinReviews: minReviews}
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"api-steam/models"
+	"context"
+	"strings"
+)
+
+// esrbRatingPending ESRB'nin "derecelendirme bekleniyor" değeridir; geçerlidir ama yaş belirtmez
+const esrbRatingPending = "RP"
+
+type contentLimitKey struct{}
+
+// WithContentLimit isteğin içerik ayarlarındaki yaş sınırını context'e ekler; sınır yoksa context değişmez
+func WithContentLimit(ctx context.Context, settings models.ContentSettings) context.Context {
+	if age, ok := settings.MaxAge(); ok {
+		return context.WithValue(ctx, contentLimitKey{}, age)
+	}
+	return ctx
+}
+
+// ContentLimit isteğin gösterebileceği en yüksek yaş sınırını döndürür; filtre yoksa ok=false döner
+func ContentLimit(ctx context.Context) (maxAge int, ok bool) {
+	maxAge, ok = ctx.Value(contentLimitKey{}).(int)
+	return maxAge, ok
+}
+
+// ParseContentSettings PEGI ve ESRB sınırlarını doğrular ve normalleştirir; boş değerler sınır yok demektir
+func ParseContentSettings(maxPEGI, maxESRB string) (models.ContentSettings, error) {
+	settings := models.ContentSettings{MaxPEGI: strings.TrimSpace(maxPEGI), MaxESRB: strings.ToUpper(strings.TrimSpace(maxESRB))}
+	if _, ok := models.PEGIAges[settings.MaxPEGI]; settings.MaxPEGI != "" && !ok {
+		return models.ContentSettings{}, ErrInvalidAgeRating
+	}
+	if _, ok := models.ESRBAges[settings.MaxESRB]; settings.MaxESRB != "" && !ok {
+		return models.ContentSettings{}, ErrInvalidAgeRating
+	}
+	return settings, nil
+}
+
+// contentAllowed yaş sınırı olan oyunun isteğin sınırı içinde kalıp kalmadığını döndürür. Filtre varken derecesi
+// olmayan oyunlar gösterilmez.
+func contentAllowed(ctx context.Context, minAge int) bool {
+	maxAge, limited := ContentLimit(ctx)
+	return !limited || (minAge > 0 && minAge <= maxAge)
+}
+
+// GameAllowed oyunun isteğin yaş sınırı içinde kalıp kalmadığını döndürür; oyun detayı listelerle aynı kuralla gizlenir
+func GameAllowed(ctx context.Context, game models.Game) bool {
+	age, _ := game.Rating.MinimumAge()
+	return contentAllowed(ctx, age)
+}
+
+// filterContent isteğin yaş sınırını aşan oyunları listeden çıkarır
+func filterContent(ctx context.Context, games []models.Game) []models.Game {
+	if _, limited := ContentLimit(ctx); !limited {
+		return games
+	}
+	filtered := make([]models.Game, 0, len(games))
+	for _, game := range games {
+		if GameAllowed(ctx, game) {
+			filtered = append(filtered, game)
+		}
+	}
+	return filtered
+}
+
+// validateAgeRating oyunun ESRB ve PEGI derecelerinin bilinen değerler olduğunu doğrular; boş değerler geçerlidir
+func validateAgeRating(rating models.Rating) error {
+	if _, ok := models.PEGIAges[strings.TrimSpace(rating.PEGI)]; strings.TrimSpace(rating.PEGI) != "" && !ok {
+		return ErrInvalidAgeRating
+	}
+	esrb := strings.ToUpper(strings.TrimSpace(rating.ESRB))
+	if _, ok := models.ESRBAges[esrb]; esrb != "" && esrb != esrbRatingPending && !ok {
+		return ErrInvalidAgeRating
+	}
+	return nil
+}
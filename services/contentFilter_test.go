@@ -0,0 +1,49 @@
+package services
+
+import (
+	"api-steam/models"
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestFilterContent(t *testing.T) {
+	games := []models.Game{
+		{Title: "Yetişkin", Rating: models.Rating{PEGI: "18", MinAge: 18}},
+		{Title: "Genç", Rating: models.Rating{ESRB: "T"}},
+		{Title: "Derecesiz"},
+		{Title: "Çocuk", Rating: models.Rating{PEGI: "3", ESRB: "EC"}},
+	}
+	tests := []struct {
+		name     string
+		settings models.ContentSettings
+		want     []string
+	}{
+		{"sınır yok", models.ContentSettings{}, []string{"Yetişkin", "Genç", "Derecesiz", "Çocuk"}},
+		{"PEGI 16", models.ContentSettings{MaxPEGI: "16"}, []string{"Genç", "Çocuk"}},
+		{"ESRB E", models.ContentSettings{MaxESRB: "E"}, []string{"Çocuk"}},
+		{"PEGI 18", models.ContentSettings{MaxPEGI: "18"}, []string{"Yetişkin", "Genç", "Çocuk"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := WithContentLimit(context.Background(), tt.settings)
+			var got []string
+			for _, game := range filterContent(ctx, games) {
+				got = append(got, game.Title)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filtrelenen = %v, beklenen %v", got, tt.want)
+			}
+			for _, game := range games {
+				allowed := GameAllowed(ctx, game)
+				listed := false
+				for _, title := range got {
+					listed = listed || title == game.Title
+				}
+				if allowed != listed {
+					t.Errorf("%s: GameAllowed = %v, listede %v; detay ve liste aynı kuralla filtrelenmeli", game.Title, allowed, listed)
+				}
+			}
+		})
+	}
+}
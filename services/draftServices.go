@@ -0,0 +1,394 @@
+package services
+
+import (
+	"api-steam/auth"
+	"api-steam/dto"
+	"api-steam/models"
+	"api-steam/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrDraftNotFound     = errors.New("oyunun taslağı yok")
+	ErrDraftExists       = errors.New("oyunun zaten bir taslağı var")
+	ErrInvalidDraftState = errors.New("taslak bu işlem için uygun durumda değil")
+	ErrDraftConflict     = errors.New("taslak bu sırada başka bir istekle değişti, lütfen tekrar deneyin")
+	ErrSelfApproval      = errors.New("taslağı düzenleyen veya incelemeye gönderen kullanıcı onaylayamaz")
+	ErrDraftStale        = errors.New("canlı oyun taslak oluşturulduktan sonra değişti; taslağı yeniden oluşturun veya force ile yayınlayın")
+)
+
+// DraftService editörlerin canlı oyun yerine taslak üzerinde çalışmasını, incelemeyi, onayı ve (zamanlanmış) yayını yönetir
+type DraftService interface {
+	DraftCreate(ctx context.Context, gameID primitive.ObjectID) (models.GameDraft, error)                                     //Canlı oyundan taslak oluşturur
+	DraftGet(ctx context.Context, gameID primitive.ObjectID) (models.GameDraft, error)                                        //Önizleme
+	DraftUpdate(ctx context.Context, gameID primitive.ObjectID, game models.Game) (models.GameDraft, error)                   //Taslak içeriğini değiştirir
+	DraftSubmit(ctx context.Context, gameID primitive.ObjectID) (models.GameDraft, error)                                     //İncelemeye gönderir
+	DraftApprove(ctx context.Context, gameID primitive.ObjectID) (models.GameDraft, error)                                    //Başka bir kullanıcı onaylar
+	DraftReject(ctx context.Context, gameID primitive.ObjectID, reason string) (models.GameDraft, error)                      //Düzenlemeye geri gönderir
+	DraftPublish(ctx context.Context, gameID primitive.ObjectID, req dto.DraftPublishRequest) (dto.DraftPublishResult, error) //Hemen yayınlar ya da zamanlar
+	DraftDiscard(ctx context.Context, gameID primitive.ObjectID) error                                                        //Taslağı siler
+	DraftList(ctx context.Context, state string) ([]models.GameDraft, error)                                                  //İnceleme kuyruğu vb.
+	DraftRevisions(ctx context.Context, gameID primitive.ObjectID) ([]models.GameRevision, error)                             //Yayın geçmişi
+	DraftPublishDue(ctx context.Context) (int, error)                                                                         //Zamanı gelen taslakları yayınlar
+}
+
+// DefaultDraftService taslakları DraftRepository'de tutar; yayın ProductService.ProductPublish ile tek yazmada yapılır
+type DefaultDraftService struct {
+	Repo     repository.DraftRepository
+	Products ProductService
+	Audit    AuditService
+	Log      *slog.Logger
+}
+
+// DraftCreate canlı oyunun kopyasından düzenlenebilir bir taslak oluşturur; taslağın temel sürümü canlı oyunun updated_at değeridir
+func (s *DefaultDraftService) DraftCreate(ctx context.Context, gameID primitive.ObjectID) (models.GameDraft, error) {
+	ctx, span := tracer.Start(ctx, "DraftService.DraftCreate")
+	defer span.End()
+	game, err := s.Products.ProductGetByID(ctx, gameID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.GameDraft{}, ErrGameNotFound
+	}
+	if err != nil {
+		return models.GameDraft{}, recordError(span, err)
+	}
+	now, actor := time.Now(), currentActor(ctx)
+	draft, err := s.Repo.Create(ctx, models.GameDraft{
+		GameID: gameID, Game: game, State: models.DraftEditing, BaseVersion: game.UpdatedAt,
+		CreatedBy: actor, UpdatedBy: actor, CreatedAt: now, UpdatedAt: now,
+	})
+	if errors.Is(err, repository.ErrDuplicate) {
+		return models.GameDraft{}, ErrDraftExists
+	}
+	if err != nil {
+		return models.GameDraft{}, recordError(span, err)
+	}
+	return draft, nil
+}
+
+// DraftGet taslağı yayınlandığında görüneceği haliyle döndürür: puanlar, oynama süreleri ve durum canlı oyundan alınır
+func (s *DefaultDraftService) DraftGet(ctx context.Context, gameID primitive.ObjectID) (models.GameDraft, error) {
+	ctx, span := tracer.Start(ctx, "DraftService.DraftGet")
+	defer span.End()
+	draft, err := s.get(ctx, gameID)
+	if err != nil {
+		return models.GameDraft{}, recordError(span, err)
+	}
+	live, err := s.Products.ProductGetByID(ctx, gameID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return models.GameDraft{}, recordError(span, err)
+	}
+	if err == nil {
+		draft.Game.Rating = withComputedRating(draft.Game.Rating, live.Rating)
+		withComputedPlaytime(&draft.Game, live)
+		withCurrentStatus(&draft.Game, live)
+	}
+	return draft, nil
+}
+
+// DraftUpdate taslak içeriğini değiştirir. İncelemedeki, onaylanmış ya da zamanlanmış taslak düzenlenirse onay ve
+// zamanlama düşer ve taslak yeniden editing durumuna döner.
+func (s *DefaultDraftService) DraftUpdate(ctx context.Context, gameID primitive.ObjectID, game models.Game) (models.GameDraft, error) {
+	ctx, span := tracer.Start(ctx, "DraftService.DraftUpdate")
+	defer span.End()
+	draft, err := s.get(ctx, gameID)
+	if err != nil {
+		return models.GameDraft{}, recordError(span, err)
+	}
+	if draft.State == models.DraftPublishing {
+		return models.GameDraft{}, ErrInvalidDraftState
+	}
+	if err := validateAgeRating(game.Rating); err != nil {
+		return models.GameDraft{}, err
+	}
+	previous := draft.State
+	game.ID = gameID
+	draft.Game, draft.State, draft.UpdatedBy = game, models.DraftEditing, currentActor(ctx)
+	draft.SubmittedBy, draft.SubmittedAt, draft.ApprovedBy, draft.ApprovedAt, draft.PublishAt = "", nil, "", nil, nil
+	return s.save(ctx, draft, previous)
+}
+
+// DraftSubmit düzenlenen taslağı incelemeye gönderir
+func (s *DefaultDraftService) DraftSubmit(ctx context.Context, gameID primitive.ObjectID) (models.GameDraft, error) {
+	ctx, span := tracer.Start(ctx, "DraftService.DraftSubmit")
+	defer span.End()
+	draft, err := s.get(ctx, gameID)
+	if err != nil {
+		return models.GameDraft{}, recordError(span, err)
+	}
+	if draft.State != models.DraftEditing {
+		return models.GameDraft{}, ErrInvalidDraftState
+	}
+	now := time.Now()
+	draft.State, draft.SubmittedBy, draft.SubmittedAt, draft.ReviewNote = models.DraftInReview, currentActor(ctx), &now, ""
+	draft, err = s.save(ctx, draft, models.DraftEditing)
+	if err == nil {
+		s.audit(ctx, "draft.submit", draft, "")
+	}
+	return draft, err
+}
+
+// DraftApprove incelemedeki taslağı onaylar. Onaylayan, taslağı son düzenleyen ya da incelemeye gönderen kullanıcı olamaz.
+func (s *DefaultDraftService) DraftApprove(ctx context.Context, gameID primitive.ObjectID) (models.GameDraft, error) {
+	ctx, span := tracer.Start(ctx, "DraftService.DraftApprove")
+	defer span.End()
+	draft, err := s.get(ctx, gameID)
+	if err != nil {
+		return models.GameDraft{}, recordError(span, err)
+	}
+	if draft.State != models.DraftInReview {
+		return models.GameDraft{}, ErrInvalidDraftState
+	}
+	approver := currentActor(ctx)
+	if approver == "" || approver == draft.SubmittedBy || approver == draft.UpdatedBy {
+		return models.GameDraft{}, ErrSelfApproval
+	}
+	now := time.Now()
+	draft.State, draft.ApprovedBy, draft.ApprovedAt = models.DraftApproved, approver, &now
+	draft, err = s.save(ctx, draft, models.DraftInReview)
+	if err == nil {
+		s.audit(ctx, "draft.approve", draft, "")
+	}
+	return draft, err
+}
+
+// DraftReject incelemedeki taslağı gerekçesiyle düzenlemeye geri gönderir
+func (s *DefaultDraftService) DraftReject(ctx context.Context, gameID primitive.ObjectID, reason string) (models.GameDraft, error) {
+	ctx, span := tracer.Start(ctx, "DraftService.DraftReject")
+	defer span.End()
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return models.GameDraft{}, ErrReasonRequired
+	}
+	draft, err := s.get(ctx, gameID)
+	if err != nil {
+		return models.GameDraft{}, recordError(span, err)
+	}
+	if draft.State != models.DraftInReview {
+		return models.GameDraft{}, ErrInvalidDraftState
+	}
+	draft.State, draft.ReviewNote, draft.SubmittedBy, draft.SubmittedAt = models.DraftEditing, reason, "", nil
+	draft, err = s.save(ctx, draft, models.DraftInReview)
+	if err == nil {
+		s.audit(ctx, "draft.reject", draft, reason)
+	}
+	return draft, err
+}
+
+// DraftPublish onaylanmış (ya da zamanlanmış) taslağı yayınlar. publish_at gelecekteyse taslak zamanlanır ve zamanı
+// geldiğinde arka plan işi yayınlar; aksi halde hemen yayınlanır ve revizyon kaydedilir.
+func (s *DefaultDraftService) DraftPublish(ctx context.Context, gameID primitive.ObjectID, req dto.DraftPublishRequest) (dto.DraftPublishResult, error) {
+	ctx, span := tracer.Start(ctx, "DraftService.DraftPublish")
+	defer span.End()
+	draft, err := s.get(ctx, gameID)
+	if err != nil {
+		return dto.DraftPublishResult{}, recordError(span, err)
+	}
+	if draft.State != models.DraftApproved && draft.State != models.DraftScheduled {
+		return dto.DraftPublishResult{}, ErrInvalidDraftState
+	}
+	if req.PublishAt != nil && req.PublishAt.After(time.Now()) {
+		previous := draft.State
+		draft.State, draft.PublishAt = models.DraftScheduled, req.PublishAt
+		draft, err = s.save(ctx, draft, previous)
+		if err != nil {
+			return dto.DraftPublishResult{}, err
+		}
+		s.audit(ctx, "draft.schedule", draft, "")
+		return dto.DraftPublishResult{Draft: &draft}, nil
+	}
+	revision, err := s.publish(ctx, draft, req.Force, currentActor(ctx))
+	if err != nil {
+		return dto.DraftPublishResult{}, recordError(span, err)
+	}
+	return dto.DraftPublishResult{Published: true, Revision: &revision}, nil
+}
+
+// DraftDiscard taslağı siler; yayınlanmakta olan taslak silinemez
+func (s *DefaultDraftService) DraftDiscard(ctx context.Context, gameID primitive.ObjectID) error {
+	ctx, span := tracer.Start(ctx, "DraftService.DraftDiscard")
+	defer span.End()
+	draft, err := s.get(ctx, gameID)
+	if err != nil {
+		return recordError(span, err)
+	}
+	if draft.State == models.DraftPublishing {
+		return ErrInvalidDraftState
+	}
+	if err := s.Repo.Delete(ctx, draft.ID); err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return recordError(span, err)
+	}
+	return nil
+}
+
+// DraftList taslakları durumlarına göre listeler (ör. state=in_review inceleme kuyruğudur)
+func (s *DefaultDraftService) DraftList(ctx context.Context, state string) ([]models.GameDraft, error) {
+	ctx, span := tracer.Start(ctx, "DraftService.DraftList")
+	defer span.End()
+	drafts, err := s.Repo.List(ctx, state)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	return drafts, nil
+}
+
+// DraftRevisions oyunun yayın geçmişini en yeniden başlayarak döndürür
+func (s *DefaultDraftService) DraftRevisions(ctx context.Context, gameID primitive.ObjectID) ([]models.GameRevision, error) {
+	ctx, span := tracer.Start(ctx, "DraftService.DraftRevisions")
+	defer span.End()
+	revisions, err := s.Repo.ListRevisions(ctx, gameID)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	return revisions, nil
+}
+
+// DraftPublishDue yayın zamanı gelmiş taslakları yayınlar. Yayınlanamayan taslak (ör. canlı oyun bu arada değiştiyse)
+// zamanlaması kaldırılıp onaylanmış duruma döner ve gerekçesi review_note'a yazılır; böylece her turda yeniden denenmez.
+func (s *DefaultDraftService) DraftPublishDue(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "DraftService.DraftPublishDue")
+	defer span.End()
+	due, err := s.Repo.ListDue(ctx, time.Now())
+	if err != nil {
+		return 0, recordError(span, err)
+	}
+	published := 0
+	for _, draft := range due {
+		if _, err := s.publish(ctx, draft, false, ActorSystem); err != nil {
+			s.Log.WarnContext(ctx, "zamanlanmış taslak yayınlanamadı", "game_id", draft.GameID, "error", err)
+			if errors.Is(err, ErrDraftConflict) {
+				continue
+			}
+			if current, err2 := s.Repo.GetByGame(ctx, draft.GameID); err2 == nil && current.State == models.DraftScheduled {
+				current.State, current.PublishAt, current.ReviewNote = models.DraftApproved, nil, "Zamanlanmış yayın başarısız: "+err.Error()
+				if _, err2 := s.Repo.Save(ctx, current, models.DraftScheduled); err2 != nil {
+					s.Log.ErrorContext(ctx, "başarısız zamanlanmış taslak güncellenemedi", "game_id", draft.GameID, "error", err2)
+				}
+			}
+			continue
+		}
+		published++
+	}
+	return published, nil
+}
+
+// publish taslağı yayınlanıyor olarak işaretler (aynı taslağın iki kez yayınlanmasını önler), canlı oyuna tek bir koşullu
+// yazmayla uygular, revizyonu kaydeder ve taslağı siler. Canlı oyuna yazılamazsa taslak önceki durumuna döner.
+func (s *DefaultDraftService) publish(ctx context.Context, draft models.GameDraft, force bool, by string) (models.GameRevision, error) {
+	previous := draft.State
+	draft.State = models.DraftPublishing
+	ok, err := s.Repo.Save(ctx, draft, models.DraftApproved, models.DraftScheduled)
+	if err != nil {
+		return models.GameRevision{}, err
+	}
+	if !ok {
+		return models.GameRevision{}, ErrDraftConflict
+	}
+	version := &draft.BaseVersion
+	if force {
+		version = nil
+	}
+	before, after, err := s.Products.ProductPublish(ctx, draft.GameID, draft.Game, version)
+	if err != nil {
+		draft.State = previous
+		if _, restoreErr := s.Repo.Save(ctx, draft, models.DraftPublishing); restoreErr != nil {
+			s.Log.ErrorContext(ctx, "taslak önceki durumuna döndürülemedi", "game_id", draft.GameID, "error", restoreErr)
+		}
+		if errors.Is(err, ErrGameChanged) {
+			return models.GameRevision{}, ErrDraftStale
+		}
+		return models.GameRevision{}, err
+	}
+	now := time.Now()
+	revision, err := s.Repo.InsertRevision(ctx, models.GameRevision{
+		GameID: draft.GameID, DraftID: draft.ID, Changes: changedFields(before, after), Before: before, After: after,
+		EditedBy: draft.UpdatedBy, ApprovedBy: draft.ApprovedBy, PublishedBy: by, PublishedAt: now,
+	})
+	if err != nil {
+		// oyun yayınlandı; revizyonun eksik kalması yayını geri almaz
+		s.Log.ErrorContext(ctx, "yayın revizyonu kaydedilemedi", "game_id", draft.GameID, "error", err)
+	}
+	if err := s.Repo.Delete(ctx, draft.ID); err != nil && !errors.Is(err, repository.ErrNotFound) {
+		s.Log.ErrorContext(ctx, "yayınlanan taslak silinemedi", "game_id", draft.GameID, "error", err)
+	}
+	s.audit(ctx, "game.publish", draft, "")
+	s.Log.InfoContext(ctx, "taslak yayınlandı", "game_id", draft.GameID, "revision", revision.Number, "changes", revision.Changes)
+	return revision, nil
+}
+
+func (s *DefaultDraftService) get(ctx context.Context, gameID primitive.ObjectID) (models.GameDraft, error) {
+	draft, err := s.Repo.GetByGame(ctx, gameID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.GameDraft{}, ErrDraftNotFound
+	}
+	return draft, err
+}
+
+// save taslağı durumu hâlâ expected ise kaydeder
+func (s *DefaultDraftService) save(ctx context.Context, draft models.GameDraft, expected string) (models.GameDraft, error) {
+	ok, err := s.Repo.Save(ctx, draft, expected)
+	if err != nil {
+		return models.GameDraft{}, err
+	}
+	if !ok {
+		return models.GameDraft{}, ErrDraftConflict
+	}
+	draft.UpdatedAt = time.Now()
+	return draft, nil
+}
+
+// audit denetim kaydını yazar; işlem zaten gerçekleştiği için hata yalnızca loglanır
+func (s *DefaultDraftService) audit(ctx context.Context, action string, draft models.GameDraft, reason string) {
+	if err := s.Audit.AuditRecord(ctx, models.AuditEntry{
+		Action:     action,
+		Resource:   "game",
+		ResourceID: draft.GameID.Hex(),
+		Reason:     reason,
+		Details:    map[string]interface{}{"draft_id": draft.ID.Hex(), "state": draft.State},
+	}); err != nil {
+		s.Log.ErrorContext(ctx, "taslak işlemi denetim kaydına yazılamadı", "action", action, "game_id", draft.GameID, "error", err)
+	}
+}
+
+// currentActor isteği yapan kullanıcının ya da API anahtarının ID'sini döndürür; kimlik yoksa boş döner
+func currentActor(ctx context.Context) string {
+	if principal, ok := auth.FromContext(ctx); ok {
+		return principal.Subject
+	}
+	return ""
+}
+
+// changedFields iki oyun arasında değişen üst düzey alanları JSON adlarıyla sıralı döndürür; updated_at sayılmaz
+func changedFields(before, after models.Game) []string {
+	var a, b map[string]interface{}
+	beforeJSON, _ := json.Marshal(before)
+	afterJSON, _ := json.Marshal(after)
+	_ = json.Unmarshal(beforeJSON, &a)
+	_ = json.Unmarshal(afterJSON, &b)
+	changes := []string{}
+	for field, value := range b {
+		if field != "updated_at" && !reflect.DeepEqual(a[field], value) {
+			changes = append(changes, field)
+		}
+	}
+	for field := range a {
+		if _, ok := b[field]; !ok {
+			changes = append(changes, field)
+		}
+	}
+	sort.Strings(changes)
+	return changes
+}
+
+// NewDraftService taslak servisini oluşturur
+func NewDraftService(repo repository.DraftRepository, products ProductService, audit AuditService, logger *slog.Logger) DraftService {
+	return &DefaultDraftService{Repo: repo, Products: products, Audit: audit, Log: logger}
+}
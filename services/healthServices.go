@@ -0,0 +1,95 @@
+package services
+
+import (
+	"api-steam/configs"
+	"api-steam/dto"
+	"api-steam/migrations"
+	"api-steam/workers"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// HealthService yük dengeleyici ve operatörler için sağlık kontrollerini yapar
+type HealthService interface {
+	Readiness(ctx context.Context) dto.ReadinessDTO //Trafik almaya hazır mı?
+	Status(ctx context.Context) dto.StatusDTO       //Ayrıntılı durum bilgisi
+}
+
+// DefaultHealthService MongoDB, migration ve arka plan işlerini kontrol eder
+type DefaultHealthService struct {
+	Client      *mongo.Client
+	Migrations  *migrations.Runner
+	Workers     *workers.Manager
+	PingTimeout time.Duration
+}
+
+// Readiness MongoDB'ye süre sınırı içinde ping atar, migration'ların uygulandığını ve işlerin çalıştığını kontrol eder
+func (s *DefaultHealthService) Readiness(ctx context.Context) dto.ReadinessDTO {
+	checks := map[string]dto.CheckDTO{}
+	latency, err := s.ping(ctx)
+	checks["mongodb"] = checkResult(err == nil, latency, err, "")
+	checks["migrations"] = checkResult(s.Migrations.Check(ctx), 0, nil, "migration'lar henüz uygulanmadı")
+	stopped := ""
+	for name, running := range s.Workers.Running() {
+		if !running {
+			stopped = name + " çalışmıyor"
+			break
+		}
+	}
+	checks["workers"] = checkResult(stopped == "", 0, nil, stopped)
+
+	ready := true
+	for _, c := range checks {
+		ready = ready && c.OK
+	}
+	return dto.ReadinessDTO{Ready: ready, Checks: checks}
+}
+
+// Status sürüm, uptime, veritabanı gecikmesi ve bağlantı havuzu bilgilerini döndürür
+func (s *DefaultHealthService) Status(ctx context.Context) dto.StatusDTO {
+	uptime := time.Since(configs.StartedAt)
+	latency, err := s.ping(ctx)
+	db := dto.DatabaseStatusDTO{Reachable: err == nil, LatencyMs: latency, Pool: configs.GetPoolStats()}
+	if err != nil {
+		db.Error = err.Error()
+	}
+	status := dto.StatusDTO{
+		Version:           configs.Version,
+		StartedAt:         configs.StartedAt.Format(time.RFC3339),
+		Uptime:            uptime.Round(time.Second).String(),
+		UptimeSeconds:     int64(uptime.Seconds()),
+		Database:          db,
+		MigrationsApplied: s.Migrations.Applied(),
+		Workers:           s.Workers.Running(),
+	}
+	if err == nil {
+		status.PendingMigrations, _ = s.Migrations.Pending(ctx)
+	}
+	return status
+}
+
+// ping MongoDB'ye PingTimeout süresi içinde ping atar ve gecikmeyi milisaniye olarak döndürür
+func (s *DefaultHealthService) ping(ctx context.Context) (float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.PingTimeout)
+	defer cancel()
+	start := time.Now()
+	err := s.Client.Ping(ctx, nil)
+	return float64(time.Since(start).Microseconds()) / 1000, err
+}
+
+func checkResult(ok bool, latency float64, err error, reason string) dto.CheckDTO {
+	c := dto.CheckDTO{OK: ok, LatencyMs: latency}
+	if err != nil {
+		c.Error = err.Error()
+	} else if !ok {
+		c.Error = reason
+	}
+	return c
+}
+
+// NewHealthService sağlık servisini oluşturur
+func NewHealthService(client *mongo.Client, runner *migrations.Runner, manager *workers.Manager, pingTimeout time.Duration) HealthService {
+	return &DefaultHealthService{Client: client, Migrations: runner, Workers: manager, PingTimeout: pingTimeout}
+}
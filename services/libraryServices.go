@@ -0,0 +1,261 @@
+package services
+
+import (
+	"api-steam/dto"
+	"api-steam/models"
+	"api-steam/repository"
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrInvalidLibraryEntry = errors.New("geçersiz sahiplik kaydı")
+	ErrAlreadyOwned        = errors.New("kullanıcı bu oyuna zaten sahip")
+	ErrNotOwned            = errors.New("kullanıcı bu oyuna sahip değil")
+	ErrUserNotFound        = errors.New("kullanıcı bulunamadı")
+)
+
+const maxLibraryPageSize = 200
+
+// LibraryService kullanıcıların sahip olduğu oyunları yönetir
+type LibraryService interface {
+	LibraryList(ctx context.Context, userID primitive.ObjectID, query repository.LibraryQuery) ([]models.LibraryEntry, error)        //Kütüphane, oyunların güncel başlık ve kapağıyla
+	LibraryOwnership(ctx context.Context, userID, gameID primitive.ObjectID) (models.LibraryEntry, error)                            //Sahiplik kaydı; sahip değilse ErrNotOwned
+	LibraryGrant(ctx context.Context, userID primitive.ObjectID, req dto.LibraryGrantRequest) (models.LibraryEntry, error)           //Satın alma, hediye veya anahtar kaydı
+	LibraryRevoke(ctx context.Context, userID, gameID primitive.ObjectID, req dto.LibraryRevokeRequest) (models.LibraryEntry, error) //Sahipliği kaldırır (ör. iade)
+}
+
+// DefaultLibraryService sahiplik kayıtlarını LibraryRepository'de tutar; değişiklikleri yorumların "oyuna sahip" işaretine ve denetim kaydına yansıtır
+type DefaultLibraryService struct {
+	Repo    repository.LibraryRepository
+	Games   repository.ProductRepository
+	Users   repository.UserRepository
+	Reviews repository.ReviewRepository
+	Audit   AuditService
+	Log     *slog.Logger
+}
+
+// LibraryList kullanıcının kütüphanesini filtreleyip oyunların güncel bilgileriyle döndürür
+func (s *DefaultLibraryService) LibraryList(ctx context.Context, userID primitive.ObjectID, query repository.LibraryQuery) ([]models.LibraryEntry, error) {
+	ctx, span := tracer.Start(ctx, "LibraryService.LibraryList")
+	defer span.End()
+	if query.Source != "" && !models.AcquisitionSources[query.Source] {
+		return nil, ErrInvalidLibraryEntry
+	}
+	entries, err := s.Repo.ListByUser(ctx, userID, query)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	if err := s.attachGames(ctx, entries); err != nil {
+		return nil, recordError(span, err)
+	}
+	if title := strings.ToLower(strings.TrimSpace(query.Title)); title != "" {
+		filtered := entries[:0]
+		for _, entry := range entries {
+			if entry.Game != nil && strings.Contains(strings.ToLower(entry.Game.Title), title) {
+				filtered = append(filtered, entry)
+			}
+		}
+		entries = filtered
+	}
+	switch query.Sort {
+	case "title":
+		sort.SliceStable(entries, func(i, j int) bool {
+			return strings.ToLower(gameTitle(entries[i])) < strings.ToLower(gameTitle(entries[j]))
+		})
+	case "price":
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].PricePaid > entries[j].PricePaid })
+	}
+	return paginate(entries, query.Skip, query.Limit, maxLibraryPageSize), nil
+}
+
+// LibraryOwnership kullanıcının oyun için sahiplik kaydını döndürür
+func (s *DefaultLibraryService) LibraryOwnership(ctx context.Context, userID, gameID primitive.ObjectID) (models.LibraryEntry, error) {
+	ctx, span := tracer.Start(ctx, "LibraryService.LibraryOwnership")
+	defer span.End()
+	entry, err := s.Repo.Get(ctx, userID, gameID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.LibraryEntry{}, ErrNotOwned
+	}
+	if err != nil {
+		return models.LibraryEntry{}, recordError(span, err)
+	}
+	if game, err := s.Games.GetByID(ctx, gameID); err == nil {
+		entry.Game = librarySummary(game)
+	}
+	return entry, nil
+}
+
+// LibraryGrant oyunu kullanıcının kütüphanesine ekler. Fiyat gönderilmezse satın almada oyunun o anki (indirimli) fiyatı kaydedilir.
+func (s *DefaultLibraryService) LibraryGrant(ctx context.Context, userID primitive.ObjectID, req dto.LibraryGrantRequest) (models.LibraryEntry, error) {
+	ctx, span := tracer.Start(ctx, "LibraryService.LibraryGrant")
+	defer span.End()
+	if !models.AcquisitionSources[req.Source] || (req.PricePaid != nil && *req.PricePaid < 0) {
+		return models.LibraryEntry{}, ErrInvalidLibraryEntry
+	}
+	gameID, err := primitive.ObjectIDFromHex(req.GameID)
+	if err != nil {
+		return models.LibraryEntry{}, ErrGameNotFound
+	}
+	if _, err := s.Users.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.LibraryEntry{}, ErrUserNotFound
+		}
+		return models.LibraryEntry{}, recordError(span, err)
+	}
+	game, err := s.Games.GetByID(ctx, gameID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.LibraryEntry{}, ErrGameNotFound
+	}
+	if err != nil {
+		return models.LibraryEntry{}, recordError(span, err)
+	}
+	entry := models.LibraryEntry{UserID: userID, GameID: gameID, Source: req.Source, Currency: req.Currency, OrderRef: strings.TrimSpace(req.OrderRef)}
+	if req.AcquiredAt != nil {
+		entry.AcquiredAt = *req.AcquiredAt
+	}
+	switch {
+	case req.PricePaid != nil:
+		entry.PricePaid = *req.PricePaid
+	case req.Source == models.AcquiredPurchase:
+		entry.PricePaid = game.Price.Effective()
+	}
+	if entry.Currency == "" {
+		entry.Currency = game.Price.Currency
+	}
+	if req.GiftedBy != "" {
+		giver, err := primitive.ObjectIDFromHex(req.GiftedBy)
+		if err != nil || req.Source != models.AcquiredGift || giver == userID {
+			return models.LibraryEntry{}, ErrInvalidLibraryEntry
+		}
+		entry.GiftedBy = &giver
+	}
+	entry, err = s.Repo.Grant(ctx, entry)
+	if errors.Is(err, repository.ErrDuplicate) {
+		return models.LibraryEntry{}, ErrAlreadyOwned
+	}
+	if err != nil {
+		return models.LibraryEntry{}, recordError(span, err)
+	}
+	s.syncReview(ctx, userID, gameID, true)
+	s.audit(ctx, models.AuditEntry{
+		Action:     "library.grant",
+		Resource:   "library",
+		ResourceID: entry.ID.Hex(),
+		Details: map[string]interface{}{
+			"user_id":    userID.Hex(),
+			"game_id":    gameID.Hex(),
+			"source":     entry.Source,
+			"price_paid": entry.PricePaid,
+			"currency":   entry.Currency,
+			"order_ref":  entry.OrderRef,
+		},
+	})
+	entry.Game = librarySummary(game)
+	return entry, nil
+}
+
+// LibraryRevoke sahiplik kaydını siler; kullanıcının yorumu varsa "oyuna sahip" işareti kalkar
+func (s *DefaultLibraryService) LibraryRevoke(ctx context.Context, userID, gameID primitive.ObjectID, req dto.LibraryRevokeRequest) (models.LibraryEntry, error) {
+	ctx, span := tracer.Start(ctx, "LibraryService.LibraryRevoke")
+	defer span.End()
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return models.LibraryEntry{}, ErrReasonRequired
+	}
+	entry, err := s.Repo.Revoke(ctx, userID, gameID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.LibraryEntry{}, ErrNotOwned
+	}
+	if err != nil {
+		return models.LibraryEntry{}, recordError(span, err)
+	}
+	s.syncReview(ctx, userID, gameID, false)
+	s.audit(ctx, models.AuditEntry{
+		Action:     "library.revoke",
+		Resource:   "library",
+		ResourceID: entry.ID.Hex(),
+		Reason:     reason,
+		Details: map[string]interface{}{
+			"user_id":     userID.Hex(),
+			"game_id":     gameID.Hex(),
+			"source":      entry.Source,
+			"acquired_at": entry.AcquiredAt.Format(time.RFC3339),
+		},
+	})
+	return entry, nil
+}
+
+// attachGames kayıtlara oyunların güncel başlık ve kapağını ekler; katalogdan silinmiş oyunların Game alanı boş kalır
+func (s *DefaultLibraryService) attachGames(ctx context.Context, entries []models.LibraryEntry) error {
+	ids := make([]primitive.ObjectID, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.GameID)
+	}
+	games, err := s.Games.GetByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	byID := make(map[primitive.ObjectID]models.Game, len(games))
+	for _, game := range games {
+		byID[game.ID] = game
+	}
+	for i := range entries {
+		if game, ok := byID[entries[i].GameID]; ok {
+			entries[i].Game = librarySummary(localize(ctx, game))
+		}
+	}
+	return nil
+}
+
+// syncReview sahiplik değişikliğini kullanıcının yorumuna yansıtır; hata yalnızca loglanır, yorum bir sonraki düzenlemede düzelir
+func (s *DefaultLibraryService) syncReview(ctx context.Context, userID, gameID primitive.ObjectID, owned bool) {
+	if err := s.Reviews.SetVerifiedOwner(ctx, userID, gameID, owned); err != nil {
+		s.Log.WarnContext(ctx, "yorumun sahiplik işareti güncellenemedi", "user_id", userID, "game_id", gameID, "error", err)
+	}
+}
+
+// audit denetim kaydını yazar; sahiplik zaten değiştiği için hata yalnızca loglanır
+func (s *DefaultLibraryService) audit(ctx context.Context, entry models.AuditEntry) {
+	if err := s.Audit.AuditRecord(ctx, entry); err != nil {
+		s.Log.ErrorContext(ctx, "kütüphane işlemi denetim kaydına yazılamadı", "action", entry.Action, "resource_id", entry.ResourceID, "error", err)
+	}
+}
+
+// librarySummary oyunun kütüphanede gösterilecek özetini döndürür
+func librarySummary(game models.Game) *models.LibraryGame {
+	return &models.LibraryGame{Title: game.Title, CoverImage: game.Media.CoverImage, ThumbnailURL: game.Media.ThumbnailURL, Status: game.Status}
+}
+
+func gameTitle(entry models.LibraryEntry) string {
+	if entry.Game == nil {
+		return ""
+	}
+	return entry.Game.Title
+}
+
+// paginate bellekteki bir listeye skip/limit uygular; limit sıfır veya üst sınırdan büyükse üst sınır kullanılır
+func paginate[T any](items []T, skip, limit, maxSize int64) []T {
+	if limit <= 0 || limit > maxSize {
+		limit = maxSize
+	}
+	if skip < 0 || skip >= int64(len(items)) {
+		return []T{}
+	}
+	end := skip + limit
+	if end > int64(len(items)) {
+		end = int64(len(items))
+	}
+	return items[skip:end]
+}
+
+// NewLibraryService kütüphane servisini oluşturur
+func NewLibraryService(repo repository.LibraryRepository, games repository.ProductRepository, users repository.UserRepository, reviews repository.ReviewRepository, audit AuditService, logger *slog.Logger) LibraryService {
+	return &DefaultLibraryService{Repo: repo, Games: games, Users: users, Reviews: reviews, Audit: audit, Log: logger}
+}
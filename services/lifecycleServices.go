@@ -0,0 +1,254 @@
+package services
+
+import (
+	"api-steam/dto"
+	"api-steam/models"
+	"api-steam/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrInvalidStatus             = errors.New("geçersiz durum: draft, in_review, coming_soon, early_access, active, delisted veya removed olmalıdır")
+	ErrInvalidStatusTransition   = errors.New("bu durum geçişine izin verilmiyor")
+	ErrStatusRequirements        = errors.New("oyun bu durum için gerekli alanları içermiyor")
+	ErrInvalidFieldType          = errors.New("alan değeri oyun modelindeki türle uyuşmuyor")
+	ErrStatusConflict            = errors.New("oyunun durumu bu sırada değişti, lütfen tekrar deneyin")
+	ErrStatusChangeViaTransition = errors.New("durum yalnızca POST /api/game/:id/status ile değiştirilebilir")
+)
+
+// LifecycleService oyunların yaşam döngüsü durum geçişlerini doğrular ve uygular
+type LifecycleService interface {
+	LifecycleTransition(ctx context.Context, id primitive.ObjectID, req dto.StatusTransitionRequest) (models.Game, error) //Geçişi uygular; olaylar yayınlanır ve denetim kaydı yazılır
+	LifecycleOptions(ctx context.Context, id primitive.ObjectID) (dto.StatusOptions, error)                               //Mevcut durum, geçilebilecek durumlar ve eksik alanlar
+}
+
+// DefaultLifecycleService geçişleri ProductRepository.SetStatus ile uygular; olaylar evented repository katmanından yayınlanır
+type DefaultLifecycleService struct {
+	Games repository.ProductRepository
+	Audit AuditService
+	Log   *slog.Logger
+}
+
+// LifecycleTransition oyunu istenen duruma geçirir. Geçiş mevcut durumdan izinli olmalı ve oyun yeni durumun gerektirdiği
+// alanları içermelidir; delisted ve removed için gerekçe zorunludur. Durum okunduktan sonra başka bir istekle değişmişse
+// ErrStatusConflict döner.
+func (s *DefaultLifecycleService) LifecycleTransition(ctx context.Context, id primitive.ObjectID, req dto.StatusTransitionRequest) (models.Game, error) {
+	ctx, span := tracer.Start(ctx, "LifecycleService.LifecycleTransition")
+	defer span.End()
+	req.Reason = strings.TrimSpace(req.Reason)
+	if !models.IsGameStatus(req.Status) {
+		return models.Game{}, ErrInvalidStatus
+	}
+	if (req.Status == models.GameStatusDelisted || req.Status == models.GameStatusRemoved) && req.Reason == "" {
+		return models.Game{}, ErrReasonRequired
+	}
+	game, err := s.Games.GetByID(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.Game{}, ErrGameNotFound
+	}
+	if err != nil {
+		return models.Game{}, recordError(span, err)
+	}
+	if !models.CanTransition(game.Status, req.Status) {
+		return models.Game{}, fmt.Errorf("%w: %s → %s", ErrInvalidStatusTransition, displayStatus(game.Status), req.Status)
+	}
+	if missing := missingForStatus(game, req.Status, time.Now()); len(missing) > 0 {
+		return models.Game{}, fmt.Errorf("%w: %s", ErrStatusRequirements, strings.Join(missing, ", "))
+	}
+	change := newStatusChange(ctx, game.Status, req.Status, req.Reason)
+	ok, err := s.Games.SetStatus(ctx, id, change)
+	if err != nil {
+		return models.Game{}, recordError(span, err)
+	}
+	if !ok {
+		return models.Game{}, ErrStatusConflict
+	}
+	if err := s.Audit.AuditRecord(ctx, models.AuditEntry{
+		Action:     "game.status",
+		Resource:   "game",
+		ResourceID: id.Hex(),
+		Reason:     req.Reason,
+		Details:    map[string]interface{}{"from": game.Status, "to": req.Status},
+	}); err != nil {
+		s.Log.ErrorContext(ctx, "durum geçişi denetim kaydına yazılamadı", "game_id", id, "error", err)
+	}
+	s.Log.InfoContext(ctx, "oyun durumu değişti", "game_id", id, "from", game.Status, "to", req.Status)
+	game.Status, game.IsEarlyAccess, game.StatusChangedAt = change.To, change.To == models.GameStatusEarlyAccess, change.At
+	game.StatusHistory = append(game.StatusHistory, change)
+	return game, nil
+}
+
+// LifecycleOptions oyunun mevcut durumunu, geçmişini ve geçilebilecek her durum için eksik alanları döndürür
+func (s *DefaultLifecycleService) LifecycleOptions(ctx context.Context, id primitive.ObjectID) (dto.StatusOptions, error) {
+	ctx, span := tracer.Start(ctx, "LifecycleService.LifecycleOptions")
+	defer span.End()
+	game, err := s.Games.GetByID(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return dto.StatusOptions{}, ErrGameNotFound
+	}
+	if err != nil {
+		return dto.StatusOptions{}, recordError(span, err)
+	}
+	now := time.Now()
+	options := dto.StatusOptions{Current: game.Status, ChangedAt: game.StatusChangedAt, Transitions: []dto.StatusOption{}, History: game.StatusHistory}
+	if options.History == nil {
+		options.History = []models.StatusChange{}
+	}
+	for _, status := range models.GameStatuses {
+		if !models.CanTransition(game.Status, status) {
+			continue
+		}
+		missing := missingForStatus(game, status, now)
+		if missing == nil {
+			missing = []string{}
+		}
+		options.Transitions = append(options.Transitions, dto.StatusOption{Status: status, Ready: len(missing) == 0, Missing: missing})
+	}
+	return options, nil
+}
+
+// missingForStatus oyunun status durumuna geçmesi için eksik olan alanları JSON adlarıyla döndürür. Gereksinimler
+// birikimlidir: incelemeye başlık ve açıklama, vitrine (coming_soon) ek olarak kapak, satışa (early_access, active)
+// ek olarak fiyat ve geçmiş bir çıkış tarihi gerekir. delisted ve removed için gereksinim yoktur.
+func missingForStatus(game models.Game, status string, now time.Time) []string {
+	var missing []string
+	switch status {
+	case models.GameStatusInReview, models.GameStatusComingSoon, models.GameStatusEarlyAccess, models.GameStatusActive:
+		if strings.TrimSpace(game.Title) == "" {
+			missing = append(missing, "title")
+		}
+		if strings.TrimSpace(game.Description) == "" && strings.TrimSpace(game.ShortDescription) == "" {
+			missing = append(missing, "description")
+		}
+	default:
+		return nil
+	}
+	if status == models.GameStatusInReview {
+		return missing
+	}
+	if game.Media.CoverImage == "" {
+		missing = append(missing, "media.cover_image")
+	}
+	if status == models.GameStatusComingSoon {
+		return missing
+	}
+	if game.Price.Currency == "" || game.Price.Amount < 0 {
+		missing = append(missing, "price") // ücretsiz oyunlarda amount 0 olabilir ama para birimi belirtilmelidir
+	}
+	if game.ReleaseDate.IsZero() || game.ReleaseDate.After(now) {
+		missing = append(missing, "release_date")
+	}
+	if _, rated := game.Rating.MinimumAge(); status == models.GameStatusActive && !rated {
+		missing = append(missing, "rating.pegi veya rating.esrb") // içerik filtreleri derecesi olmayan oyunları gösteremez
+	}
+	return missing
+}
+
+// checkStatusRequirements güncellenen oyunda mevcut durumun gerektirdiği ve güncellemeden önce dolu olan bir alan boşalıyorsa
+// ErrStatusRequirements döner; böylece PUT, PATCH ve taslak yayını satıştaki bir oyunun başlığını, kapağını, fiyatını ya da
+// yaş derecesini silemez. Güncellemeden önce de eksik olan alanlar (eski kayıtlar) yazmayı engellemez.
+func checkStatusRequirements(current, updated models.Game, now time.Time) error {
+	before := missingForStatus(current, current.Status, now)
+	var broken []string
+	for _, field := range missingForStatus(updated, current.Status, now) {
+		if !slices.Contains(before, field) {
+			broken = append(broken, field)
+		}
+	}
+	if len(broken) > 0 {
+		return fmt.Errorf("%w: %s durumundaki oyunda %s boş bırakılamaz", ErrStatusRequirements, current.Status, strings.Join(broken, ", "))
+	}
+	return nil
+}
+
+// statusRequirementFields missingForStatus'un baktığı alanlardır; PATCH bunlardan birine dokunmuyorsa birleştirme yapılmaz
+var statusRequirementFields = []string{"title", "description", "short_description", "media", "price", "release_date", "rating"}
+
+// patchedGame PATCH güncellemelerinden durum gereksinimlerini etkileyenleri current'a uygular ve sonucu döndürür.
+// Değer modeldeki türe çevrilemiyorsa ErrInvalidFieldType döner.
+func patchedGame(current models.Game, updates map[string]interface{}) (models.Game, error) {
+	raw, err := bson.Marshal(current)
+	if err != nil {
+		return models.Game{}, err
+	}
+	doc := bson.M{}
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return models.Game{}, err
+	}
+	for field, value := range updates {
+		root, _, _ := strings.Cut(field, ".")
+		if !slices.Contains(statusRequirementFields, root) {
+			continue
+		}
+		setPath(doc, strings.Split(field, "."), value)
+	}
+	if raw, err = bson.Marshal(doc); err != nil {
+		return models.Game{}, fmt.Errorf("%w: %v", ErrInvalidFieldType, err)
+	}
+	var game models.Game
+	if err := bson.Unmarshal(raw, &game); err != nil {
+		return models.Game{}, fmt.Errorf("%w: %v", ErrInvalidFieldType, err)
+	}
+	return game, nil
+}
+
+// setPath "media.cover_image" gibi noktalı bir yolu $set'in yaptığı gibi belgeye yazar; ara belgeler yoksa oluşturulur
+func setPath(doc bson.M, path []string, value interface{}) {
+	if len(path) == 1 {
+		doc[path[0]] = value
+		return
+	}
+	child, ok := doc[path[0]].(bson.M)
+	if !ok {
+		child = bson.M{}
+		if d, isD := doc[path[0]].(bson.D); isD {
+			child = d.Map()
+		}
+		doc[path[0]] = child
+	}
+	setPath(child, path[1:], value)
+}
+
+// applyInitialStatus yeni eklenen oyunun durumunu doğrular (boşsa draft) ve ilk geçiş kaydını oluşturur
+func applyInitialStatus(ctx context.Context, game *models.Game) error {
+	if game.Status == "" {
+		game.Status = models.GameStatusDraft
+	}
+	if !models.IsGameStatus(game.Status) {
+		return ErrInvalidStatus
+	}
+	change := newStatusChange(ctx, "", game.Status, "")
+	if missing := missingForStatus(*game, game.Status, change.At); len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrStatusRequirements, strings.Join(missing, ", "))
+	}
+	game.IsEarlyAccess = game.Status == models.GameStatusEarlyAccess
+	game.StatusChangedAt = change.At
+	game.StatusHistory = []models.StatusChange{change}
+	return nil
+}
+
+// newStatusChange geçiş kaydını zaman ve isteği yapan kimlikle oluşturur
+func newStatusChange(ctx context.Context, from, to, reason string) models.StatusChange {
+	return models.StatusChange{From: from, To: to, At: time.Now(), By: currentActor(ctx), Reason: reason}
+}
+
+func displayStatus(status string) string {
+	if status == "" {
+		return "(boş)"
+	}
+	return status
+}
+
+// NewLifecycleService yaşam döngüsü servisini oluşturur
+func NewLifecycleService(games repository.ProductRepository, audit AuditService, logger *slog.Logger) LifecycleService {
+	return &DefaultLifecycleService{Games: games, Audit: audit, Log: logger}
+}
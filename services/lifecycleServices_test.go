@@ -0,0 +1,113 @@
+package services
+
+import (
+	"api-steam/models"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// activeGame aktif durumun bütün gereksinimlerini karşılayan bir oyundur
+func activeGame() models.Game {
+	game := models.Game{Title: "Oyun", Description: "Açıklama", Status: models.GameStatusActive}
+	game.Media.CoverImage = "https://cdn.example.com/cover.jpg"
+	game.Price = models.Price{Amount: 10, Currency: "TRY"}
+	game.ReleaseDate = testNow.AddDate(-1, 0, 0)
+	game.Rating.PEGI = "16"
+	return game
+}
+
+func TestMissingForStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		edit   func(*models.Game)
+		status string
+		want   []string
+	}{
+		{"aktif eksiksiz", func(*models.Game) {}, models.GameStatusActive, nil},
+		{"taslakta gereksinim yok", func(g *models.Game) { *g = models.Game{} }, models.GameStatusDraft, nil},
+		{"kaldırılmışta gereksinim yok", func(g *models.Game) { *g = models.Game{} }, models.GameStatusRemoved, nil},
+		{"inceleme başlık ve açıklama", func(g *models.Game) { *g = models.Game{} }, models.GameStatusInReview, []string{"title", "description"}},
+		{"kısa açıklama yeterli", func(g *models.Game) { g.Description, g.ShortDescription = "", "kısa" }, models.GameStatusInReview, nil},
+		{"vitrinde kapak", func(g *models.Game) { g.Media.CoverImage = "" }, models.GameStatusComingSoon, []string{"media.cover_image"}},
+		{"vitrinde gelecek tarih serbest", func(g *models.Game) { g.ReleaseDate = testNow.AddDate(1, 0, 0) }, models.GameStatusComingSoon, nil},
+		{"satışta gelecek tarih", func(g *models.Game) { g.ReleaseDate = testNow.AddDate(1, 0, 0) }, models.GameStatusEarlyAccess, []string{"release_date"}},
+		{"satışta para birimi", func(g *models.Game) { g.Price.Currency = "" }, models.GameStatusEarlyAccess, []string{"price"}},
+		{"ücretsiz oyun", func(g *models.Game) { g.Price.Amount = 0 }, models.GameStatusActive, nil},
+		{"erken erişimde derece gerekmez", func(g *models.Game) { g.Rating.PEGI = "" }, models.GameStatusEarlyAccess, nil},
+		{"aktifte derece", func(g *models.Game) { g.Rating.PEGI = "" }, models.GameStatusActive, []string{"rating.pegi veya rating.esrb"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := activeGame()
+			tt.edit(&game)
+			if got := missingForStatus(game, tt.status, testNow); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("eksik = %v, beklenen %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckStatusRequirements(t *testing.T) {
+	tests := []struct {
+		name    string
+		current func(*models.Game)
+		updated func(*models.Game)
+		wantErr bool
+	}{
+		{"değişiklik yok", nil, nil, false},
+		{"başlık değişir", nil, func(g *models.Game) { g.Title = "Yeni" }, false},
+		{"derece silinir", nil, func(g *models.Game) { g.Rating.PEGI = "" }, true},
+		{"başlık silinir", nil, func(g *models.Game) { g.Title = " " }, true},
+		{"kapak silinir", nil, func(g *models.Game) { g.Media.CoverImage = "" }, true},
+		{"fiyat silinir", nil, func(g *models.Game) { g.Price = models.Price{} }, true},
+		{"taslakta derece silinebilir", func(g *models.Game) { g.Status = models.GameStatusDraft }, func(g *models.Game) { g.Rating.PEGI = "" }, false},
+		{"önceden eksik alan engellemez", func(g *models.Game) { g.Rating.PEGI = "" }, func(g *models.Game) { g.Title = "Yeni" }, false},
+		{"durumu olmayan eski kayıt", func(g *models.Game) { g.Status = "" }, func(g *models.Game) { g.Title = "" }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := activeGame()
+			if tt.current != nil {
+				tt.current(&current)
+			}
+			updated := current
+			if tt.updated != nil {
+				tt.updated(&updated)
+			}
+			err := checkStatusRequirements(current, updated, testNow)
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrStatusRequirements)) {
+				t.Errorf("hata = %v, hata bekleniyor mu: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPatchedGame(t *testing.T) {
+	tests := []struct {
+		name    string
+		updates map[string]interface{}
+		check   func(models.Game) bool
+		wantErr error
+	}{
+		{"nokta yolu", map[string]interface{}{"media.cover_image": ""}, func(g models.Game) bool { return g.Media.CoverImage == "" && g.Title == "Oyun" }, nil},
+		{"nesne bütünüyle değişir", map[string]interface{}{"price": map[string]interface{}{"amount": 5.0}}, func(g models.Game) bool { return g.Price.Amount == 5 && g.Price.Currency == "" }, nil},
+		{"null alan", map[string]interface{}{"rating.pegi": nil}, func(g models.Game) bool { return g.Rating.PEGI == "" }, nil},
+		{"ilgisiz alan yok sayılır", map[string]interface{}{"developer": 5.0}, func(g models.Game) bool { return g.Title == "Oyun" }, nil},
+		{"yanlış tür", map[string]interface{}{"title": 5.0}, nil, ErrInvalidFieldType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := patchedGame(activeGame(), tt.updates)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("hata = %v, beklenen %v", err, tt.wantErr)
+			}
+			if tt.check != nil && !tt.check(got) {
+				t.Errorf("birleştirilmiş oyun beklenen gibi değil: %+v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"api-steam/models"
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locales mağazanın desteklediği dillerdir. Oyunların kendi metin alanları Default dilindedir; diğer diller oyunun
+// translations alanında tutulur.
+type Locales struct {
+	Default   string
+	Supported []string // Default dahil, yapılandırmadaki sırayla
+}
+
+// NewLocales dil kodlarını normalleştirir; varsayılan dil desteklenenler arasında yoksa başa eklenir
+func NewLocales(defaultLocale string, supported []string) Locales {
+	locales := Locales{Default: normalizeLocale(defaultLocale)}
+	seen := map[string]bool{locales.Default: true}
+	locales.Supported = []string{locales.Default}
+	for _, locale := range supported {
+		if locale = normalizeLocale(locale); locale != "" && !seen[locale] {
+			seen[locale] = true
+			locales.Supported = append(locales.Supported, locale)
+		}
+	}
+	return locales
+}
+
+// Supports dilin desteklenip desteklenmediğini döndürür
+func (l Locales) Supports(locale string) bool {
+	for _, supported := range l.Supported {
+		if supported == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// Translated varsayılan dil dışındaki, çevirisi tutulan dilleri döndürür
+func (l Locales) Translated() []string {
+	translated := make([]string, 0, len(l.Supported))
+	for _, locale := range l.Supported {
+		if locale != l.Default {
+			translated = append(translated, locale)
+		}
+	}
+	return translated
+}
+
+// Negotiate isteğin dilini seçer: ?lang= desteklenen bir dilse o, değilse Accept-Language'daki en yüksek öncelikli
+// desteklenen dil, o da yoksa varsayılan dil. "en-US" gibi bölgeli kodlar desteklenmiyorsa ana dile ("en") düşer.
+func (l Locales) Negotiate(lang, acceptLanguage string) string {
+	if locale, ok := l.match(lang); ok {
+		return locale
+	}
+	type candidate struct {
+		tag string
+		q   float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if tag = strings.TrimSpace(tag); tag != "" && q > 0 {
+			candidates = append(candidates, candidate{tag: tag, q: q})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	for _, c := range candidates {
+		if c.tag == "*" {
+			return l.Default
+		}
+		if locale, ok := l.match(c.tag); ok {
+			return locale
+		}
+	}
+	return l.Default
+}
+
+// match dil kodunu desteklenen bir dile eşler; önce tam kod, sonra ana dil denenir
+func (l Locales) match(tag string) (string, bool) {
+	tag = normalizeLocale(tag)
+	if tag == "" {
+		return "", false
+	}
+	if l.Supports(tag) {
+		return tag, true
+	}
+	if primary, _, ok := strings.Cut(tag, "-"); ok && l.Supports(primary) {
+		return primary, true
+	}
+	return "", false
+}
+
+// normalizeLocale dil kodunu küçük harfe ve tireli biçime ("pt_BR" → "pt-br") çevirir
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+type localeKey struct{}
+
+// WithLocale isteğin dilini context'e ekler
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// Locale isteğin dilini döndürür; dil seçilmemişse (editör ve arka plan işlemleri) ok=false döner
+func Locale(ctx context.Context) (locale string, ok bool) {
+	locale, ok = ctx.Value(localeKey{}).(string)
+	return locale, ok
+}
+
+// localize oyunun metin alanlarını isteğin dilindeki çeviriyle değiştirir; çevrilmemiş alanlar varsayılan dilde kalır.
+// Dil seçilmiş isteklerde çeviri listesi yanıttan çıkarılır, dil seçilmemişse oyun olduğu gibi döner.
+func localize(ctx context.Context, game models.Game) models.Game {
+	locale, ok := Locale(ctx)
+	if !ok {
+		return game
+	}
+	if t, found := game.TranslationFor(locale); found {
+		game.Title = fallback(t.Title, game.Title)
+		game.Description = fallback(t.Description, game.Description)
+		game.ShortDescription = fallback(t.ShortDescription, game.ShortDescription)
+	}
+	game.Translations = nil
+	return game
+}
+
+// localizeAll listedeki oyunları isteğin diline çevirir
+func localizeAll(ctx context.Context, games []models.Game) []models.Game {
+	if _, ok := Locale(ctx); !ok {
+		return games
+	}
+	for i := range games {
+		games[i] = localize(ctx, games[i])
+	}
+	return games
+}
+
+// localizeSummary özetin başlığını isteğin dilindeki başlıkla değiştirir
+func localizeSummary(ctx context.Context, summary models.RelatedGame) models.RelatedGame {
+	if locale, ok := Locale(ctx); ok {
+		summary.Title = fallback(summary.Titles[locale], summary.Title)
+	}
+	return summary
+}
+
+func fallback(value, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"api-steam/dto"
+	"api-steam/models"
+	"api-steam/moderation"
+	"api-steam/repository"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrUnknownModerationAction = errors.New("bilinmeyen moderasyon işlemi")
+	ErrReasonRequired          = errors.New("bu işlem için gerekçe gereklidir")
+	ErrInvalidReportReason     = errors.New("geçersiz şikayet nedeni")
+	ErrAlreadyReported         = errors.New("bu yorumu zaten şikayet ettiniz")
+)
+
+// Moderatör işlemleri ve karşılık gelen yorum durumları
+var moderationActions = map[string]string{
+	"approve": models.ReviewPublished,
+	"reject":  models.ReviewRejected,
+	"hide":    models.ReviewHidden,
+}
+
+// ModerationService moderasyon kuyruğunu, moderatör kararlarını ve kullanıcı şikayetlerini yönetir
+type ModerationService interface {
+	ModerationQueue(ctx context.Context, status string, skip, limit int64) ([]models.Review, error)                   //Onay bekleyen (veya verilen durumdaki) yorumlar
+	ModerationDecide(ctx context.Context, reviewID primitive.ObjectID, action, reason string) (models.Review, error)  //approve, reject, hide
+	ModerationReport(ctx context.Context, reporterID, reviewID primitive.ObjectID, req dto.ReviewReportRequest) error //Kullanıcı şikayeti
+	ModerationReports(ctx context.Context, reviewID primitive.ObjectID) ([]models.ReviewReport, error)                //Yorumun şikayetleri
+}
+
+// DefaultModerationService kararları ReviewService üzerinden uygular (Rating tutarlılığı orada sağlanır) ve denetim kaydına yazar
+type DefaultModerationService struct {
+	Repo            repository.ReviewRepository
+	Reviews         ReviewService
+	Audit           AuditService
+	Log             *slog.Logger
+	ReportThreshold int // Bu kadar şikayet alan yayındaki yorum otomatik olarak kuyruğa alınır
+}
+
+// ModerationQueue verilen durumdaki yorumları, en çok şikayet alan ve en eski olan başta olacak şekilde döndürür
+func (s *DefaultModerationService) ModerationQueue(ctx context.Context, status string, skip, limit int64) ([]models.Review, error) {
+	ctx, span := tracer.Start(ctx, "ModerationService.ModerationQueue")
+	defer span.End()
+	switch status {
+	case "":
+		status = models.ReviewPending
+	case models.ReviewPending, models.ReviewHidden, models.ReviewRejected:
+	default:
+		return nil, ErrInvalidReviewStatus
+	}
+	if limit <= 0 || limit > maxReviewPageSize {
+		limit = maxReviewPageSize
+	}
+	reviews, err := s.Repo.ListForModeration(ctx, []string{status}, skip, limit)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	return reviews, nil
+}
+
+// ModerationDecide moderatör kararını uygular, yorumun açık şikayetlerini kapatır ve işlemi denetim kaydına yazar
+func (s *DefaultModerationService) ModerationDecide(ctx context.Context, reviewID primitive.ObjectID, action, reason string) (models.Review, error) {
+	ctx, span := tracer.Start(ctx, "ModerationService.ModerationDecide")
+	defer span.End()
+	status, ok := moderationActions[action]
+	if !ok {
+		return models.Review{}, ErrUnknownModerationAction
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" && action != "approve" {
+		return models.Review{}, ErrReasonRequired
+	}
+	before, after, err := s.Reviews.ReviewModerate(ctx, reviewID, status, reason)
+	if err != nil {
+		return models.Review{}, recordError(span, err)
+	}
+	if err := s.Repo.ResolveReports(ctx, reviewID); err != nil {
+		s.Log.WarnContext(ctx, "şikayetler kapatılamadı", "review_id", reviewID, "error", err)
+	}
+	s.audit(ctx, models.AuditEntry{
+		Action:     "review." + action,
+		Resource:   "review",
+		ResourceID: reviewID.Hex(),
+		Reason:     reason,
+		Details: map[string]interface{}{
+			"game_id":      after.GameID.Hex(),
+			"author_id":    after.UserID.Hex(),
+			"from":         before.Status,
+			"to":           after.Status,
+			"flags":        before.Flags,
+			"report_count": before.ReportCount,
+		},
+	})
+	return after, nil
+}
+
+// ModerationReport kullanıcının şikayetini kaydeder; şikayet sayısı eşiğe ulaşırsa yorum kuyruğa alınır ve Rating'den çıkar
+func (s *DefaultModerationService) ModerationReport(ctx context.Context, reporterID, reviewID primitive.ObjectID, req dto.ReviewReportRequest) error {
+	ctx, span := tracer.Start(ctx, "ModerationService.ModerationReport")
+	defer span.End()
+	if !models.ReportReasons[req.Reason] {
+		return ErrInvalidReportReason
+	}
+	review, err := s.Repo.GetByID(ctx, reviewID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrReviewNotFound
+	}
+	if err != nil {
+		return recordError(span, err)
+	}
+	if review.UserID == reporterID {
+		return ErrOwnReview
+	}
+	review, err = s.Repo.InsertReport(ctx, models.ReviewReport{ReviewID: reviewID, ReporterID: reporterID, Reason: req.Reason, Comment: strings.TrimSpace(req.Comment)})
+	if errors.Is(err, repository.ErrDuplicate) {
+		return ErrAlreadyReported
+	}
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrReviewNotFound
+	}
+	if err != nil {
+		return recordError(span, err)
+	}
+	if review.Status != models.ReviewPublished || review.ReportCount < s.ReportThreshold {
+		return nil
+	}
+	if _, err := s.Reviews.ReviewFlag(ctx, reviewID, moderation.FlagReports); err != nil {
+		return recordError(span, err)
+	}
+	s.audit(ctx, models.AuditEntry{
+		ActorID:    ActorSystem,
+		ActorKind:  ActorSystem,
+		Action:     "review.auto_queue",
+		Resource:   "review",
+		ResourceID: reviewID.Hex(),
+		Reason:     "şikayet eşiği aşıldı",
+		Details:    map[string]interface{}{"game_id": review.GameID.Hex(), "report_count": review.ReportCount, "threshold": s.ReportThreshold},
+	})
+	return nil
+}
+
+// ModerationReports yorumun şikayetlerini döndürür
+func (s *DefaultModerationService) ModerationReports(ctx context.Context, reviewID primitive.ObjectID) ([]models.ReviewReport, error) {
+	ctx, span := tracer.Start(ctx, "ModerationService.ModerationReports")
+	defer span.End()
+	reports, err := s.Repo.ListReports(ctx, reviewID)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	return reports, nil
+}
+
+// audit denetim kaydını yazar; karar zaten uygulandığı için hata yalnızca loglanır
+func (s *DefaultModerationService) audit(ctx context.Context, entry models.AuditEntry) {
+	if err := s.Audit.AuditRecord(ctx, entry); err != nil {
+		s.Log.ErrorContext(ctx, "moderasyon işlemi denetim kaydına yazılamadı", "action", entry.Action, "resource_id", entry.ResourceID, "error", err)
+	}
+}
+
+// NewModerationService moderasyon servisini oluşturur
+func NewModerationService(repo repository.ReviewRepository, reviews ReviewService, audit AuditService, logger *slog.Logger, reportThreshold int) ModerationService {
+	return &DefaultModerationService{Repo: repo, Reviews: reviews, Audit: audit, Log: logger, ReportThreshold: reportThreshold}
+}
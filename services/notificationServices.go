@@ -0,0 +1,163 @@
+package services
+
+import (
+	"api-steam/dto"
+	"api-steam/events"
+	"api-steam/models"
+	"api-steam/notify"
+	"api-steam/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrUnknownChannel       = errors.New("bilinmeyen bildirim kanalı")
+	ErrInvalidWebhookURL    = notify.ErrInvalidWebhookURL
+	ErrBlockedWebhookURL    = notify.ErrBlockedWebhookAddress
+	ErrNotificationNotFound = errors.New("bildirim bulunamadı")
+)
+
+// NotificationService katalog olaylarını istek listesi sahiplerine bildirir ve gelen kutusunu yönetir
+type NotificationService interface {
+	HandleGameEvent(ctx context.Context, event events.Event)                                                                                             //Olayı ilgili kullanıcılara dağıtır
+	NotificationList(ctx context.Context, userID primitive.ObjectID, unreadOnly bool) ([]models.Notification, error)                                     //Gelen kutusu
+	NotificationMarkRead(ctx context.Context, userID, id primitive.ObjectID) error                                                                       //Bildirimi okundu işaretler
+	NotificationGetSettings(ctx context.Context, userID primitive.ObjectID) (models.NotificationSettings, error)                                         //Kanal ayarları
+	NotificationUpdateSettings(ctx context.Context, userID primitive.ObjectID, req dto.NotificationSettingsRequest) (models.NotificationSettings, error) //Kanal ayarlarını değiştirir
+}
+
+// inboxPageSize gelen kutusunda döndürülen en fazla bildirim sayısıdır
+const inboxPageSize = 100
+
+// DefaultNotificationService bildirimleri notify.Dispatcher ile kullanıcının kanallarından teslim eder
+type DefaultNotificationService struct {
+	Wishlists     repository.WishlistRepository
+	Users         repository.UserRepository
+	Notifications repository.NotificationRepository
+	Dispatcher    *notify.Dispatcher
+	Log           *slog.Logger
+	BaseURL       string
+}
+
+// HandleGameEvent olayın oyununu istek listesinde tutan ve bu olay için bildirim isteyen kullanıcılara bildirim gönderir
+func (s *DefaultNotificationService) HandleGameEvent(ctx context.Context, event events.Event) {
+	ctx, span := tracer.Start(ctx, "NotificationService.HandleGameEvent")
+	defer span.End()
+	items, err := s.Wishlists.ListByGame(ctx, event.GameID)
+	if err != nil {
+		s.Log.ErrorContext(ctx, "istek listesi sahipleri getirilemedi", "game_id", event.GameID, "error", recordError(span, err))
+		return
+	}
+	notification := s.render(event)
+	sent := 0
+	for _, item := range items {
+		if (event.Type == events.GameOnSale && !item.NotifyOnSale) || (event.Type == events.GameReleased && !item.NotifyOnRelease) {
+			continue
+		}
+		user, err := s.Users.GetByID(ctx, item.UserID)
+		if err != nil {
+			s.Log.WarnContext(ctx, "bildirim alıcısı bulunamadı", "user_id", item.UserID, "error", err)
+			continue
+		}
+		n := notification
+		n.UserID = user.ID
+		s.Dispatcher.Dispatch(ctx, user, n)
+		sent++
+	}
+	s.Log.InfoContext(ctx, "istek listesi bildirimleri gönderildi", "type", event.Type, "game_id", event.GameID, "recipients", sent)
+}
+
+// render olaydan kullanıcıya gösterilecek bildirimi oluşturur
+func (s *DefaultNotificationService) render(event events.Event) models.Notification {
+	game := event.After
+	link := fmt.Sprintf("%s/games/%s", s.BaseURL, game.ID.Hex())
+	n := models.Notification{Type: event.Type, GameID: game.ID, CreatedAt: event.At}
+	switch event.Type {
+	case events.GameOnSale:
+		n.Title = fmt.Sprintf("%s indirimde!", game.Title)
+		n.Body = fmt.Sprintf("İstek listendeki %s şimdi %%%.0f indirimle %.2f %s.\n\n%s", game.Title, game.Price.Discount*100, game.Price.Amount*(1-game.Price.Discount), game.Price.Currency, link)
+	case events.GameReleased:
+		n.Title = fmt.Sprintf("%s çıktı!", game.Title)
+		n.Body = fmt.Sprintf("İstek listendeki %s artık satışta.\n\n%s", game.Title, link)
+	}
+	return n
+}
+
+// NotificationList kullanıcının gelen kutusunu döndürür
+func (s *DefaultNotificationService) NotificationList(ctx context.Context, userID primitive.ObjectID, unreadOnly bool) ([]models.Notification, error) {
+	ctx, span := tracer.Start(ctx, "NotificationService.NotificationList")
+	defer span.End()
+	notifications, err := s.Notifications.ListByUser(ctx, userID, unreadOnly, inboxPageSize)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	return notifications, nil
+}
+
+// NotificationMarkRead kullanıcının bildirimini okundu olarak işaretler
+func (s *DefaultNotificationService) NotificationMarkRead(ctx context.Context, userID, id primitive.ObjectID) error {
+	ctx, span := tracer.Start(ctx, "NotificationService.NotificationMarkRead")
+	defer span.End()
+	err := s.Notifications.MarkRead(ctx, userID, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrNotificationNotFound
+	}
+	return recordError(span, err)
+}
+
+// NotificationGetSettings kullanıcının kanal ayarlarını döndürür; ayar yoksa varsayılanları döner
+func (s *DefaultNotificationService) NotificationGetSettings(ctx context.Context, userID primitive.ObjectID) (models.NotificationSettings, error) {
+	ctx, span := tracer.Start(ctx, "NotificationService.NotificationGetSettings")
+	defer span.End()
+	user, err := s.Users.GetByID(ctx, userID)
+	if err != nil {
+		return models.NotificationSettings{}, recordError(span, err)
+	}
+	if user.Notifications == nil {
+		return models.NotificationSettings{Channels: notify.DefaultChannels}, nil
+	}
+	return *user.Notifications, nil
+}
+
+// NotificationUpdateSettings kanal ayarlarını doğrular ve kaydeder
+func (s *DefaultNotificationService) NotificationUpdateSettings(ctx context.Context, userID primitive.ObjectID, req dto.NotificationSettingsRequest) (models.NotificationSettings, error) {
+	ctx, span := tracer.Start(ctx, "NotificationService.NotificationUpdateSettings")
+	defer span.End()
+	settings := models.NotificationSettings{Channels: []string{}, WebhookURL: strings.TrimSpace(req.WebhookURL)}
+	seen := map[string]bool{}
+	for _, name := range req.Channels {
+		if !s.Dispatcher.Supports(name) {
+			return models.NotificationSettings{}, fmt.Errorf("%w: %s", ErrUnknownChannel, name)
+		}
+		if !seen[name] {
+			seen[name] = true
+			settings.Channels = append(settings.Channels, name)
+		}
+	}
+	if seen[notify.ChannelWebhook] || settings.WebhookURL != "" {
+		if err := notify.CheckWebhookURL(ctx, settings.WebhookURL); err != nil {
+			return models.NotificationSettings{}, err
+		}
+	}
+	if err := s.Users.UpdateNotificationSettings(ctx, userID, settings); err != nil {
+		return models.NotificationSettings{}, recordError(span, err)
+	}
+	return settings, nil
+}
+
+// NewNotificationService bildirim servisini oluşturur
+func NewNotificationService(wishlists repository.WishlistRepository, users repository.UserRepository, notifications repository.NotificationRepository, dispatcher *notify.Dispatcher, logger *slog.Logger, baseURL string) NotificationService {
+	return &DefaultNotificationService{
+		Wishlists:     wishlists,
+		Users:         users,
+		Notifications: notifications,
+		Dispatcher:    dispatcher,
+		Log:           logger,
+		BaseURL:       baseURL,
+	}
+}
@@ -0,0 +1,238 @@
+package services
+
+import (
+	"api-steam/dto"
+	"api-steam/models"
+	"api-steam/repository"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrEmptySessionBatch    = errors.New("en az bir oturum gönderilmelidir")
+	ErrSessionBatchTooLarge = errors.New("tek istekte en fazla 500 oturum gönderilebilir")
+	ErrNoPlaytime           = errors.New("bu oyun için oynama kaydı yok")
+)
+
+const (
+	maxSessionBatch      = 500
+	maxPlaytimePageSize  = 100
+	recentSessionsLimit  = 20
+	sessionClockSkew     = 5 * time.Minute // İstemci saatinin ileride olmasına bu kadar tolerans gösterilir
+	playtimeStatsBatches = 500             // Oyun istatistikleri bu kadar oyunda bir toplu yazılır
+)
+
+// PlaytimeService oynama oturumlarını alır, kullanıcı istatistiklerini döner ve oyunların oynama sürelerini hesaplar
+type PlaytimeService interface {
+	PlaytimeIngest(ctx context.Context, req dto.PlaySessionBatchRequest) (dto.PlaytimeIngestResult, error)                        //Oturumları kaydeder ve kullanıcı toplamlarını artırır
+	PlaytimeUserStats(ctx context.Context, userID primitive.ObjectID, sort string, skip, limit int64) ([]models.PlayStats, error) //Kullanıcının oyun başına toplamları
+	PlaytimeUserGame(ctx context.Context, userID, gameID primitive.ObjectID) (models.PlayStats, []models.PlaySession, error)      //Tek oyundaki toplam ve son oturumlar
+	PlaytimeRecompute(ctx context.Context) (int, error)                                                                           //Oyunların ortalama/medyan sürelerini yeniden hesaplar
+}
+
+// DefaultPlaytimeService oturumları PlaytimeRepository'de tutar; oyun başına hesaplanan süreleri ProductRepository üzerinden yazar
+type DefaultPlaytimeService struct {
+	Repo       repository.PlaytimeRepository
+	Games      repository.ProductRepository
+	Log        *slog.Logger
+	MaxSession time.Duration // Bundan uzun oturumlar (ör. açık bırakılmış istemci) reddedilir
+}
+
+// PlaytimeIngest oturumları tek tek doğrular ve kaydeder. Geçersiz olanlar diğerlerini engellemez, sonuçta nedeniyle bildirilir.
+// Aynı oturum tekrar gönderilirse toplamlara ikinci kez eklenmez.
+func (s *DefaultPlaytimeService) PlaytimeIngest(ctx context.Context, req dto.PlaySessionBatchRequest) (dto.PlaytimeIngestResult, error) {
+	ctx, span := tracer.Start(ctx, "PlaytimeService.PlaytimeIngest")
+	defer span.End()
+	result := dto.PlaytimeIngestResult{Rejected: []dto.PlaySessionRejection{}}
+	switch {
+	case len(req.Sessions) == 0:
+		return result, ErrEmptySessionBatch
+	case len(req.Sessions) > maxSessionBatch:
+		return result, ErrSessionBatchTooLarge
+	}
+	known, err := s.knownGames(ctx, req.Sessions)
+	if err != nil {
+		return result, recordError(span, err)
+	}
+	now := time.Now()
+	for i, r := range req.Sessions {
+		session, reason := s.parseSession(r, known, now)
+		if reason != "" {
+			result.Rejected = append(result.Rejected, dto.PlaySessionRejection{Index: i, Error: reason})
+			continue
+		}
+		session, err := s.Repo.InsertSession(ctx, session)
+		if errors.Is(err, repository.ErrDuplicate) {
+			result.Duplicates++
+			continue
+		}
+		if err != nil {
+			return result, recordError(span, err)
+		}
+		if err := s.Repo.AddToStats(ctx, session); err != nil {
+			return result, recordError(span, err)
+		}
+		result.Accepted++
+	}
+	s.Log.InfoContext(ctx, "oynama oturumları alındı", "accepted", result.Accepted, "duplicates", result.Duplicates, "rejected", len(result.Rejected))
+	return result, nil
+}
+
+// PlaytimeUserStats kullanıcının oyun başına toplamlarını oyunların güncel başlık ve kapağıyla döndürür
+func (s *DefaultPlaytimeService) PlaytimeUserStats(ctx context.Context, userID primitive.ObjectID, sort string, skip, limit int64) ([]models.PlayStats, error) {
+	ctx, span := tracer.Start(ctx, "PlaytimeService.PlaytimeUserStats")
+	defer span.End()
+	if limit <= 0 || limit > maxPlaytimePageSize {
+		limit = maxPlaytimePageSize
+	}
+	stats, err := s.Repo.ListStats(ctx, userID, sort, skip, limit)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	ids := make([]primitive.ObjectID, 0, len(stats))
+	for _, st := range stats {
+		ids = append(ids, st.GameID)
+	}
+	games, err := s.Games.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	byID := make(map[primitive.ObjectID]models.Game, len(games))
+	for _, game := range games {
+		byID[game.ID] = game
+	}
+	for i := range stats {
+		if game, ok := byID[stats[i].GameID]; ok {
+			stats[i].Game = librarySummary(game)
+		}
+	}
+	return stats, nil
+}
+
+// PlaytimeUserGame kullanıcının oyundaki toplamını ve son oturumlarını döndürür
+func (s *DefaultPlaytimeService) PlaytimeUserGame(ctx context.Context, userID, gameID primitive.ObjectID) (models.PlayStats, []models.PlaySession, error) {
+	ctx, span := tracer.Start(ctx, "PlaytimeService.PlaytimeUserGame")
+	defer span.End()
+	stats, err := s.Repo.GetStats(ctx, userID, gameID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.PlayStats{}, nil, ErrNoPlaytime
+	}
+	if err != nil {
+		return models.PlayStats{}, nil, recordError(span, err)
+	}
+	if game, err := s.Games.GetByID(ctx, gameID); err == nil {
+		stats.Game = librarySummary(game)
+	}
+	sessions, err := s.Repo.ListSessions(ctx, userID, gameID, recentSessionsLimit)
+	if err != nil {
+		return models.PlayStats{}, nil, recordError(span, err)
+	}
+	return stats, sessions, nil
+}
+
+// PlaytimeRecompute her oyunun oyuncu toplamlarından ortalama ve medyan süreyi hesaplar, TotalPlayTime'ı ortalamayla günceller.
+// Hesaplanan oyun sayısını döndürür.
+func (s *DefaultPlaytimeService) PlaytimeRecompute(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "PlaytimeService.PlaytimeRecompute")
+	defer span.End()
+	now := time.Now()
+	pending := map[primitive.ObjectID]models.PlaytimeStats{}
+	games := 0
+	err := s.Repo.ForEachGame(ctx, func(gameID primitive.ObjectID, totals []int64) error {
+		pending[gameID] = playtimeStats(totals, now)
+		games++
+		if len(pending) < playtimeStatsBatches {
+			return nil
+		}
+		err := s.Games.SetPlaytimeStats(ctx, pending)
+		pending = map[primitive.ObjectID]models.PlaytimeStats{}
+		return err
+	})
+	if err == nil {
+		err = s.Games.SetPlaytimeStats(ctx, pending)
+	}
+	if err != nil {
+		return 0, recordError(span, err)
+	}
+	s.Log.InfoContext(ctx, "oyun oynama süreleri yeniden hesaplandı", "games", games, "duration", time.Since(now).String())
+	return games, nil
+}
+
+// parseSession isteği doğrular; geçersizse nedenini döndürür
+func (s *DefaultPlaytimeService) parseSession(r dto.PlaySessionRequest, known map[primitive.ObjectID]bool, now time.Time) (models.PlaySession, string) {
+	userID, err := primitive.ObjectIDFromHex(r.UserID)
+	if err != nil {
+		return models.PlaySession{}, "geçersiz user_id"
+	}
+	gameID, err := primitive.ObjectIDFromHex(r.GameID)
+	if err != nil || !known[gameID] {
+		return models.PlaySession{}, ErrGameNotFound.Error()
+	}
+	duration := r.EndedAt.Sub(r.StartedAt)
+	switch {
+	case r.StartedAt.IsZero() || r.EndedAt.IsZero():
+		return models.PlaySession{}, "started_at ve ended_at zorunludur"
+	case duration <= 0:
+		return models.PlaySession{}, "ended_at started_at'ten sonra olmalıdır"
+	case duration > s.MaxSession:
+		return models.PlaySession{}, "oturum en fazla " + s.MaxSession.String() + " sürebilir"
+	case r.EndedAt.After(now.Add(sessionClockSkew)):
+		return models.PlaySession{}, "ended_at gelecekte olamaz"
+	}
+	return models.PlaySession{
+		UserID:          userID,
+		GameID:          gameID,
+		StartedAt:       r.StartedAt.UTC(),
+		EndedAt:         r.EndedAt.UTC(),
+		DurationSeconds: int64(duration / time.Second),
+	}, ""
+}
+
+// knownGames istekteki oyunlardan katalogda bulunanları tek sorguda döndürür
+func (s *DefaultPlaytimeService) knownGames(ctx context.Context, sessions []dto.PlaySessionRequest) (map[primitive.ObjectID]bool, error) {
+	seen := map[primitive.ObjectID]bool{}
+	var ids []primitive.ObjectID
+	for _, r := range sessions {
+		if id, err := primitive.ObjectIDFromHex(r.GameID); err == nil && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	games, err := s.Games.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[primitive.ObjectID]bool, len(games))
+	for _, game := range games {
+		known[game.ID] = true
+	}
+	return known, nil
+}
+
+// playtimeStats artan sırada verilen oyuncu toplamlarından (saniye) ortalama ve medyanı dakika olarak hesaplar
+func playtimeStats(totals []int64, at time.Time) models.PlaytimeStats {
+	var sum int64
+	for _, t := range totals {
+		sum += t
+	}
+	n := len(totals)
+	median := totals[n/2]
+	if n%2 == 0 {
+		median = (totals[n/2-1] + totals[n/2]) / 2
+	}
+	return models.PlaytimeStats{
+		AverageMinutes: int(sum / int64(n) / 60),
+		MedianMinutes:  int(median / 60),
+		Players:        n,
+		ComputedAt:     at,
+	}
+}
+
+// NewPlaytimeService oynama süresi servisini oluşturur
+func NewPlaytimeService(repo repository.PlaytimeRepository, games repository.ProductRepository, logger *slog.Logger, maxSession time.Duration) PlaytimeService {
+	return &DefaultPlaytimeService{Repo: repo, Games: games, Log: logger, MaxSession: maxSession}
+}
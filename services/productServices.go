@@ -2,142 +2,934 @@ package services
 
 import (
 	"api-steam/dto"
+	"api-steam/hardware"
 	"api-steam/models"
 	"api-steam/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strings"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+var (
+	ErrInvalidGameRelation = errors.New("geçersiz oyun ilişkisi")
+	ErrGameHasDependents   = errors.New("oyuna bağlı DLC veya sürümler var; ?dependents=cascade ile birlikte silin veya ?dependents=detach ile ayırın")
+	ErrInvalidDeletePolicy = errors.New("dependents yalnızca cascade veya detach olabilir")
+	ErrInvalidReleaseDate  = models.ErrInvalidReleaseDate
+	ErrGameChanged         = errors.New("oyun taslak oluşturulduktan sonra değişti")
+	ErrInvalidRating       = errors.New("rating alanı bir nesne olmalıdır; tek bir alanı değiştirmek için rating.pegi gibi yollar kullanın")
+)
+
+// Ana oyun silinirken ona bağlı DLC ve sürümlere uygulanacak işlem
+const (
+	DependentsCascade = "cascade" // DLC ve sürümler de silinir
+	DependentsDetach  = "detach"  // DLC ve sürümler bağımsız ana oyuna dönüştürülür
+)
+
 // ProductService ürün servisi için arayüz tanımlar bunuda repostroy katmanından verialarak yapar  ProductRepository den çekerek işlemi servies->Handeler a taşımak için kulanırız katmanına taşır
 type ProductService interface {
-	ProductInsert(product models.Game) (*dto.GameDTO, error)                          //veri eklemk
-	ProductGetAll() ([]models.Game, error)                                            //Tüm verileri getirme
-	ProductDelete(id primitive.ObjectID) (bool, error)                                //İD ye göre veri silme
-	ProductUptade(id primitive.ObjectID, game models.Game) (bool, error)              //Veriyi komple günceleme
-	ProductPatch(id primitive.ObjectID, updates map[string]interface{}) (bool, error) //Verilen bütünlüğü kadar günceleme
-	ProductGetByID(id primitive.ObjectID) (models.Game, error)                        //Id ye göre arama
-	ProductGetSorted(sortField string, order int) ([]models.Game, error)              //fiyata göre sıralama
-	ProductGetByExactName(name string) ([]models.Game, error)                         //Tam isme göre arama
-	ProductGetByPartialName(name string) ([]models.Game, error)                       //Kısmi isme göre arama
-	ProductInsertMany(games []models.Game) (*dto.GameDTO, error)
-	ProductGetByPriceRange(minPrice, maxPrice float64) ([]models.Game, error)
+	ProductInsert(ctx context.Context, product models.Game) (*dto.GameDTO, error)                          //veri eklemk
+	ProductGetAll(ctx context.Context) ([]models.Game, error)                                              //Tüm verileri getirme
+	ProductDelete(ctx context.Context, id primitive.ObjectID, dependents string) (bool, error)             //İD ye göre veri silme; bağlı DLC/sürümler dependents'e göre işlenir
+	ProductUptade(ctx context.Context, id primitive.ObjectID, game models.Game) (bool, error)              //Veriyi komple günceleme
+	ProductPatch(ctx context.Context, id primitive.ObjectID, updates map[string]interface{}) (bool, error) //Verilen bütünlüğü kadar günceleme
+	ProductGetByID(ctx context.Context, id primitive.ObjectID) (models.Game, error)                        //Id ye göre arama
+	ProductGetSorted(ctx context.Context, sortField string, order int) ([]models.Game, error)              //fiyata göre sıralama
+	ProductGetByExactName(ctx context.Context, name string) ([]models.Game, error)                         //Tam isme göre arama
+	ProductGetByPartialName(ctx context.Context, name string) ([]models.Game, error)                       //Kısmi isme göre arama
+	ProductInsertMany(ctx context.Context, games []models.Game) (*dto.GameDTO, error)
+	ProductGetByPriceRange(ctx context.Context, minPrice, maxPrice float64) ([]models.Game, error)
+	ProductStats(ctx context.Context) (map[string]int64, int64, error)                                                                      //Duruma göre oyun sayıları ve indirimdeki oyun sayısı
+	ProductRelated(ctx context.Context, game models.Game) (dlcs, editions []models.RelatedGame, err error)                                  //Oyunun ana oyununa bağlı DLC ve sürümler
+	ProductPublish(ctx context.Context, id primitive.ObjectID, game models.Game, version *time.Time) (before, after models.Game, err error) //Taslağı canlı oyuna tek yazmada uygular
+	ProductGetBySpec(ctx context.Context, name string, spec dto.HardwareSpecRequest) ([]models.Game, error)                                 //Verilen bilgisayarda çalışan oyunlar
 }
 
 // DefaultProductService Repistory katmanında tanımladığımız fonksiyonları kulanmak için nesne türetme benzeri bir işlem
 type DefaultProductService struct {
-	Repo repository.ProductRepository
+	Repo     repository.ProductRepository
+	Hardware *hardware.Table // Sistem gereksinimlerini karşılaştırılabilir hale getirmek için
+	Log      *slog.Logger
 }
 
 // ProductInsert ürün eklemek için servis işlemini gerçekleştirir
-func (s *DefaultProductService) ProductInsert(product models.Game) (*dto.GameDTO, error) {
+func (s *DefaultProductService) ProductInsert(ctx context.Context, product models.Game) (*dto.GameDTO, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.ProductInsert")
+	defer span.End()
 	var res dto.GameDTO
-	result, err := s.Repo.Insert(product)
-	if err != nil || !result {
+	if err := validateAgeRating(product.Rating); err != nil {
+		res.Status = false
+		return &res, err
+	}
+	product.Rating = withComputedRating(product.Rating, models.Rating{}) // puanlar yorumlardan hesaplanır
+	withComputedPlaytime(&product, models.Game{})                        // oynama süreleri oturumlardan hesaplanır
+	s.withRequirementSpecs(&product)
+	product.Translations = nil // çeviriler oyun eklendikten sonra çeviri uç noktalarıyla eklenir
+	if err := applyInitialStatus(ctx, &product); err != nil {
+		res.Status = false
+		return &res, err
+	}
+	if err := s.checkRelations(ctx, &product, primitive.NilObjectID); err != nil {
 		res.Status = false
 		return &res, err
 	}
+	result, err := s.Repo.Insert(ctx, product)
+	if err != nil || !result {
+		res.Status = false
+		return &res, recordError(span, err)
+	}
 	res = dto.GameDTO{Status: result}
 	return &res, nil
 }
 
 // ProductInsert birden fazla ürün eklemek için servis işlemini gerçekleştirir
-func (s *DefaultProductService) ProductInsertMany(games []models.Game) (*dto.GameDTO, error) {
+func (s *DefaultProductService) ProductInsertMany(ctx context.Context, games []models.Game) (*dto.GameDTO, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.ProductInsertMany")
+	defer span.End()
 	var res dto.GameDTO
-	result, err := s.Repo.InsertMany(games)
+	for i := range games {
+		if err := validateAgeRating(games[i].Rating); err != nil {
+			res.Status = false
+			return &res, fmt.Errorf("%d. oyun: %w", i+1, err)
+		}
+		games[i].Rating = withComputedRating(games[i].Rating, models.Rating{})
+		withComputedPlaytime(&games[i], models.Game{})
+		s.withRequirementSpecs(&games[i])
+		games[i].Translations = nil
+		if err := applyInitialStatus(ctx, &games[i]); err != nil {
+			res.Status = false
+			return &res, fmt.Errorf("%d. oyun: %w", i+1, err)
+		}
+		if err := s.checkRelations(ctx, &games[i], primitive.NilObjectID); err != nil {
+			res.Status = false
+			return &res, fmt.Errorf("%d. oyun: %w", i+1, err)
+		}
+	}
+	result, err := s.Repo.InsertMany(ctx, games)
 	if err != nil || !result {
 		res.Status = false
-		return &res, err
+		return &res, recordError(span, err)
 	}
+	s.Log.InfoContext(ctx, "toplu ekleme tamamlandı", "count", len(games))
 	res = dto.GameDTO{Status: result}
 	return &res, nil
 
 }
 
 // ürün listesini
-func (s *DefaultProductService) ProductGetAll() ([]models.Game, error) { //servis katmanında repostory de tanımladığımız GetAll ukulanan fonksiyon
-	result, err := s.Repo.GetAll()
+func (s *DefaultProductService) ProductGetAll(ctx context.Context) ([]models.Game, error) { //servis katmanında repostory de tanımladığımız GetAll ukulanan fonksiyon
+	ctx, span := tracer.Start(ctx, "ProductService.ProductGetAll")
+	defer span.End()
+	result, err := s.Repo.GetAll(ctx)
 	if err != nil {
-		return nil, err
+		return nil, recordError(span, err)
 	}
-	return result, err
+	return localizeAll(ctx, filterContent(ctx, storefrontOnly(result))), nil
 }
 
-// ürün silme
-func (s *DefaultProductService) ProductDelete(id primitive.ObjectID) (bool, error) {
-	result, err := s.Repo.Delete(id)
+// ürün silme. Ana oyuna bağlı DLC ve sürümler varsa dependents belirtilmeden silinmez; oyun içinde bulunduğu paketlerden
+// her durumda çıkarılır. Bağlılar ve paketler önce işlenir, oyunun kendisi en son silinir: bir adım başarısız olursa oyun
+// yerinde kalır ve (false, err) döner, istek tekrarlandığında kalan bağlılar işlenir.
+func (s *DefaultProductService) ProductDelete(ctx context.Context, id primitive.ObjectID, dependents string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.ProductDelete")
+	defer span.End()
+	if dependents != "" && dependents != DependentsCascade && dependents != DependentsDetach {
+		return false, ErrInvalidDeletePolicy
+	}
+	if _, err := s.Repo.GetByID(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
+		}
+		return false, recordError(span, err)
+	}
+	children, err := s.Repo.GetChildren(ctx, id)
+	if err != nil {
+		return false, recordError(span, err)
+	}
+	if len(children) > 0 && dependents == "" {
+		return false, ErrGameHasDependents
+	}
+	bundles := 0
+	for _, child := range children {
+		if dependents == DependentsCascade {
+			var n int
+			if n, err = s.removeFromBundles(ctx, child.ID); err == nil {
+				bundles += n
+				_, err = s.Repo.Delete(ctx, child.ID)
+			}
+		} else {
+			_, err = s.Repo.Patch(ctx, child.ID, map[string]interface{}{"kind": models.GameKindBase, "parent_id": nil})
+		}
+		if err != nil {
+			s.Log.ErrorContext(ctx, "oyunun bağlısı işlenemedi, oyun silinmedi", "id", id, "dependent", child.ID, "policy", dependents, "error", err)
+			return false, recordError(span, err)
+		}
+	}
+	n, err := s.removeFromBundles(ctx, id)
+	if err != nil {
+		return false, recordError(span, err)
+	}
+	bundles += n
+	result, err := s.Repo.Delete(ctx, id)
 	if err != nil || result == false {
-		return false, err
+		return false, recordError(span, err)
 	}
+	s.Log.InfoContext(ctx, "oyun silindi", "id", id, "dependents", len(children), "policy", dependents, "bundles", bundles)
 	return true, nil
 }
 
 // id ye göre ürünü kmple günceler
-func (s *DefaultProductService) ProductUptade(id primitive.ObjectID, game models.Game) (bool, error) {
-	result, err := s.Repo.Update(id, game)
-	if err != nil || result == false {
+func (s *DefaultProductService) ProductUptade(ctx context.Context, id primitive.ObjectID, game models.Game) (bool, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.ProductUptade")
+	defer span.End()
+	current, err := s.Repo.GetByID(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, recordError(span, err)
+	}
+	if err := validateAgeRating(game.Rating); err != nil {
 		return false, err
 	}
+	game.Rating = withComputedRating(game.Rating, current.Rating) // yorumlardan hesaplanan alanlar PUT ile ezilmez
+	withComputedPlaytime(&game, current)
+	s.withRequirementSpecs(&game)
+	if game.Status != "" && game.Status != current.Status {
+		return false, ErrStatusChangeViaTransition
+	}
+	withCurrentStatus(&game, current)        // durum ve geçmişi yalnızca durum geçişiyle değişir
+	game.Translations = current.Translations // çeviriler yalnızca çeviri uç noktalarıyla değişir
+	if err := checkStatusRequirements(current, game, time.Now()); err != nil {
+		return false, err
+	}
+	if err := s.checkRelations(ctx, &game, id); err != nil {
+		return false, err
+	}
+	result, err := s.Repo.Update(ctx, id, game)
+	if err != nil || result == false {
+		return false, recordError(span, err)
+	}
 	return true, nil
 
 }
 
+// ProductPublish taslak içeriği canlı oyunun yerine yazar. Hesaplanan alanlar (puanlar, oynama süreleri) ve yaşam döngüsü
+// alanları canlı oyundan alınır, ilişkiler PUT'taki gibi doğrulanır. Yazma tek belgelik koşullu bir değiştirmedir:
+// version verilmişse canlı oyunun updated_at değeri ona eşit olmalıdır, verilmemişse okunduğu andaki değeri kullanılır;
+// aksi halde ErrGameChanged döner.
+func (s *DefaultProductService) ProductPublish(ctx context.Context, id primitive.ObjectID, game models.Game, version *time.Time) (models.Game, models.Game, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.ProductPublish")
+	defer span.End()
+	current, err := s.Repo.GetByID(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.Game{}, models.Game{}, ErrGameNotFound
+	}
+	if err != nil {
+		return models.Game{}, models.Game{}, recordError(span, err)
+	}
+	if version != nil && !version.Equal(current.UpdatedAt) {
+		return models.Game{}, models.Game{}, ErrGameChanged
+	}
+	if err := validateAgeRating(game.Rating); err != nil {
+		return models.Game{}, models.Game{}, err
+	}
+	game.ID, game.CreatedAt = id, current.CreatedAt
+	game.Rating = withComputedRating(game.Rating, current.Rating)
+	withComputedPlaytime(&game, current)
+	withCurrentStatus(&game, current)
+	s.withRequirementSpecs(&game)
+	game.Translations = current.Translations
+	if err := checkStatusRequirements(current, game, time.Now()); err != nil {
+		return models.Game{}, models.Game{}, err
+	}
+	if err := s.checkRelations(ctx, &game, id); err != nil {
+		return models.Game{}, models.Game{}, err
+	}
+	ok, err := s.Repo.ReplaceIfVersion(ctx, id, game, current.UpdatedAt)
+	if err != nil {
+		return models.Game{}, models.Game{}, recordError(span, err)
+	}
+	if !ok {
+		return models.Game{}, models.Game{}, ErrGameChanged
+	}
+	after, err := s.Repo.GetByID(ctx, id)
+	if err != nil {
+		return models.Game{}, models.Game{}, recordError(span, err)
+	}
+	return current, after, nil
+}
+
 // ProductPatch, bir ürünün belirli alanlarını günceller
-func (s *DefaultProductService) ProductPatch(id primitive.ObjectID, updates map[string]interface{}) (bool, error) {
+func (s *DefaultProductService) ProductPatch(ctx context.Context, id primitive.ObjectID, updates map[string]interface{}) (bool, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.ProductPatch")
+	defer span.End()
+	if err := stripComputedRating(updates); err != nil {
+		return false, err
+	}
+	stripComputedPlaytime(updates)
+	requirementsChanged := stripRequirementSpecs(updates)
+	stripTranslations(updates)
+	if err := s.patchReleaseDates(ctx, id, updates); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := s.patchStatus(ctx, id, updates); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := s.patchRelations(ctx, id, updates); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := s.patchAgeRating(ctx, id, updates); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := s.patchStatusRequirements(ctx, id, updates); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
 	// Repository katmanındaki Patch metodunu çağır
-	result, err := s.Repo.Patch(id, updates)
+	result, err := s.Repo.Patch(ctx, id, updates)
 	if err != nil {
-		return false, err
+		return false, recordError(span, err)
+	}
+	if result && requirementsChanged {
+		if err := s.refreshRequirementSpecs(ctx, id); err != nil {
+			// metinler kaydedildi; donanım değerleri bir sonraki yeniden hesaplamada düzelir
+			s.Log.WarnContext(ctx, "donanım gereksinimleri güncellenemedi", "id", id, "error", err)
+		}
 	}
 
 	return result, nil
 }
 
 // ID ye göre filtreleme yapmak için
-func (s *DefaultProductService) ProductGetByID(id primitive.ObjectID) (models.Game, error) {
-	result, err := s.Repo.GetByID(id)
+func (s *DefaultProductService) ProductGetByID(ctx context.Context, id primitive.ObjectID) (models.Game, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.ProductGetByID")
+	defer span.End()
+	result, err := s.Repo.GetByID(ctx, id)
 	if err != nil {
-		return models.Game{}, err //boş game ve hata döner
+		return models.Game{}, recordError(span, err) //boş game ve hata döner
 	}
-
-	return result, nil
+	if result.Kind == models.GameKindBundle {
+		// paket fiyatı içindeki oyunların güncel fiyatlarından hesaplanır
+		items, err := s.Repo.GetByIDs(ctx, result.BundleItems)
+		if err != nil {
+			return models.Game{}, recordError(span, err)
+		}
+		result.BundlePricing = bundlePricing(&result, localizeAll(ctx, items))
+	}
+	return localize(ctx, result), nil
 }
 
 // fiyata göre sıralamak için
-func (s *DefaultProductService) ProductGetSorted(sortField string, order int) ([]models.Game, error) { //Fiyata artan ve azalana göre sıralama
-	result, err := s.Repo.GetAndSorted(sortField, order)
+func (s *DefaultProductService) ProductGetSorted(ctx context.Context, sortField string, order int) ([]models.Game, error) { //Fiyata artan ve azalana göre sıralama
+	ctx, span := tracer.Start(ctx, "ProductService.ProductGetSorted")
+	defer span.End()
+	result, err := s.Repo.GetAndSorted(ctx, sortField, order)
 	if err != nil {
-		return nil, err //boş games ve hata döner
+		return nil, recordError(span, err) //boş games ve hata döner
 	}
 
-	return result, nil
+	return localizeAll(ctx, filterContent(ctx, storefrontOnly(result))), nil
 }
 
 // tam isme göre filtereleme
-func (s *DefaultProductService) ProductGetByExactName(name string) ([]models.Game, error) {
-	result, err := s.Repo.GetByExactName(name)
+func (s *DefaultProductService) ProductGetByExactName(ctx context.Context, name string) ([]models.Game, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.ProductGetByExactName")
+	defer span.End()
+	result, err := s.Repo.GetByExactName(ctx, name)
 	if err != nil {
-		return nil, err
+		return nil, recordError(span, err)
 	}
-	return result, nil
+	return localizeAll(ctx, filterContent(ctx, storefrontOnly(result))), nil
 }
-func (s *DefaultProductService) ProductGetByPartialName(name string) ([]models.Game, error) {
-	result, err := s.Repo.GetByPartialName(name)
+func (s *DefaultProductService) ProductGetByPartialName(ctx context.Context, name string) ([]models.Game, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.ProductGetByPartialName")
+	defer span.End()
+	result, err := s.Repo.GetByPartialName(ctx, name)
 	if err != nil {
-		return nil, err
+		return nil, recordError(span, err)
 	}
-	return result, nil
+	return localizeAll(ctx, filterContent(ctx, storefrontOnly(result))), nil
 }
 
 // ProductGetByPriceRange, belirli bir fiyat aralığındaki oyunları getirir
-func (s *DefaultProductService) ProductGetByPriceRange(minPrice, maxPrice float64) ([]models.Game, error) {
-	result, err := s.Repo.GetByPriceRange(minPrice, maxPrice)
+func (s *DefaultProductService) ProductGetByPriceRange(ctx context.Context, minPrice, maxPrice float64) ([]models.Game, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.ProductGetByPriceRange")
+	defer span.End()
+	result, err := s.Repo.GetByPriceRange(ctx, minPrice, maxPrice)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	return localizeAll(ctx, filterContent(ctx, storefrontOnly(result))), nil
+}
+
+// ProductGetBySpec verilen bilgisayarın minimum gereksinimlerini karşıladığı oyunları getirir; name boş değilse başlıkta
+// geçmelidir. Tanınmayan işlemci ya da ekran kartı ErrUnknownHardware döner.
+func (s *DefaultProductService) ProductGetBySpec(ctx context.Context, name string, req dto.HardwareSpecRequest) ([]models.Game, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.ProductGetBySpec")
+	defer span.End()
+	spec, err := resolveHardware(s.Hardware, req, true)
 	if err != nil {
 		return nil, err
 	}
-	return result, nil
+	result, err := s.Repo.GetBySpec(ctx, name, spec)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	return localizeAll(ctx, filterContent(ctx, storefrontOnly(result))), nil
+}
+
+// ProductStats, metrikler için duruma göre oyun sayılarını ve indirimdeki oyun sayısını getirir
+func (s *DefaultProductService) ProductStats(ctx context.Context) (map[string]int64, int64, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.ProductStats")
+	defer span.End()
+	byStatus, err := s.Repo.CountByStatus(ctx)
+	if err != nil {
+		return nil, 0, recordError(span, err)
+	}
+	onSale, err := s.Repo.CountOnSale(ctx)
+	if err != nil {
+		return nil, 0, recordError(span, err)
+	}
+	return byStatus, onSale, nil
+}
+
+// ProductRelated oyunun ana oyununa bağlı DLC ve sürümleri döndürür; oyunun kendisi listelenmez. Paketlerin ilişkili
+// öğesi yoktur.
+func (s *DefaultProductService) ProductRelated(ctx context.Context, game models.Game) ([]models.RelatedGame, []models.RelatedGame, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.ProductRelated")
+	defer span.End()
+	dlcs, editions := []models.RelatedGame{}, []models.RelatedGame{}
+	root := game.ID
+	switch game.KindOrBase() {
+	case models.GameKindBundle:
+		return dlcs, editions, nil
+	case models.GameKindDLC, models.GameKindEdition:
+		if game.ParentID == nil {
+			return dlcs, editions, nil
+		}
+		root = *game.ParentID
+	}
+	children, err := s.Repo.GetChildren(ctx, root)
+	if err != nil {
+		return nil, nil, recordError(span, err)
+	}
+	for _, child := range children {
+		if child.ID == game.ID || !models.IsStorefrontVisible(child.Status) || !GameAllowed(ctx, child) {
+			continue // taslak, kaldırılmış ya da yaş sınırını aşan DLC ve sürümler oyun sayfasında gösterilmez
+		}
+		switch child.Kind {
+		case models.GameKindDLC:
+			dlcs = append(dlcs, relatedSummary(localize(ctx, child)))
+		case models.GameKindEdition:
+			editions = append(editions, relatedSummary(localize(ctx, child)))
+		}
+	}
+	return dlcs, editions, nil
+}
+
+// checkRelations oyunun türüne göre ana oyun ve paket içeriği alanlarını doğrular, türü normalleştirir ve paketlerin
+// fiyatını içindeki oyunlardan hesaplar. self güncellenen oyunun ID'sidir; yeni oyunlarda NilObjectID verilir.
+func (s *DefaultProductService) checkRelations(ctx context.Context, game *models.Game, self primitive.ObjectID) error {
+	kind := game.KindOrBase()
+	if !models.GameKinds[kind] {
+		return fmt.Errorf("%w: kind game, dlc, edition veya bundle olmalıdır", ErrInvalidGameRelation)
+	}
+	game.Kind = kind
+	if kind != models.GameKindBase && !self.IsZero() {
+		// DLC ve sürümler yalnızca ana oyuna bağlanabildiği için bağlıları olan oyunun türü değiştirilemez
+		children, err := s.Repo.GetChildren(ctx, self)
+		if err != nil {
+			return err
+		}
+		if len(children) > 0 {
+			return fmt.Errorf("%w: bağlı DLC veya sürümleri olan oyun %s yapılamaz", ErrInvalidGameRelation, kind)
+		}
+	}
+	switch kind {
+	case models.GameKindBase:
+		if game.ParentID != nil || len(game.BundleItems) > 0 {
+			return fmt.Errorf("%w: ana oyunda parent_id ve bundle_items kullanılamaz", ErrInvalidGameRelation)
+		}
+	case models.GameKindDLC, models.GameKindEdition:
+		if game.ParentID == nil || game.ParentID.IsZero() || len(game.BundleItems) > 0 {
+			return fmt.Errorf("%w: %s için parent_id zorunludur, bundle_items kullanılamaz", ErrInvalidGameRelation, kind)
+		}
+		if *game.ParentID == self {
+			return fmt.Errorf("%w: oyun kendisine bağlanamaz", ErrInvalidGameRelation)
+		}
+		parent, err := s.Repo.GetByID(ctx, *game.ParentID)
+		if errors.Is(err, repository.ErrNotFound) {
+			return fmt.Errorf("%w: ana oyun bulunamadı", ErrInvalidGameRelation)
+		}
+		if err != nil {
+			return err
+		}
+		if parent.KindOrBase() != models.GameKindBase {
+			return fmt.Errorf("%w: %s yalnızca bir ana oyuna bağlanabilir", ErrInvalidGameRelation, kind)
+		}
+	case models.GameKindBundle:
+		if game.ParentID != nil {
+			return fmt.Errorf("%w: pakette parent_id kullanılamaz", ErrInvalidGameRelation)
+		}
+		seen := map[primitive.ObjectID]bool{}
+		items := make([]primitive.ObjectID, 0, len(game.BundleItems))
+		for _, item := range game.BundleItems {
+			if item == self {
+				return fmt.Errorf("%w: paket kendisini içeremez", ErrInvalidGameRelation)
+			}
+			if !seen[item] {
+				seen[item] = true
+				items = append(items, item)
+			}
+		}
+		if len(items) < 2 {
+			return fmt.Errorf("%w: paket en az iki farklı oyun içermelidir", ErrInvalidGameRelation)
+		}
+		games, err := s.Repo.GetByIDs(ctx, items)
+		if err != nil {
+			return err
+		}
+		if len(games) != len(items) {
+			return fmt.Errorf("%w: paketteki oyunlardan bazıları bulunamadı", ErrInvalidGameRelation)
+		}
+		for _, item := range games {
+			if item.Kind == models.GameKindBundle {
+				return fmt.Errorf("%w: paket başka bir paketi içeremez", ErrInvalidGameRelation)
+			}
+			if item.Price.Currency != games[0].Price.Currency {
+				return fmt.Errorf("%w: paketteki oyunların para birimi aynı olmalıdır", ErrInvalidGameRelation)
+			}
+		}
+		game.BundleItems = items
+		bundlePricing(game, games)
+	}
+	return nil
+}
+
+// relationFields PATCH ile değiştirildiğinde oyunun ilişkilerinin yeniden doğrulanmasını gerektiren alanlardır
+var relationFields = []string{"kind", "parent_id", "bundle_items"}
+
+// patchRelations PATCH güncellemesindeki ilişki alanlarını mevcut oyunla birleştirip doğrular ve ObjectID'ye çevirir.
+// Paketlerde fiyat alanları değişmişse paket fiyatı yeniden hesaplanır.
+func (s *DefaultProductService) patchRelations(ctx context.Context, id primitive.ObjectID, updates map[string]interface{}) error {
+	for _, field := range []string{"dlcs", "editions", "bundle_pricing", "achievements"} {
+		delete(updates, field) // okuma sırasında doldurulan alanlar kaydedilmez
+	}
+	relationTouched, priceTouched := false, false
+	for _, field := range relationFields {
+		if _, ok := updates[field]; ok {
+			relationTouched = true
+		}
+	}
+	for field := range updates {
+		if field == "price" || strings.HasPrefix(field, "price.") {
+			priceTouched = true // paket fiyatı içindeki oyunlardan hesaplanır; mevcut oyunun paket olup olmadığına bakılmalı
+		}
+	}
+	if !relationTouched && !priceTouched {
+		return nil
+	}
+	current, err := s.Repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !relationTouched && current.Kind != models.GameKindBundle {
+		return nil
+	}
+	merged := current
+	if kind, ok := updates["kind"]; ok && kind != nil {
+		text, isText := kind.(string)
+		if !isText {
+			return fmt.Errorf("%w: kind metin olmalıdır", ErrInvalidGameRelation)
+		}
+		merged.Kind = text
+	} else if ok {
+		merged.Kind = ""
+	}
+	if parent, ok := updates["parent_id"]; ok {
+		merged.ParentID = nil
+		if parent != nil {
+			hex, _ := parent.(string)
+			parentID, err := primitive.ObjectIDFromHex(hex)
+			if err != nil {
+				return fmt.Errorf("%w: parent_id bir MongoDB ObjectID olmalıdır", ErrInvalidGameRelation)
+			}
+			merged.ParentID = &parentID
+		}
+	}
+	if items, ok := updates["bundle_items"]; ok {
+		list, isList := items.([]interface{})
+		if !isList && items != nil {
+			return fmt.Errorf("%w: bundle_items ObjectID listesi olmalıdır", ErrInvalidGameRelation)
+		}
+		merged.BundleItems = make([]primitive.ObjectID, 0, len(list))
+		for _, item := range list {
+			hex, _ := item.(string)
+			itemID, err := primitive.ObjectIDFromHex(hex)
+			if err != nil {
+				return fmt.Errorf("%w: bundle_items ObjectID listesi olmalıdır", ErrInvalidGameRelation)
+			}
+			merged.BundleItems = append(merged.BundleItems, itemID)
+		}
+	}
+	if merged.KindOrBase() == models.GameKindBundle {
+		flattenPrice(updates)
+		if discount, ok := updates["price.discount"].(float64); ok {
+			merged.Price.Discount = discount
+		}
+	}
+	if err := s.checkRelations(ctx, &merged, id); err != nil {
+		return err
+	}
+	if relationTouched {
+		updates["kind"], updates["parent_id"], updates["bundle_items"] = merged.Kind, merged.ParentID, merged.BundleItems
+	}
+	if merged.Kind == models.GameKindBundle {
+		updates["price.amount"], updates["price.currency"], updates["price.on_sale"] = merged.Price.Amount, merged.Price.Currency, merged.Price.OnSale
+	}
+	return nil
+}
+
+// patchReleaseDates PATCH'te metin olarak gelen oyun ve platform çıkış tarihlerini POST ve PUT ile aynı kurallarla
+// (models.ReleaseDate) tarihe çevirir; aksi halde MongoDB'ye metin olarak yazılır ve takvim sorgularında görünmezler.
+// "platforms" listesindeki her platform aynı adlı mevcut platformun üzerine yazılır, böylece gövdede olmayan alanlar korunur.
+func (s *DefaultProductService) patchReleaseDates(ctx context.Context, id primitive.ObjectID, updates map[string]interface{}) error {
+	for field, value := range updates {
+		if field != "release_date" && !(strings.HasPrefix(field, "platforms.") && strings.HasSuffix(field, ".release_date")) {
+			continue
+		}
+		date, err := releaseDateValue(value)
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, field)
+		}
+		updates[field] = date
+	}
+	raw, ok := updates["platforms"]
+	if !ok || raw == nil {
+		return nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("%w: platforms: %v", ErrInvalidFieldType, err)
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return fmt.Errorf("%w: platforms bir liste olmalıdır", ErrInvalidFieldType)
+	}
+	current, err := s.Repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	platforms := make([]models.Platform, 0, len(items))
+	for _, item := range items {
+		var named struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(item, &named); err != nil {
+			return fmt.Errorf("%w: platforms: %v", ErrInvalidFieldType, err)
+		}
+		platform := models.Platform{}
+		for _, existing := range current.Platforms {
+			if strings.EqualFold(existing.Name, named.Name) {
+				platform = existing
+				break
+			}
+		}
+		if err := json.Unmarshal(item, &platform); err != nil {
+			if errors.Is(err, ErrInvalidReleaseDate) {
+				return fmt.Errorf("%w: %s platformunun release_date alanı", ErrInvalidReleaseDate, named.Name)
+			}
+			return fmt.Errorf("%w: platforms: %v", ErrInvalidFieldType, err)
+		}
+		platforms = append(platforms, platform)
+	}
+	updates["platforms"] = platforms
+	return nil
+}
+
+// releaseDateValue PATCH gövdesindeki tarih değerini çevirir; null tarihi siler
+func releaseDateValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if v == "" {
+			return nil, nil
+		}
+		return models.ParseReleaseDate(v)
+	case time.Time:
+		return v, nil
+	}
+	return nil, ErrInvalidReleaseDate
+}
+
+// flattenPrice PATCH'te gönderilen "price" nesnesini "price.<alan>" güncellemelerine açar; böylece hesaplanan fiyat alanları ayrıca yazılabilir
+func flattenPrice(updates map[string]interface{}) {
+	if nested, ok := updates["price"].(map[string]interface{}); ok {
+		delete(updates, "price")
+		for field, value := range nested {
+			updates["price."+field] = value
+		}
+	}
+}
+
+// removeFromBundles silinen oyunu içeren paketlerden çıkarır ve paket fiyatlarını yeniden hesaplar; güncellenen paket sayısını döndürür
+func (s *DefaultProductService) removeFromBundles(ctx context.Context, id primitive.ObjectID) (int, error) {
+	bundles, err := s.Repo.GetBundlesContaining(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	for _, bundle := range bundles {
+		remaining := make([]primitive.ObjectID, 0, len(bundle.BundleItems))
+		for _, item := range bundle.BundleItems {
+			if item != id {
+				remaining = append(remaining, item)
+			}
+		}
+		bundle.BundleItems = remaining
+		items, err := s.Repo.GetByIDs(ctx, remaining)
+		if err != nil {
+			return 0, err
+		}
+		bundlePricing(&bundle, items)
+		if len(remaining) < 2 {
+			s.Log.WarnContext(ctx, "pakette tek oyun kaldı", "bundle_id", bundle.ID, "removed", id)
+		}
+		if _, err := s.Repo.Patch(ctx, bundle.ID, map[string]interface{}{"bundle_items": remaining, "price.amount": bundle.Price.Amount, "price.on_sale": bundle.Price.OnSale}); err != nil {
+			return 0, err
+		}
+	}
+	return len(bundles), nil
+}
+
+// bundlePricing paketteki oyunların güncel fiyatlarını toplar, paketin kendi indirim oranını (price.discount) uygular ve
+// paketin Price alanını toplam ve indirimle günceller. Oyunlar paketteki sırasıyla listelenir.
+func bundlePricing(bundle *models.Game, items []models.Game) *models.BundlePricing {
+	byID := make(map[primitive.ObjectID]models.Game, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+	pricing := &models.BundlePricing{Items: []models.RelatedGame{}, Currency: bundle.Price.Currency}
+	for _, id := range bundle.BundleItems {
+		item, ok := byID[id]
+		if !ok {
+			continue
+		}
+		pricing.Items = append(pricing.Items, relatedSummary(item))
+		pricing.ItemsTotal += item.Price.Effective()
+		pricing.Currency = item.Price.Currency
+	}
+	discount := bundle.Price.Discount
+	if discount < 0 || discount >= 1 {
+		discount = 0
+	}
+	pricing.ItemsTotal = roundPrice(pricing.ItemsTotal)
+	pricing.Price = roundPrice(pricing.ItemsTotal * (1 - discount))
+	pricing.Savings = roundPrice(pricing.ItemsTotal - pricing.Price)
+	if pricing.ItemsTotal > 0 {
+		pricing.SavingsPercent = int(math.Round(pricing.Savings / pricing.ItemsTotal * 100))
+	}
+	bundle.Price.Amount, bundle.Price.Currency, bundle.Price.Discount = pricing.ItemsTotal, pricing.Currency, discount
+	bundle.Price.OnSale = discount > 0
+	return pricing
+}
+
+func roundPrice(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// relatedSummary oyunun DLC/sürüm/paket listelerinde gösterilen özetini oluşturur; başlığın çevirileri önbelleğe alınan
+// özetlerin (grafikler) istek sırasında dile göre gösterilebilmesi için taşınır
+func relatedSummary(game models.Game) models.RelatedGame {
+	var titles map[string]string
+	for _, t := range game.Translations {
+		if t.Title != "" {
+			if titles == nil {
+				titles = map[string]string{}
+			}
+			titles[t.Locale] = t.Title
+		}
+	}
+	return models.RelatedGame{
+		ID:         game.ID,
+		Title:      game.Title,
+		Kind:       game.KindOrBase(),
+		Price:      game.Price,
+		CoverImage: game.Media.CoverImage,
+		Status:     game.Status,
+		MinAge:     game.Rating.MinAge,
+		Titles:     titles,
+	}
+}
+
+// computedRatingFields yorumlardan hesaplanan ve istek gövdesiyle değiştirilemeyen Rating alanlarıdır
+var computedRatingFields = map[string]bool{"average_score": true, "total_reviews": true, "positive_percentage": true, "score_sum": true, "positive_count": true, "min_age": true}
+
+// withComputedRating istekteki Rating'den yalnızca editoryal alanları (ESRB, PEGI) alır, hesaplanan alanları current'tan korur;
+// yaş sınırı derecelerden yeniden hesaplanır
+func withComputedRating(requested, current models.Rating) models.Rating {
+	current.ESRB, current.PEGI = strings.ToUpper(strings.TrimSpace(requested.ESRB)), strings.TrimSpace(requested.PEGI)
+	current.MinAge, _ = current.MinimumAge()
+	return current
+}
+
+// patchAgeRating PATCH ile ESRB ya da PEGI değişiyorsa dereceleri doğrular ve yaş sınırını mevcut oyunla birleştirerek
+// yeniden hesaplar
+func (s *DefaultProductService) patchAgeRating(ctx context.Context, id primitive.ObjectID, updates map[string]interface{}) error {
+	esrb, esrbOK := updates["rating.esrb"]
+	pegi, pegiOK := updates["rating.pegi"]
+	if !esrbOK && !pegiOK {
+		return nil
+	}
+	current, err := s.Repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	rating := current.Rating
+	if esrbOK {
+		rating.ESRB = ratingValue(esrb)
+	}
+	if pegiOK {
+		rating.PEGI = ratingValue(pegi)
+	}
+	if err := validateAgeRating(rating); err != nil {
+		return err
+	}
+	rating = withComputedRating(rating, rating)
+	updates["rating.esrb"], updates["rating.pegi"], updates["rating.min_age"] = rating.ESRB, rating.PEGI, rating.MinAge
+	return nil
+}
+
+// stripComputedRating PATCH güncellemelerinden hesaplanan Rating alanlarını çıkarır; "rating" nesnesi gönderilmişse
+// alanları tek tek güncellenecek şekilde açılır, böylece hesaplanan alanlar silinmez. Nesne olmayan bir "rating"
+// (null dahil) bütün alt belgeyi değiştirip puan toplamlarını ve yaş derecesini sileceği için reddedilir.
+func stripComputedRating(updates map[string]interface{}) error {
+	for field := range computedRatingFields {
+		delete(updates, "rating."+field)
+	}
+	value, ok := updates["rating"]
+	if !ok {
+		return nil
+	}
+	nested, ok := value.(map[string]interface{})
+	if !ok {
+		return ErrInvalidRating
+	}
+	delete(updates, "rating")
+	for field, value := range nested {
+		if !computedRatingFields[field] {
+			updates["rating."+field] = value
+		}
+	}
+	return nil
+}
+
+// ratingValue PATCH gövdesindeki derece değerini metne çevirir; PEGI sayı olarak da gönderilebilir (ör. 18)
+func ratingValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// withComputedPlaytime oturumlardan hesaplanan oynama süresi alanlarını current'tan alır; istekteki değerler yok sayılır
+func withComputedPlaytime(game *models.Game, current models.Game) {
+	game.TotalPlayTime, game.PlaytimeStats = current.TotalPlayTime, current.PlaytimeStats
+}
+
+// stripComputedPlaytime PATCH güncellemelerinden hesaplanan oynama süresi alanlarını çıkarır
+func stripComputedPlaytime(updates map[string]interface{}) {
+	for field := range updates {
+		if field == "total_playtime" || field == "playtime_stats" || strings.HasPrefix(field, "playtime_stats.") {
+			delete(updates, field)
+		}
+	}
+}
+
+// withCurrentStatus yaşam döngüsü alanlarını current'tan alır; IsEarlyAccess durumdan türetilir
+func withCurrentStatus(game *models.Game, current models.Game) {
+	game.Status, game.StatusChangedAt, game.StatusHistory = current.Status, current.StatusChangedAt, current.StatusHistory
+	game.IsEarlyAccess = current.Status == models.GameStatusEarlyAccess
+}
+
+// patchStatus PATCH'ten yaşam döngüsü alanlarını çıkarır; status mevcut durumdan farklıysa ErrStatusChangeViaTransition döner
+func (s *DefaultProductService) patchStatus(ctx context.Context, id primitive.ObjectID, updates map[string]interface{}) error {
+	for field := range updates {
+		if field == "is_early_access" || field == "status_changed_at" || field == "status_history" || strings.HasPrefix(field, "status_history.") {
+			delete(updates, field)
+		}
+	}
+	status, ok := updates["status"]
+	if !ok {
+		return nil
+	}
+	delete(updates, "status")
+	current, err := s.Repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if value, _ := status.(string); value != current.Status {
+		return ErrStatusChangeViaTransition
+	}
+	return nil
+}
+
+// patchStatusRequirements PATCH uygulandıktan sonra oyunun mevcut durumunun gereksinimlerini karşılamaya devam edip
+// etmediğini denetler
+func (s *DefaultProductService) patchStatusRequirements(ctx context.Context, id primitive.ObjectID, updates map[string]interface{}) error {
+	touched := false
+	for field := range updates {
+		root, _, _ := strings.Cut(field, ".")
+		touched = touched || slices.Contains(statusRequirementFields, root)
+	}
+	if !touched {
+		return nil
+	}
+	current, err := s.Repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	merged, err := patchedGame(current, updates)
+	if err != nil {
+		return err
+	}
+	return checkStatusRequirements(current, merged, time.Now())
+}
+
+// storefrontOnly mağazada görünmeyen (taslak, incelemede, satıştan kalkmış, kaldırılmış) oyunları listeden çıkarır
+func storefrontOnly(games []models.Game) []models.Game {
+	visible := make([]models.Game, 0, len(games))
+	for _, game := range games {
+		if models.IsStorefrontVisible(game.Status) {
+			visible = append(visible, game)
+		}
+	}
+	return visible
 }
 
 // NewProductService  servis katmanındakş funclarımı kulanabilmek içinb bir nesne türetme işlemi gibi
-func NewProductService(repo repository.ProductRepository) ProductService {
-	return &DefaultProductService{Repo: repo}
+func NewProductService(repo repository.ProductRepository, table *hardware.Table, logger *slog.Logger) ProductService {
+	return &DefaultProductService{Repo: repo, Hardware: table, Log: logger}
 }
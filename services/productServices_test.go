@@ -0,0 +1,299 @@
+package services
+
+import (
+	"api-steam/models"
+	"api-steam/repository"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestStripComputedRating(t *testing.T) {
+	tests := []struct {
+		name    string
+		updates map[string]interface{}
+		want    map[string]interface{}
+		wantErr error
+	}{
+		{"rating yok", map[string]interface{}{"title": "x"}, map[string]interface{}{"title": "x"}, nil},
+		{"nokta yolu hesaplanan alan", map[string]interface{}{"rating.total_reviews": 0, "rating.pegi": "16"}, map[string]interface{}{"rating.pegi": "16"}, nil},
+		{"nesne açılır", map[string]interface{}{"rating": map[string]interface{}{"esrb": "M", "score_sum": 0}}, map[string]interface{}{"rating.esrb": "M"}, nil},
+		{"boş nesne", map[string]interface{}{"rating": map[string]interface{}{}}, map[string]interface{}{}, nil},
+		{"null reddedilir", map[string]interface{}{"rating": nil}, nil, ErrInvalidRating},
+		{"metin reddedilir", map[string]interface{}{"rating": "x"}, nil, ErrInvalidRating},
+		{"sayı reddedilir", map[string]interface{}{"rating": 18.0}, nil, ErrInvalidRating},
+		{"dizi reddedilir", map[string]interface{}{"rating": []interface{}{"M"}}, nil, ErrInvalidRating},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := stripComputedRating(tt.updates)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("hata = %v, beklenen %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(tt.updates, tt.want) {
+				t.Errorf("güncellemeler = %v, beklenen %v", tt.updates, tt.want)
+			}
+		})
+	}
+}
+
+func TestProductPatchRejectsNonObjectRating(t *testing.T) {
+	// Repo verilmez: geçersiz rating veritabanına ulaşmadan reddedilmeli
+	s := &DefaultProductService{}
+	for _, rating := range []interface{}{nil, "x", 0.0} {
+		ok, err := s.ProductPatch(context.Background(), primitive.NewObjectID(), map[string]interface{}{"rating": rating})
+		if ok || !errors.Is(err, ErrInvalidRating) {
+			t.Errorf("rating=%v: sonuç = (%v, %v), beklenen (false, ErrInvalidRating)", rating, ok, err)
+		}
+	}
+}
+
+// gameRepository yalnızca GetByID'yi karşılayan sahte depodur
+type gameRepository struct {
+	repository.ProductRepository
+	game models.Game
+}
+
+func (r *gameRepository) GetByID(context.Context, primitive.ObjectID) (models.Game, error) {
+	return r.game, nil
+}
+
+func TestPatchReleaseDates(t *testing.T) {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	current := models.Game{Platforms: []models.Platform{{Name: "PC", ReleaseDate: day, Requirements: "8 GB"}}}
+	s := &DefaultProductService{Repo: &gameRepository{game: current}}
+	tests := []struct {
+		name    string
+		updates map[string]interface{}
+		want    map[string]interface{}
+		wantErr error
+	}{
+		{"yalnızca gün", map[string]interface{}{"release_date": "2026-03-01"}, map[string]interface{}{"release_date": day}, nil},
+		{"RFC3339", map[string]interface{}{"release_date": "2026-03-01T00:00:00Z"}, map[string]interface{}{"release_date": day}, nil},
+		{"null silinir", map[string]interface{}{"release_date": nil}, map[string]interface{}{"release_date": nil}, nil},
+		{"nokta yolu", map[string]interface{}{"platforms.0.release_date": "2026-03-01"}, map[string]interface{}{"platforms.0.release_date": day}, nil},
+		{"platform alanları korunur", map[string]interface{}{"platforms": []interface{}{
+			map[string]interface{}{"name": "pc", "release_date": "2026-04-01"},
+			map[string]interface{}{"name": "PS5"},
+		}}, map[string]interface{}{"platforms": []models.Platform{
+			{Name: "pc", ReleaseDate: day.AddDate(0, 1, 0), Requirements: "8 GB"},
+			{Name: "PS5"},
+		}}, nil},
+		{"geçersiz tarih", map[string]interface{}{"release_date": "01.03.2026"}, nil, ErrInvalidReleaseDate},
+		{"sayı", map[string]interface{}{"release_date": 5.0}, nil, ErrInvalidReleaseDate},
+		{"geçersiz platform tarihi", map[string]interface{}{"platforms": []interface{}{map[string]interface{}{"name": "PC", "release_date": "dün"}}}, nil, ErrInvalidReleaseDate},
+		{"platform listesi değil", map[string]interface{}{"platforms": "PC"}, nil, ErrInvalidFieldType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.patchReleaseDates(context.Background(), primitive.NewObjectID(), tt.updates)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("hata = %v, beklenen %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(tt.updates, tt.want) {
+				t.Errorf("güncellemeler = %v, beklenen %v", tt.updates, tt.want)
+			}
+		})
+	}
+}
+
+func TestPatchRelationsRejectsWrongTypes(t *testing.T) {
+	s := &DefaultProductService{Repo: &gameRepository{game: models.Game{Title: "A"}}}
+	tests := []struct {
+		name    string
+		updates map[string]interface{}
+	}{
+		{"sayı kind", map[string]interface{}{"kind": 1.0}},
+		{"nesne kind", map[string]interface{}{"kind": map[string]interface{}{"value": "dlc"}}},
+		{"liste olmayan bundle_items", map[string]interface{}{"kind": "bundle", "bundle_items": "abc"}},
+		{"metin olmayan parent_id", map[string]interface{}{"kind": "dlc", "parent_id": 5.0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.patchRelations(context.Background(), primitive.NewObjectID(), tt.updates); !errors.Is(err, ErrInvalidGameRelation) {
+				t.Errorf("hata = %v, beklenen ErrInvalidGameRelation", err)
+			}
+		})
+	}
+}
+
+func TestBundlePricing(t *testing.T) {
+	a, b, c := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	items := []models.Game{
+		{ID: a, Title: "A", Price: models.Price{Amount: 100, Currency: "TRY"}},
+		{ID: b, Title: "B", Price: models.Price{Amount: 50, Currency: "TRY", OnSale: true, Discount: 0.5}},
+		{ID: c, Title: "C", Price: models.Price{Amount: 19.99, Currency: "TRY"}},
+	}
+	tests := []struct {
+		name       string
+		bundle     []primitive.ObjectID
+		discount   float64
+		wantItems  []string
+		wantTotal  float64
+		wantPrice  float64
+		wantSaving float64
+		wantPct    int
+		wantOnSale bool
+	}{
+		{"indirimsiz", []primitive.ObjectID{a, b}, 0, []string{"A", "B"}, 125, 125, 0, 0, false},
+		{"paket indirimi", []primitive.ObjectID{a, b, c}, 0.2, []string{"A", "B", "C"}, 144.99, 115.99, 29, 20, true},
+		{"paket sırası korunur", []primitive.ObjectID{c, a}, 0.1, []string{"C", "A"}, 119.99, 107.99, 12, 10, true},
+		{"silinmiş oyun atlanır", []primitive.ObjectID{a, primitive.NewObjectID()}, 0, []string{"A"}, 100, 100, 0, 0, false},
+		{"geçersiz indirim yok sayılır", []primitive.ObjectID{a}, 1.5, []string{"A"}, 100, 100, 0, 0, false},
+		{"negatif indirim yok sayılır", []primitive.ObjectID{a}, -0.3, []string{"A"}, 100, 100, 0, 0, false},
+		{"boş paket", nil, 0.5, []string{}, 0, 0, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundle := models.Game{Kind: models.GameKindBundle, BundleItems: tt.bundle, Price: models.Price{Discount: tt.discount}}
+			got := bundlePricing(&bundle, items)
+			titles := []string{}
+			for _, item := range got.Items {
+				titles = append(titles, item.Title)
+			}
+			if !reflect.DeepEqual(titles, tt.wantItems) {
+				t.Errorf("oyunlar = %v, beklenen %v", titles, tt.wantItems)
+			}
+			if got.ItemsTotal != tt.wantTotal || got.Price != tt.wantPrice || got.Savings != tt.wantSaving || got.SavingsPercent != tt.wantPct {
+				t.Errorf("döküm = %+v, beklenen toplam=%v fiyat=%v kazanç=%v yüzde=%d", got, tt.wantTotal, tt.wantPrice, tt.wantSaving, tt.wantPct)
+			}
+			if bundle.Price.Amount != tt.wantTotal || bundle.Price.OnSale != tt.wantOnSale {
+				t.Errorf("paket fiyatı = %+v, beklenen tutar=%v indirimde=%v", bundle.Price, tt.wantTotal, tt.wantOnSale)
+			}
+		})
+	}
+}
+
+// deleteRepository ProductDelete'in kullandığı metotları bellekte karşılar; failOn verilen ID'deki yazma başarısız olur
+type deleteRepository struct {
+	repository.ProductRepository
+	games  map[primitive.ObjectID]models.Game
+	failOn primitive.ObjectID
+	calls  []string
+}
+
+func (r *deleteRepository) GetByID(_ context.Context, id primitive.ObjectID) (models.Game, error) {
+	game, ok := r.games[id]
+	if !ok {
+		return models.Game{}, repository.ErrNotFound
+	}
+	return game, nil
+}
+
+func (r *deleteRepository) GetByIDs(_ context.Context, ids []primitive.ObjectID) ([]models.Game, error) {
+	var games []models.Game
+	for _, id := range ids {
+		if game, ok := r.games[id]; ok {
+			games = append(games, game)
+		}
+	}
+	return games, nil
+}
+
+func (r *deleteRepository) GetChildren(_ context.Context, id primitive.ObjectID) ([]models.Game, error) {
+	var children []models.Game
+	for _, game := range r.games {
+		if game.ParentID != nil && *game.ParentID == id {
+			children = append(children, game)
+		}
+	}
+	return children, nil
+}
+
+func (r *deleteRepository) GetBundlesContaining(_ context.Context, id primitive.ObjectID) ([]models.Game, error) {
+	var bundles []models.Game
+	for _, game := range r.games {
+		if game.Kind == models.GameKindBundle && slices.Contains(game.BundleItems, id) {
+			bundles = append(bundles, game)
+		}
+	}
+	return bundles, nil
+}
+
+func (r *deleteRepository) Delete(_ context.Context, id primitive.ObjectID) (bool, error) {
+	r.calls = append(r.calls, "delete "+r.games[id].Title)
+	if id == r.failOn {
+		return false, errors.New("bağlantı koptu")
+	}
+	delete(r.games, id)
+	return true, nil
+}
+
+func (r *deleteRepository) Patch(_ context.Context, id primitive.ObjectID, updates map[string]interface{}) (bool, error) {
+	game := r.games[id]
+	r.calls = append(r.calls, "patch "+game.Title)
+	if id == r.failOn {
+		return false, errors.New("bağlantı koptu")
+	}
+	if items, ok := updates["bundle_items"].([]primitive.ObjectID); ok {
+		game.BundleItems = items
+	}
+	if _, ok := updates["parent_id"]; ok {
+		game.Kind, game.ParentID = models.GameKindBase, nil
+	}
+	r.games[id] = game
+	return true, nil
+}
+
+func TestProductDelete(t *testing.T) {
+	base, dlc, bundle := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	newRepo := func() *deleteRepository {
+		return &deleteRepository{games: map[primitive.ObjectID]models.Game{
+			base:   {ID: base, Title: "Oyun"},
+			dlc:    {ID: dlc, Title: "DLC", Kind: models.GameKindDLC, ParentID: &base},
+			bundle: {ID: bundle, Title: "Paket", Kind: models.GameKindBundle, BundleItems: []primitive.ObjectID{base, dlc}},
+		}}
+	}
+	tests := []struct {
+		name       string
+		id         primitive.ObjectID
+		dependents string
+		failOn     primitive.ObjectID
+		want       bool
+		wantErr    bool
+		wantCalls  []string
+		wantLeft   []primitive.ObjectID
+	}{
+		{"bağlılar varken politika yok", base, "", primitive.NilObjectID, false, true, nil, []primitive.ObjectID{base, dlc, bundle}},
+		{"bulunamadı", primitive.NewObjectID(), DependentsCascade, primitive.NilObjectID, false, false, nil, []primitive.ObjectID{base, dlc, bundle}},
+		{"cascade, oyun en son silinir", base, DependentsCascade, primitive.NilObjectID, true, false,
+			[]string{"patch Paket", "delete DLC", "patch Paket", "delete Oyun"}, []primitive.ObjectID{bundle}},
+		{"detach", base, DependentsDetach, primitive.NilObjectID, true, false,
+			[]string{"patch DLC", "patch Paket", "delete Oyun"}, []primitive.ObjectID{dlc, bundle}},
+		{"bağlı silinemezse oyun kalır", base, DependentsCascade, dlc, false, true,
+			[]string{"patch Paket", "delete DLC"}, []primitive.ObjectID{base, dlc, bundle}},
+		{"paket güncellenemezse oyun kalır", base, DependentsDetach, bundle, false, true,
+			[]string{"patch DLC", "patch Paket"}, []primitive.ObjectID{base, dlc, bundle}},
+		{"oyun silinemezse", base, DependentsDetach, base, false, true,
+			[]string{"patch DLC", "patch Paket", "delete Oyun"}, []primitive.ObjectID{base, dlc, bundle}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRepo()
+			repo.failOn = tt.failOn
+			s := &DefaultProductService{Repo: repo, Log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+			got, err := s.ProductDelete(context.Background(), tt.id, tt.dependents)
+			if got != tt.want || (err != nil) != tt.wantErr {
+				t.Fatalf("sonuç = (%v, %v), beklenen (%v, hata=%v)", got, err, tt.want, tt.wantErr)
+			}
+			if !reflect.DeepEqual(repo.calls, tt.wantCalls) {
+				t.Errorf("çağrılar = %v, beklenen %v", repo.calls, tt.wantCalls)
+			}
+			for _, id := range tt.wantLeft {
+				if _, ok := repo.games[id]; !ok {
+					t.Errorf("%s silinmemeliydi", id.Hex())
+				}
+			}
+			if len(repo.games) != len(tt.wantLeft) {
+				t.Errorf("kalan oyun sayısı = %d, beklenen %d", len(repo.games), len(tt.wantLeft))
+			}
+		})
+	}
+}
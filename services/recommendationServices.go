@@ -0,0 +1,262 @@
+package services
+
+import (
+	"api-steam/dto"
+	"api-steam/models"
+	"api-steam/repository"
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Öneri puanı ağırlıkları: içerik benzerliği, popülerlik ve yenilik
+const (
+	recommendContentWeight    = 0.75
+	recommendPopularityWeight = 0.15
+	recommendRecencyWeight    = 0.10
+	recommendRecencyWindow    = 180 * 24 * time.Hour // Bu süreden yeni oyunlar yenilik katkısı alır
+	recommendMaxSeeds         = 50                   // Profil en ağır bu kadar oyundan çıkarılır
+	recommendPlaytimeSeeds    = 50
+	defaultRecommendations    = 20
+	maxRecommendations        = 50
+)
+
+// Öneri kaynakları: kullanıcının oyunla ilişkisi
+const (
+	SeedPlayed     = "played"
+	SeedOwned      = "owned"
+	SeedWishlisted = "wishlisted"
+	SeedReviewed   = "reviewed"
+)
+
+// seedWeights her kaynağın kullanıcı profiline katkısıdır; olumsuz yorumlanan oyunlar profili ters yönde etkiler
+var seedWeights = map[string]float64{SeedOwned: 1, SeedWishlisted: 0.8, SeedReviewed: 1.5, SeedPlayed: 1}
+
+// RecommendationService kullanıcının kütüphanesi, istek listesi, yorumları ve oynama sürelerinden kişisel öneriler üretir
+type RecommendationService interface {
+	RecommendForUser(ctx context.Context, userID primitive.ObjectID, limit int) ([]dto.Recommendation, error) //Sahip olunmayan oyunları puana göre sıralar
+}
+
+// DefaultRecommendationService önerileri istek anında uygulamanın kendi MongoDB verisinden hesaplar
+type DefaultRecommendationService struct {
+	Games     repository.ProductRepository
+	Users     repository.UserRepository
+	Library   repository.LibraryRepository
+	Wishlists repository.WishlistRepository
+	Reviews   repository.ReviewRepository
+	Playtime  repository.PlaytimeRepository
+	Log       *slog.Logger
+}
+
+// recommendSeed kullanıcının ilgilendiği bir oyundur; profil ve açıklamalar bunlardan çıkarılır
+type recommendSeed struct {
+	game    models.Game
+	profile similarProfile
+	source  string
+	weight  float64
+}
+
+// RecommendForUser kullanıcının sahip olmadığı, istek listesinde bulunmayan ve yorumlamadığı oyunları tür, etiket ve
+// geliştirici benzerliğine, popülerliğe ve yeniliğe göre sıralar. Kullanıcının yaş sınırını aşan, derecesi belirtilmemiş
+// ya da kaldırılmış oyunlar ve paketler önerilmez. Kullanıcı hakkında veri yoksa popüler ve yeni oyunlar döner.
+func (s *DefaultRecommendationService) RecommendForUser(ctx context.Context, userID primitive.ObjectID, limit int) ([]dto.Recommendation, error) {
+	ctx, span := tracer.Start(ctx, "RecommendationService.RecommendForUser")
+	defer span.End()
+	if limit <= 0 {
+		limit = defaultRecommendations
+	}
+	if limit > maxRecommendations {
+		limit = maxRecommendations
+	}
+	user, err := s.Users.GetByID(ctx, userID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	games, err := s.Games.GetAll(ctx)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	games = localizeAll(ctx, games) // açıklamalardaki başlıklar da isteğin dilinde olur
+	byID := make(map[primitive.ObjectID]models.Game, len(games))
+	for _, game := range games {
+		byID[game.ID] = game
+	}
+	seeds, known, err := s.seeds(ctx, userID, byID)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	if _, limited := ContentLimit(ctx); !limited && user.Content != nil {
+		ctx = WithContentLimit(ctx, *user.Content) // istekte sınır verilmemişse profil ayarları kullanılır
+	}
+	maxReviews := 0
+	for _, game := range games {
+		if game.Rating.TotalReviews > maxReviews {
+			maxReviews = game.Rating.TotalReviews
+		}
+	}
+	now := time.Now()
+	var recommendations []dto.Recommendation
+	for _, game := range games {
+		if known[game.ID] || game.Kind == models.GameKindBundle || game.Status == models.GameStatusRemoved {
+			continue
+		}
+		if age, _ := game.Rating.MinimumAge(); !contentAllowed(ctx, age) {
+			continue
+		}
+		rec := dto.Recommendation{Game: game}
+		content, because := s.contentScore(newSimilarProfile(game), seeds)
+		if len(seeds) > 0 && content <= 0 {
+			continue // kullanıcının ilgi alanlarıyla hiçbir ortak noktası yok
+		}
+		rec.Score = recommendContentWeight*content + recommendPopularityWeight*popularity(game.Rating, maxReviews) + recommendRecencyWeight*recency(game.ReleaseDate, now)
+		rec.Score = math.Round(rec.Score*1000) / 1000
+		if because != nil {
+			rec.Because = &dto.RecommendationSource{GameID: because.game.ID.Hex(), Title: because.game.Title, Source: because.source}
+			rec.Explanation = explainSeed(*because)
+		} else {
+			rec.Explanation = "Popüler ve yeni oyunlar arasında"
+		}
+		recommendations = append(recommendations, rec)
+	}
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		if recommendations[i].Score != recommendations[j].Score {
+			return recommendations[i].Score > recommendations[j].Score
+		}
+		return recommendations[i].Game.Title < recommendations[j].Game.Title
+	})
+	if len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+	if recommendations == nil {
+		recommendations = []dto.Recommendation{}
+	}
+	return recommendations, nil
+}
+
+// seeds kullanıcının kütüphanesi, oynama süreleri, istek listesi ve yorumlarından profil oyunlarını çıkarır. Aynı oyun
+// birden fazla kaynakta geçerse en ağır kaynak kullanılır. known önerilmeyecek (zaten bilinen) oyunlardır.
+func (s *DefaultRecommendationService) seeds(ctx context.Context, userID primitive.ObjectID, games map[primitive.ObjectID]models.Game) ([]recommendSeed, map[primitive.ObjectID]bool, error) {
+	known := map[primitive.ObjectID]bool{}
+	bySeed := map[primitive.ObjectID]recommendSeed{}
+	add := func(gameID primitive.ObjectID, source string, weight float64) {
+		known[gameID] = true
+		game, ok := games[gameID]
+		if !ok {
+			return
+		}
+		if current, ok := bySeed[gameID]; ok && math.Abs(current.weight) >= math.Abs(weight) {
+			return
+		}
+		bySeed[gameID] = recommendSeed{game: game, profile: newSimilarProfile(game), source: source, weight: weight}
+	}
+	library, err := s.Library.ListByUser(ctx, userID, repository.LibraryQuery{})
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, entry := range library {
+		add(entry.GameID, SeedOwned, seedWeights[SeedOwned])
+	}
+	stats, err := s.Playtime.ListStats(ctx, userID, "total", 0, recommendPlaytimeSeeds)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, st := range stats {
+		hours := float64(st.TotalSeconds) / 3600
+		add(st.GameID, SeedPlayed, seedWeights[SeedPlayed]+math.Log1p(hours)/2) // çok oynanan oyunlar profili daha çok belirler
+	}
+	wishlist, err := s.Wishlists.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, item := range wishlist {
+		add(item.GameID, SeedWishlisted, seedWeights[SeedWishlisted])
+	}
+	reviews, err := s.Reviews.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, review := range reviews {
+		weight := seedWeights[SeedReviewed]
+		if !review.Recommended {
+			weight = -weight
+		}
+		add(review.GameID, SeedReviewed, weight)
+	}
+	seeds := make([]recommendSeed, 0, len(bySeed))
+	for _, seed := range bySeed {
+		seeds = append(seeds, seed)
+	}
+	sort.Slice(seeds, func(i, j int) bool {
+		if math.Abs(seeds[i].weight) != math.Abs(seeds[j].weight) {
+			return math.Abs(seeds[i].weight) > math.Abs(seeds[j].weight)
+		}
+		return seeds[i].game.ID.Hex() < seeds[j].game.ID.Hex()
+	})
+	if len(seeds) > recommendMaxSeeds {
+		seeds = seeds[:recommendMaxSeeds]
+	}
+	return seeds, known, nil
+}
+
+// contentScore adayın profil oyunlarına ağırlıklı ortalama benzerliğini (0-1) ve en çok katkı veren olumlu profil oyununu döndürür
+func (s *DefaultRecommendationService) contentScore(candidate similarProfile, seeds []recommendSeed) (float64, *recommendSeed) {
+	var total, weights, best float64
+	var because *recommendSeed
+	for i := range seeds {
+		sim := 0.45*jaccard(candidate.genres, seeds[i].profile.genres) +
+			0.35*jaccard(candidate.tags, seeds[i].profile.tags) +
+			0.20*jaccard(candidate.developers, seeds[i].profile.developers)
+		total += seeds[i].weight * sim
+		weights += math.Abs(seeds[i].weight)
+		if contribution := seeds[i].weight * sim; contribution > best {
+			best, because = contribution, &seeds[i]
+		}
+	}
+	if weights == 0 || total <= 0 {
+		return 0, nil
+	}
+	return total / weights, because
+}
+
+// popularity yorum sayısını (logaritmik, en çok yorumlanan oyuna göre) olumlu yorum oranıyla çarparak 0-1 arası puanlar
+func popularity(rating models.Rating, maxReviews int) float64 {
+	if maxReviews <= 0 || rating.TotalReviews <= 0 {
+		return 0
+	}
+	return math.Log1p(float64(rating.TotalReviews)) / math.Log1p(float64(maxReviews)) * float64(rating.PositivePercentage) / 100
+}
+
+// recency son recommendRecencyWindow içinde çıkan oyunlara çıkış tarihi yaklaştıkça 1'e yaklaşan bir puan verir; çıkmamış oyunlar 0 alır
+func recency(released, now time.Time) float64 {
+	age := now.Sub(released)
+	if released.IsZero() || age < 0 || age > recommendRecencyWindow {
+		return 0
+	}
+	return 1 - float64(age)/float64(recommendRecencyWindow)
+}
+
+// explainSeed öneriyi getiren profil oyununu kullanıcıya okunur şekilde açıklar
+func explainSeed(seed recommendSeed) string {
+	switch seed.source {
+	case SeedPlayed:
+		return seed.game.Title + " oynadığın için"
+	case SeedWishlisted:
+		return seed.game.Title + " istek listende olduğu için"
+	case SeedReviewed:
+		return seed.game.Title + " oyununu beğendiğin için"
+	}
+	return seed.game.Title + " kütüphanende olduğu için"
+}
+
+// NewRecommendationService öneri servisini oluşturur
+func NewRecommendationService(games repository.ProductRepository, users repository.UserRepository, library repository.LibraryRepository, wishlists repository.WishlistRepository, reviews repository.ReviewRepository, playtime repository.PlaytimeRepository, logger *slog.Logger) RecommendationService {
+	return &DefaultRecommendationService{Games: games, Users: users, Library: library, Wishlists: wishlists, Reviews: reviews, Playtime: playtime, Log: logger}
+}
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"api-steam/dto"
+	"api-steam/models"
+	"api-steam/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidReleaseRange    = errors.New("geçersiz tarih aralığı: to, from'dan sonra olmalı ve aralık en fazla bir yıl olabilir")
+	ErrInvalidReleaseGrouping = errors.New("group yalnızca week veya month olabilir")
+)
+
+// Takvim gruplama birimleri
+const (
+	ReleaseGroupWeek  = "week"
+	ReleaseGroupMonth = "month"
+)
+
+const (
+	maxReleaseRange      = 366 * 24 * time.Hour
+	defaultComingSoon    = 50
+	maxComingSoon        = 200
+	icsPastWindow        = 30 * 24 * time.Hour  // Takvim aboneliği son 30 günün çıkışlarını da içerir
+	icsFutureWindow      = 365 * 24 * time.Hour // ve gelecek bir yılın
+	icsProductIdentifier = "-//api-steam//Cikis Takvimi//TR"
+)
+
+// ReleaseService çıkış takvimini, yakında çıkacak oyunları ve takvim aboneliğini sunar
+type ReleaseService interface {
+	ReleaseCalendar(ctx context.Context, query dto.ReleaseCalendarQuery) (dto.ReleaseCalendar, error) //Çıkışları hafta ya da aya göre gruplar
+	ReleaseComingSoon(ctx context.Context, platform string, limit int) ([]dto.ReleaseEntry, error)    //Çıkış tarihine göre yakında çıkacak oyunlar
+	ReleaseICS(ctx context.Context, platform string) ([]byte, error)                                  //iCalendar (.ics) aboneliği
+}
+
+// DefaultReleaseService çıkış tarihlerini oyunların ve platformlarının release_date alanlarından okur
+type DefaultReleaseService struct {
+	Games repository.ProductRepository
+	Log   *slog.Logger
+}
+
+// ReleaseCalendar [from, to) aralığındaki çıkışları hafta (ISO, pazartesi başlangıçlı) ya da aya göre gruplar. Çıkışı
+// olmayan dönemler de boş listeyle döner. Platformun kendi tarihi yoksa oyunun çıkış tarihi kullanılır.
+func (s *DefaultReleaseService) ReleaseCalendar(ctx context.Context, query dto.ReleaseCalendarQuery) (dto.ReleaseCalendar, error) {
+	ctx, span := tracer.Start(ctx, "ReleaseService.ReleaseCalendar")
+	defer span.End()
+	if query.GroupBy == "" {
+		query.GroupBy = ReleaseGroupMonth
+	}
+	if query.GroupBy != ReleaseGroupWeek && query.GroupBy != ReleaseGroupMonth {
+		return dto.ReleaseCalendar{}, ErrInvalidReleaseGrouping
+	}
+	if query.From.IsZero() {
+		query.From = periodStart(time.Now(), ReleaseGroupMonth)
+	}
+	if query.To.IsZero() {
+		query.To = query.From.AddDate(0, 3, 0)
+	}
+	if !query.To.After(query.From) || query.To.Sub(query.From) > maxReleaseRange {
+		return dto.ReleaseCalendar{}, ErrInvalidReleaseRange
+	}
+	games, err := s.Games.GetReleases(ctx, repository.ReleaseQuery{From: query.From, To: query.To, Platform: query.Platform})
+	if err != nil {
+		return dto.ReleaseCalendar{}, recordError(span, err)
+	}
+	games = localizeAll(ctx, filterContent(ctx, games))
+	calendar := dto.ReleaseCalendar{From: query.From, To: query.To, GroupBy: query.GroupBy, Platform: query.Platform, Periods: []dto.ReleasePeriod{}}
+	for start := periodStart(query.From, query.GroupBy); start.Before(query.To); start = nextPeriod(start, query.GroupBy) {
+		calendar.Periods = append(calendar.Periods, dto.ReleasePeriod{Label: periodLabel(start, query.GroupBy), Start: start, End: nextPeriod(start, query.GroupBy), Games: []dto.ReleaseEntry{}})
+	}
+	for _, entry := range releaseEntries(games, query.Platform) {
+		if entry.ReleaseDate == nil || entry.ReleaseDate.Before(query.From) || !entry.ReleaseDate.Before(query.To) {
+			continue // oyunun başka bir platformdaki çıkışı aralığa düştüğü için gelmiş olabilir
+		}
+		for i := range calendar.Periods {
+			if !entry.ReleaseDate.Before(calendar.Periods[i].Start) && entry.ReleaseDate.Before(calendar.Periods[i].End) {
+				calendar.Periods[i].Games = append(calendar.Periods[i].Games, entry)
+				break
+			}
+		}
+	}
+	return calendar, nil
+}
+
+// ReleaseComingSoon henüz çıkmamış oyunları en yakın çıkış tarihinden başlayarak döndürür; tarihi belli olmayan
+// "coming_soon" durumundaki oyunlar listenin sonunda yer alır
+func (s *DefaultReleaseService) ReleaseComingSoon(ctx context.Context, platform string, limit int) ([]dto.ReleaseEntry, error) {
+	ctx, span := tracer.Start(ctx, "ReleaseService.ReleaseComingSoon")
+	defer span.End()
+	if limit <= 0 {
+		limit = defaultComingSoon
+	}
+	if limit > maxComingSoon {
+		limit = maxComingSoon
+	}
+	now := time.Now()
+	games, err := s.Games.GetReleases(ctx, repository.ReleaseQuery{From: now, Platform: platform, IncludeUndated: true})
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	games = localizeAll(ctx, filterContent(ctx, games))
+	entries := []dto.ReleaseEntry{}
+	for _, entry := range releaseEntries(games, platform) {
+		if entry.ReleaseDate != nil && entry.ReleaseDate.Before(now) {
+			continue
+		}
+		if entry.ReleaseDate == nil && entry.Game.Status != models.GameStatusComingSoon {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+// ReleaseICS son 30 gün ile gelecek bir yılın çıkışlarını tüm gün süren etkinlikler olarak iCalendar (RFC 5545)
+// biçiminde döndürür. Takvim uygulamaları adresi periyodik olarak yeniden okuyarak tarih değişikliklerini alır.
+func (s *DefaultReleaseService) ReleaseICS(ctx context.Context, platform string) ([]byte, error) {
+	ctx, span := tracer.Start(ctx, "ReleaseService.ReleaseICS")
+	defer span.End()
+	now := time.Now().UTC()
+	games, err := s.Games.GetReleases(ctx, repository.ReleaseQuery{From: now.Add(-icsPastWindow), To: now.Add(icsFutureWindow), Platform: platform})
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	games = localizeAll(ctx, filterContent(ctx, games))
+	descriptions := make(map[string]string, len(games))
+	for _, game := range games {
+		descriptions[game.ID.Hex()] = game.ShortDescription
+	}
+	name := "Oyun çıkış takvimi"
+	if platform != "" {
+		name += " (" + platform + ")"
+	}
+	var b strings.Builder
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:"+icsProductIdentifier)
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+	writeICSLine(&b, "METHOD:PUBLISH")
+	writeICSLine(&b, "X-WR-CALNAME:"+escapeICSText(name))
+	writeICSLine(&b, "REFRESH-INTERVAL;VALUE=DURATION:PT12H")
+	for _, entry := range releaseEntries(games, platform) {
+		if entry.ReleaseDate == nil {
+			continue
+		}
+		day := entry.ReleaseDate.UTC()
+		summary := entry.Game.Title
+		if len(entry.Platforms) > 0 {
+			summary += " (" + strings.Join(entry.Platforms, ", ") + ")"
+		}
+		writeICSLine(&b, "BEGIN:VEVENT")
+		writeICSLine(&b, "UID:"+entry.Game.ID.Hex()+"-"+day.Format("20060102")+"@api-steam")
+		writeICSLine(&b, "DTSTAMP:"+now.Format("20060102T150405Z"))
+		writeICSLine(&b, "DTSTART;VALUE=DATE:"+day.Format("20060102"))
+		writeICSLine(&b, "DTEND;VALUE=DATE:"+day.AddDate(0, 0, 1).Format("20060102"))
+		writeICSLine(&b, "SUMMARY:"+escapeICSText(summary))
+		if description := descriptions[entry.Game.ID.Hex()]; description != "" {
+			writeICSLine(&b, "DESCRIPTION:"+escapeICSText(description))
+		}
+		writeICSLine(&b, "TRANSP:TRANSPARENT")
+		writeICSLine(&b, "END:VEVENT")
+	}
+	writeICSLine(&b, "END:VCALENDAR")
+	return []byte(b.String()), nil
+}
+
+// releaseEntries oyunları çıkış tarihlerine göre kayıtlara açar: aynı gün çıkılan platformlar tek kayıtta birleşir,
+// farklı günlerde çıkılan platformlar ayrı kayıt olur. platform verilmişse yalnızca o platform dikkate alınır.
+// Kayıtlar tarihe (tarihsizler sonda) sonra başlığa göre sıralıdır.
+func releaseEntries(games []models.Game, platform string) []dto.ReleaseEntry {
+	var entries []dto.ReleaseEntry
+	for _, game := range games {
+		summary := relatedSummary(game)
+		if len(game.Platforms) == 0 {
+			if platform == "" {
+				entries = append(entries, dto.ReleaseEntry{Game: summary, ReleaseDate: releaseDatePtr(game.ReleaseDate), Platforms: []string{}})
+			}
+			continue
+		}
+		byDay := map[string]int{}
+		for _, p := range game.Platforms {
+			if platform != "" && !strings.EqualFold(p.Name, platform) {
+				continue
+			}
+			date := game.ReleaseDateOn(p)
+			day := ""
+			if !date.IsZero() {
+				day = date.UTC().Format(time.DateOnly)
+			}
+			if i, ok := byDay[day]; ok {
+				entries[i].Platforms = append(entries[i].Platforms, p.Name)
+				continue
+			}
+			byDay[day] = len(entries)
+			entries = append(entries, dto.ReleaseEntry{Game: summary, ReleaseDate: releaseDatePtr(date), Platforms: []string{p.Name}})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i].ReleaseDate, entries[j].ReleaseDate
+		if (a == nil) != (b == nil) {
+			return b == nil
+		}
+		if a != nil && !a.Equal(*b) {
+			return a.Before(*b)
+		}
+		return entries[i].Game.Title < entries[j].Game.Title
+	})
+	return entries
+}
+
+func releaseDatePtr(date time.Time) *time.Time {
+	if date.IsZero() {
+		return nil
+	}
+	return &date
+}
+
+// periodStart zamanın içinde bulunduğu ISO haftanın (pazartesi) ya da ayın UTC başlangıcını döndürür
+func periodStart(t time.Time, group string) time.Time {
+	t = t.UTC()
+	if group == ReleaseGroupWeek {
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	}
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func nextPeriod(start time.Time, group string) time.Time {
+	if group == ReleaseGroupWeek {
+		return start.AddDate(0, 0, 7)
+	}
+	return start.AddDate(0, 1, 0)
+}
+
+// periodLabel dönemi 2026-W42 (ISO hafta) ya da 2026-10 biçiminde adlandırır
+func periodLabel(start time.Time, group string) string {
+	if group == ReleaseGroupWeek {
+		year, week := start.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	}
+	return start.Format("2006-01")
+}
+
+// escapeICSText metin değerlerindeki ters bölü, virgül, noktalı virgül ve satır sonlarını RFC 5545'e göre kaçırır
+func escapeICSText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`).Replace(value)
+}
+
+// writeICSLine satırı CRLF ile yazar; 75 baytı aşan satırlar UTF-8 karakterleri bölünmeden boşlukla başlayan devam satırlarına katlanır
+func writeICSLine(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut-- // çok baytlı karakterin ortasından bölme
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74 // devam satırının başındaki boşluk da sayılır
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+// NewReleaseService çıkış takvimi servisini oluşturur
+func NewReleaseService(games repository.ProductRepository, logger *slog.Logger) ReleaseService {
+	return &DefaultReleaseService{Games: games, Log: logger}
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"api-steam/models"
+	"api-steam/repository"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// releaseRepository yalnızca GetReleases'i karşılayan sahte depodur; diğer metotlar çağrılırsa panik olur
+type releaseRepository struct {
+	repository.ProductRepository
+	games []models.Game
+	query repository.ReleaseQuery
+}
+
+func (r *releaseRepository) GetReleases(_ context.Context, query repository.ReleaseQuery) ([]models.Game, error) {
+	r.query = query
+	return r.games, nil
+}
+
+// unfoldICS katlanmış satırları birleştirip satırlara ayırır
+func unfoldICS(t *testing.T, ics string) []string {
+	t.Helper()
+	if !strings.HasSuffix(ics, "\r\n") {
+		t.Fatalf("takvim CRLF ile bitmiyor: %q", ics)
+	}
+	return strings.Split(strings.TrimSuffix(strings.ReplaceAll(ics, "\r\n ", ""), "\r\n"), "\r\n")
+}
+
+func TestEscapeICSText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Oyun", "Oyun"},
+		{"Kılıç, Kalkan; Büyü", `Kılıç\, Kalkan\; Büyü`},
+		{`C:\oyun`, `C:\\oyun`},
+		{"satır\r\nsatır\nsatır", `satır\nsatır\nsatır`},
+	}
+	for _, tt := range tests {
+		if got := escapeICSText(tt.in); got != tt.want {
+			t.Errorf("escapeICSText(%q) = %q, beklenen %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWriteICSLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{"kısa", "SUMMARY:Oyun"},
+		{"tam 75 bayt", "SUMMARY:" + strings.Repeat("a", 67)},
+		{"uzun ASCII", "DESCRIPTION:" + strings.Repeat("abc", 60)},
+		{"uzun çok baytlı", "DESCRIPTION:" + strings.Repeat("çığ", 50)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			writeICSLine(&b, tt.line)
+			for _, line := range strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n") {
+				if len(line) > 75 {
+					t.Errorf("satır 75 baytı aşıyor: %d", len(line))
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("satır UTF-8 karakterinin ortasından bölünmüş: %q", line)
+				}
+			}
+			if got := unfoldICS(t, b.String()); len(got) != 1 || got[0] != tt.line {
+				t.Errorf("katlama geri açılınca = %q, beklenen %q", got, tt.line)
+			}
+		})
+	}
+}
+
+func TestReleaseICS(t *testing.T) {
+	now := time.Now().UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 10)
+	first, second := primitive.NewObjectID(), primitive.NewObjectID()
+	repo := &releaseRepository{games: []models.Game{
+		{ID: first, Title: "Kılıç, Kalkan", ShortDescription: "Açık dünya; RPG", ReleaseDate: day, Platforms: []models.Platform{
+			{Name: "PC"}, {Name: "PS5"}, {Name: "Switch", ReleaseDate: day.AddDate(0, 1, 0)},
+		}},
+		{ID: second, Title: "Tarihsiz", Platforms: []models.Platform{{Name: "PC"}}},
+	}}
+	service := NewReleaseService(repo, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	ics, err := service.ReleaseICS(context.Background(), "")
+	if err != nil {
+		t.Fatalf("beklenmeyen hata: %v", err)
+	}
+	if !repo.query.From.Before(now) || !repo.query.To.After(now.AddDate(0, 11, 0)) {
+		t.Errorf("sorgu aralığı = %v - %v", repo.query.From, repo.query.To)
+	}
+	lines := unfoldICS(t, string(ics))
+	if lines[0] != "BEGIN:VCALENDAR" || lines[len(lines)-1] != "END:VCALENDAR" {
+		t.Errorf("takvim sarmalayıcısı hatalı: %q ... %q", lines[0], lines[len(lines)-1])
+	}
+	events := map[string]map[string]string{}
+	var current map[string]string
+	for _, line := range lines {
+		switch {
+		case line == "BEGIN:VEVENT":
+			current = map[string]string{}
+		case line == "END:VEVENT":
+			events[current["UID"]] = current
+			current = nil
+		case current != nil:
+			key, value, _ := strings.Cut(line, ":")
+			current[key] = value
+		}
+	}
+	if len(events) != 2 {
+		t.Fatalf("etkinlik sayısı = %d, beklenen 2 (tarihsiz oyun atlanmalı): %v", len(events), events)
+	}
+	tests := []struct {
+		uid         string
+		start, end  string
+		summary     string
+		description string
+	}{
+		{first.Hex() + "-" + day.Format("20060102") + "@api-steam", day.Format("20060102"), day.AddDate(0, 0, 1).Format("20060102"), `Kılıç\, Kalkan (PC\, PS5)`, `Açık dünya\; RPG`},
+		{first.Hex() + "-" + day.AddDate(0, 1, 0).Format("20060102") + "@api-steam", day.AddDate(0, 1, 0).Format("20060102"), day.AddDate(0, 1, 1).Format("20060102"), `Kılıç\, Kalkan (Switch)`, `Açık dünya\; RPG`},
+	}
+	for _, tt := range tests {
+		event, ok := events[tt.uid]
+		if !ok {
+			t.Errorf("%s etkinliği yok", tt.uid)
+			continue
+		}
+		if event["DTSTART;VALUE=DATE"] != tt.start || event["DTEND;VALUE=DATE"] != tt.end {
+			t.Errorf("%s: tarih = %s - %s, beklenen %s - %s", tt.uid, event["DTSTART;VALUE=DATE"], event["DTEND;VALUE=DATE"], tt.start, tt.end)
+		}
+		if event["SUMMARY"] != tt.summary || event["DESCRIPTION"] != tt.description {
+			t.Errorf("%s: özet = %q, açıklama = %q", tt.uid, event["SUMMARY"], event["DESCRIPTION"])
+		}
+	}
+
+	// platform verilince yalnızca o platformun çıkışı yazılır
+	ics, _ = service.ReleaseICS(context.Background(), "switch")
+	if got := strings.Count(string(ics), "BEGIN:VEVENT"); got != 1 || !strings.Contains(string(ics), "X-WR-CALNAME:Oyun çıkış takvimi (switch)") {
+		t.Errorf("platform filtreli takvim hatalı: %d etkinlik\n%s", got, ics)
+	}
+}
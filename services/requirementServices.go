@@ -0,0 +1,190 @@
+package services
+
+import (
+	"api-steam/dto"
+	"api-steam/hardware"
+	"api-steam/models"
+	"api-steam/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrInvalidHardwareSpec = errors.New("geçersiz donanım bilgisi: en az bir bileşen verilmeli, sayısal değerler negatif olamaz")
+	ErrUnknownHardware     = errors.New("donanım tanınmadı")
+	ErrRequirementsUnknown = errors.New("oyunun sistem gereksinimleri belirtilmemiş ya da okunamadı")
+)
+
+// RequirementService oyunların sistem gereksinimlerini kullanıcının bilgisayarıyla karşılaştırır
+type RequirementService interface {
+	RequirementsCheck(ctx context.Context, gameID primitive.ObjectID, req dto.HardwareSpecRequest) (dto.CanIRunResult, error) //Bilgisayar oyunu çalıştırır mı?
+	RequirementsReparse(ctx context.Context) (int, error)                                                                     //Tüm oyunların donanım değerlerini yeniden hesaplar
+}
+
+// DefaultRequirementService gereksinim metinlerini donanım tablosuyla okur
+type DefaultRequirementService struct {
+	Games    repository.ProductRepository
+	Hardware *hardware.Table
+	Log      *slog.Logger
+}
+
+// RequirementsCheck bilgisayarı oyunun minimum ve önerilen gereksinimleriyle karşılaştırır. Gereksinimler kayıtlı değerler
+// yerine metinlerden yeniden okunur, böylece donanım tablosundaki değişiklikler hemen yansır. İstekte verilmeyen ya da
+// tanınmayan bileşenler elemez, unknown listesinde döner.
+func (s *DefaultRequirementService) RequirementsCheck(ctx context.Context, gameID primitive.ObjectID, req dto.HardwareSpecRequest) (dto.CanIRunResult, error) {
+	ctx, span := tracer.Start(ctx, "RequirementService.RequirementsCheck")
+	defer span.End()
+	have, err := resolveHardware(s.Hardware, req, false)
+	if err != nil {
+		return dto.CanIRunResult{}, err
+	}
+	game, err := s.Games.GetByID(ctx, gameID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return dto.CanIRunResult{}, ErrGameNotFound
+	}
+	if err != nil {
+		return dto.CanIRunResult{}, recordError(span, err)
+	}
+	minimum, recommended := s.Hardware.Parse(game.SystemReqs.Minimum), s.Hardware.Parse(game.SystemReqs.Recommended)
+	if minimum == nil && recommended == nil {
+		return dto.CanIRunResult{}, ErrRequirementsUnknown
+	}
+	result := dto.CanIRunResult{Failing: []models.Shortfall{}, Spec: have, Minimum: minimum, Recommended: recommended}
+	unknown := map[string]bool{}
+	compare := func(need *models.HardwareSpec) []models.Shortfall {
+		if need == nil {
+			return nil
+		}
+		shortfalls, missing := hardware.Compare(have, *need)
+		for _, component := range missing {
+			if !unknown[component] {
+				unknown[component] = true
+				result.Unknown = append(result.Unknown, component)
+			}
+		}
+		return shortfalls
+	}
+	if failing := compare(minimum); len(failing) > 0 {
+		result.Verdict, result.Failing = models.VerdictBelowMinimum, failing
+		return result, nil
+	}
+	switch failing := compare(recommended); {
+	case recommended == nil:
+		result.Verdict = models.VerdictMeetsMinimum // önerilen belirtilmemişse minimumdan fazlası söylenemez
+	case len(failing) > 0:
+		result.Verdict, result.Failing = models.VerdictMeetsMinimum, failing
+	default:
+		result.Verdict = models.VerdictMeetsRecommended
+	}
+	return result, nil
+}
+
+// RequirementsReparse tüm oyunların gereksinim metinlerini donanım tablosuyla yeniden okur ve değişenleri yazar; tablo
+// güncellendiğinde kayıtlı değerlerin ve aramanın tabloyla uyumlu kalmasını sağlar. Değişen oyun sayısını döndürür.
+func (s *DefaultRequirementService) RequirementsReparse(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "RequirementService.RequirementsReparse")
+	defer span.End()
+	games, err := s.Games.GetAll(ctx)
+	if err != nil {
+		return 0, recordError(span, err)
+	}
+	changed := 0
+	for _, game := range games {
+		minimum, recommended := s.Hardware.Parse(game.SystemReqs.Minimum), s.Hardware.Parse(game.SystemReqs.Recommended)
+		if sameSpec(minimum, game.SystemReqs.Minimum.Spec) && sameSpec(recommended, game.SystemReqs.Recommended.Spec) {
+			continue
+		}
+		if err := s.Games.SetRequirementSpecs(ctx, game.ID, minimum, recommended); err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return changed, recordError(span, err)
+		}
+		changed++
+	}
+	if changed > 0 {
+		s.Log.InfoContext(ctx, "donanım gereksinimleri yeniden hesaplandı", "games", len(games), "changed", changed, "models", s.Hardware.Len())
+	}
+	return changed, nil
+}
+
+// withRequirementSpecs gereksinim metinlerinden donanım değerlerini hesaplar; istekteki değerler yok sayılır
+func (s *DefaultProductService) withRequirementSpecs(game *models.Game) {
+	game.SystemReqs.Minimum.Spec = s.Hardware.Parse(game.SystemReqs.Minimum)
+	game.SystemReqs.Recommended.Spec = s.Hardware.Parse(game.SystemReqs.Recommended)
+}
+
+// refreshRequirementSpecs PATCH'ten sonra oyunun donanım değerlerini güncel metinlerden yeniden hesaplar
+func (s *DefaultProductService) refreshRequirementSpecs(ctx context.Context, id primitive.ObjectID) error {
+	game, err := s.Repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	minimum, recommended := s.Hardware.Parse(game.SystemReqs.Minimum), s.Hardware.Parse(game.SystemReqs.Recommended)
+	if sameSpec(minimum, game.SystemReqs.Minimum.Spec) && sameSpec(recommended, game.SystemReqs.Recommended.Spec) {
+		return nil
+	}
+	return s.Repo.SetRequirementSpecs(ctx, id, minimum, recommended)
+}
+
+// stripRequirementSpecs PATCH güncellemelerinden hesaplanan donanım değerlerini çıkarır ve sistem gereksinimlerinin
+// değişip değişmediğini döndürür
+func stripRequirementSpecs(updates map[string]interface{}) bool {
+	changed := false
+	for field, value := range updates {
+		if !strings.HasPrefix(field, "system_requirements") {
+			continue
+		}
+		if strings.HasPrefix(field, "system_requirements.minimum.spec") || strings.HasPrefix(field, "system_requirements.recommended.spec") {
+			delete(updates, field)
+			continue
+		}
+		changed = true
+		nested, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		delete(nested, "spec") // system_requirements.minimum / .recommended
+		for _, level := range []string{"minimum", "recommended"} {
+			if requirements, ok := nested[level].(map[string]interface{}); ok {
+				delete(requirements, "spec") // system_requirements
+			}
+		}
+	}
+	return changed
+}
+
+// resolveHardware istekteki bilgisayarı doğrular ve donanım tablosuyla tanınan haline çevirir. strict açıksa tanınmayan
+// işletim sistemi, işlemci ya da ekran kartı ErrUnknownHardware döner; kapalıysa bu bileşenler bilinmiyor sayılır.
+func resolveHardware(table *hardware.Table, req dto.HardwareSpecRequest, strict bool) (models.HardwareSpec, error) {
+	if req.Empty() || req.MemoryGB < 0 || req.StorageGB < 0 || req.DirectX < 0 {
+		return models.HardwareSpec{}, ErrInvalidHardwareSpec
+	}
+	spec := table.Resolve(models.HardwareSpec{OS: req.OS, CPU: req.CPU, GPU: req.GPU, MemoryGB: req.MemoryGB, StorageGB: req.StorageGB, DirectX: req.DirectX})
+	if strict {
+		switch {
+		case strings.TrimSpace(req.OS) != "" && spec.OS == "":
+			return models.HardwareSpec{}, fmt.Errorf("%w: işletim sistemi %q (windows, macos veya linux olmalıdır)", ErrUnknownHardware, req.OS)
+		case strings.TrimSpace(req.CPU) != "" && spec.CPUTier == 0:
+			return models.HardwareSpec{}, fmt.Errorf("%w: işlemci %q donanım tablosunda yok", ErrUnknownHardware, req.CPU)
+		case strings.TrimSpace(req.GPU) != "" && spec.GPUTier == 0:
+			return models.HardwareSpec{}, fmt.Errorf("%w: ekran kartı %q donanım tablosunda yok", ErrUnknownHardware, req.GPU)
+		}
+	}
+	return spec, nil
+}
+
+// sameSpec iki donanım değerinin (nil dahil) aynı olup olmadığını döndürür
+func sameSpec(a, b *models.HardwareSpec) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// NewRequirementService sistem gereksinimi servisini oluşturur
+func NewRequirementService(games repository.ProductRepository, table *hardware.Table, logger *slog.Logger) RequirementService {
+	return &DefaultRequirementService{Games: games, Hardware: table, Log: logger}
+}
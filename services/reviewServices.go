@@ -0,0 +1,363 @@
+package services
+
+import (
+	"api-steam/auth"
+	"api-steam/dto"
+	"api-steam/models"
+	"api-steam/moderation"
+	"api-steam/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrInvalidReview       = errors.New("geçersiz yorum")
+	ErrAlreadyReviewed     = errors.New("bu oyuna zaten yorum yazdınız")
+	ErrReviewNotFound      = errors.New("yorum bulunamadı")
+	ErrOwnReview           = errors.New("kendi yorumunuza oy veremezsiniz")
+	ErrInvalidReviewStatus = errors.New("geçersiz yorum durumu")
+)
+
+const (
+	minReviewScore     = 1
+	maxReviewScore     = 10
+	maxReviewTextRunes = 8000
+	maxReviewPageSize  = 100
+)
+
+// ReviewService yorumları yönetir ve oyunun Rating değerlerini yorumlarla tutarlı tutar
+type ReviewService interface {
+	ReviewList(ctx context.Context, gameID primitive.ObjectID, query repository.ReviewQuery) ([]models.Review, error)          //Oyunun yayınlanmış yorumları
+	ReviewCreate(ctx context.Context, userID, gameID primitive.ObjectID, req dto.ReviewRequest) (models.Review, error)         //Yorum yazar
+	ReviewUpdateOwn(ctx context.Context, userID, gameID primitive.ObjectID, req dto.ReviewRequest) (models.Review, error)      //Kendi yorumunu düzenler
+	ReviewDeleteOwn(ctx context.Context, userID, gameID primitive.ObjectID) error                                              //Kendi yorumunu siler
+	ReviewModerate(ctx context.Context, id primitive.ObjectID, status, reason string) (before, after models.Review, err error) //Moderatör kararını uygular
+	ReviewFlag(ctx context.Context, id primitive.ObjectID, flag string) (models.Review, error)                                 //Yayındaki yorumu moderasyon kuyruğuna alır
+	ReviewVote(ctx context.Context, userID, reviewID primitive.ObjectID, helpful bool) error                                   //Faydalı/faydalı değil oyu
+	ReviewUnvote(ctx context.Context, userID, reviewID primitive.ObjectID) error                                               //Oyu geri alır
+	ReviewRecomputeRating(ctx context.Context, gameID primitive.ObjectID) (models.RatingTotals, error)                         //Rating'i yorumlardan baştan hesaplar
+}
+
+// DefaultReviewService yorumları ReviewRepository'de, Rating toplamlarını ProductRepository üzerinden oyun belgesinde tutar
+type DefaultReviewService struct {
+	Repo    repository.ReviewRepository
+	Games   repository.ProductRepository
+	Library repository.LibraryRepository // Yorumu yazanın oyuna sahip olup olmadığı buradan anlaşılır
+	Filter  *moderation.Filter           // Kelime listesi ve bağlantı denetimi; işaretlenen yorumlar onay bekler
+	Log     *slog.Logger
+}
+
+// ReviewList oyunun yorumlarını döndürür
+func (s *DefaultReviewService) ReviewList(ctx context.Context, gameID primitive.ObjectID, query repository.ReviewQuery) ([]models.Review, error) {
+	ctx, span := tracer.Start(ctx, "ReviewService.ReviewList")
+	defer span.End()
+	if query.Limit <= 0 || query.Limit > maxReviewPageSize {
+		query.Limit = maxReviewPageSize
+	}
+	reviews, err := s.Repo.ListByGame(ctx, gameID, query)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	return reviews, nil
+}
+
+// ReviewCreate kullanıcının oyuna yorumunu kaydeder ve oyunun Rating değerlerine ekler
+func (s *DefaultReviewService) ReviewCreate(ctx context.Context, userID, gameID primitive.ObjectID, req dto.ReviewRequest) (models.Review, error) {
+	ctx, span := tracer.Start(ctx, "ReviewService.ReviewCreate")
+	defer span.End()
+	if err := validateReview(req); err != nil {
+		return models.Review{}, err
+	}
+	if _, err := s.Games.GetByID(ctx, gameID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.Review{}, ErrGameNotFound
+		}
+		return models.Review{}, recordError(span, err)
+	}
+	status, flags := models.ReviewPublished, s.Filter.Check(req.Text)
+	if len(flags) > 0 {
+		status = models.ReviewPending
+		s.Log.InfoContext(ctx, "yorum otomatik olarak moderasyona alındı", "game_id", gameID, "user_id", userID, "flags", flags)
+	}
+	review, err := s.Repo.Insert(ctx, models.Review{
+		GameID:          gameID,
+		UserID:          userID,
+		Score:           req.Score,
+		Recommended:     *req.Recommended,
+		Text:            req.Text,
+		PlaytimeMinutes: req.PlaytimeMinutes,
+		VerifiedOwner:   s.ownsGame(ctx, userID, gameID),
+		Status:          status,
+		Flags:           flags,
+	})
+	if errors.Is(err, repository.ErrDuplicate) {
+		return models.Review{}, ErrAlreadyReviewed
+	}
+	if err != nil {
+		return models.Review{}, recordError(span, err)
+	}
+	s.applyDelta(ctx, gameID, review.Totals())
+	return review, nil
+}
+
+// ReviewUpdateOwn kullanıcının kendi yorumunu düzenler; Rating yalnızca eski ve yeni katkı arasındaki fark kadar değişir
+func (s *DefaultReviewService) ReviewUpdateOwn(ctx context.Context, userID, gameID primitive.ObjectID, req dto.ReviewRequest) (models.Review, error) {
+	ctx, span := tracer.Start(ctx, "ReviewService.ReviewUpdateOwn")
+	defer span.End()
+	if err := validateReview(req); err != nil {
+		return models.Review{}, err
+	}
+	existing, err := s.Repo.GetByUserAndGame(ctx, userID, gameID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.Review{}, ErrReviewNotFound
+	}
+	if err != nil {
+		return models.Review{}, recordError(span, err)
+	}
+	status, flags := editedStatus(existing, s.Filter.Check(req.Text))
+	verified := s.ownsGame(ctx, userID, gameID)
+	before, after, err := s.Repo.Update(ctx, existing.ID, repository.ReviewChanges{
+		Score:           &req.Score,
+		Recommended:     req.Recommended,
+		Text:            &req.Text,
+		PlaytimeMinutes: &req.PlaytimeMinutes,
+		VerifiedOwner:   &verified,
+		Status:          &status,
+		Flags:           &flags,
+	})
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.Review{}, ErrReviewNotFound
+	}
+	if err != nil {
+		return models.Review{}, recordError(span, err)
+	}
+	s.applyDelta(ctx, gameID, after.Totals().Sub(before.Totals()))
+	return after, nil
+}
+
+// ReviewDeleteOwn kullanıcının kendi yorumunu siler ve katkısını Rating'den çıkarır
+func (s *DefaultReviewService) ReviewDeleteOwn(ctx context.Context, userID, gameID primitive.ObjectID) error {
+	ctx, span := tracer.Start(ctx, "ReviewService.ReviewDeleteOwn")
+	defer span.End()
+	existing, err := s.Repo.GetByUserAndGame(ctx, userID, gameID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrReviewNotFound
+	}
+	if err != nil {
+		return recordError(span, err)
+	}
+	deleted, err := s.Repo.Delete(ctx, existing.ID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrReviewNotFound
+	}
+	if err != nil {
+		return recordError(span, err)
+	}
+	s.applyDelta(ctx, gameID, models.RatingTotals{}.Sub(deleted.Totals()))
+	return nil
+}
+
+// ReviewModerate moderatör kararını uygular: durumu, gerekçeyi ve kararı vereni kaydeder.
+// Yayından kalkan yorum Rating'den çıkar, yayına giren eklenir.
+func (s *DefaultReviewService) ReviewModerate(ctx context.Context, id primitive.ObjectID, status, reason string) (models.Review, models.Review, error) {
+	ctx, span := tracer.Start(ctx, "ReviewService.ReviewModerate")
+	defer span.End()
+	switch status {
+	case models.ReviewPublished, models.ReviewPending, models.ReviewHidden, models.ReviewRejected:
+	default:
+		return models.Review{}, models.Review{}, ErrInvalidReviewStatus
+	}
+	moderator := "system"
+	if principal, ok := auth.FromContext(ctx); ok {
+		moderator = principal.Subject
+	}
+	// Karar verilen yorumun işaretleri ve şikayetleri kapanır; önceki işaretler denetim kaydında kalır
+	now, noReports, noFlags := time.Now(), 0, []string{}
+	before, after, err := s.Repo.Update(ctx, id, repository.ReviewChanges{
+		Status:           &status,
+		Flags:            &noFlags,
+		ReportCount:      &noReports,
+		ModerationReason: &reason,
+		ModeratedBy:      &moderator,
+		ModeratedAt:      &now,
+	})
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.Review{}, models.Review{}, ErrReviewNotFound
+	}
+	if err != nil {
+		return models.Review{}, models.Review{}, recordError(span, err)
+	}
+	s.applyDelta(ctx, after.GameID, after.Totals().Sub(before.Totals()))
+	return before, after, nil
+}
+
+// ReviewFlag yayındaki bir yorumu verilen nedenle onay bekleyen duruma alır; yorum yayında değilse olduğu gibi döner
+func (s *DefaultReviewService) ReviewFlag(ctx context.Context, id primitive.ObjectID, flag string) (models.Review, error) {
+	ctx, span := tracer.Start(ctx, "ReviewService.ReviewFlag")
+	defer span.End()
+	review, err := s.Repo.GetByID(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.Review{}, ErrReviewNotFound
+	}
+	if err != nil {
+		return models.Review{}, recordError(span, err)
+	}
+	if review.Status != models.ReviewPublished {
+		return review, nil
+	}
+	status, flags := models.ReviewPending, appendFlag(review.Flags, flag)
+	before, after, err := s.Repo.Update(ctx, id, repository.ReviewChanges{Status: &status, Flags: &flags})
+	if err != nil {
+		return models.Review{}, recordError(span, err)
+	}
+	s.applyDelta(ctx, after.GameID, after.Totals().Sub(before.Totals()))
+	return after, nil
+}
+
+// ReviewVote kullanıcının yoruma oyunu kaydeder; oy değiştirilirse eski oy sayaçtan düşülür
+func (s *DefaultReviewService) ReviewVote(ctx context.Context, userID, reviewID primitive.ObjectID, helpful bool) error {
+	ctx, span := tracer.Start(ctx, "ReviewService.ReviewVote")
+	defer span.End()
+	review, err := s.Repo.GetByID(ctx, reviewID)
+	if errors.Is(err, repository.ErrNotFound) || (err == nil && !review.Counted()) {
+		return ErrReviewNotFound
+	}
+	if err != nil {
+		return recordError(span, err)
+	}
+	if review.UserID == userID {
+		return ErrOwnReview
+	}
+	previous, err := s.Repo.Vote(ctx, models.ReviewVote{ReviewID: reviewID, UserID: userID, Helpful: helpful})
+	if err != nil {
+		return recordError(span, err)
+	}
+	if previous != nil && previous.Helpful == helpful {
+		return nil
+	}
+	dHelpful, dNotHelpful := voteDelta(helpful, 1)
+	if previous != nil {
+		h, n := voteDelta(previous.Helpful, -1)
+		dHelpful, dNotHelpful = dHelpful+h, dNotHelpful+n
+	}
+	return recordError(span, s.Repo.AdjustVotes(ctx, reviewID, dHelpful, dNotHelpful))
+}
+
+// ReviewUnvote kullanıcının yoruma verdiği oyu geri alır
+func (s *DefaultReviewService) ReviewUnvote(ctx context.Context, userID, reviewID primitive.ObjectID) error {
+	ctx, span := tracer.Start(ctx, "ReviewService.ReviewUnvote")
+	defer span.End()
+	previous, err := s.Repo.Unvote(ctx, reviewID, userID)
+	if err != nil {
+		return recordError(span, err)
+	}
+	if previous == nil {
+		return nil
+	}
+	dHelpful, dNotHelpful := voteDelta(previous.Helpful, -1)
+	return recordError(span, s.Repo.AdjustVotes(ctx, reviewID, dHelpful, dNotHelpful))
+}
+
+// ReviewRecomputeRating oyunun Rating toplamlarını yayınlanmış yorumlardan baştan hesaplayıp yazar
+func (s *DefaultReviewService) ReviewRecomputeRating(ctx context.Context, gameID primitive.ObjectID) (models.RatingTotals, error) {
+	ctx, span := tracer.Start(ctx, "ReviewService.ReviewRecomputeRating")
+	defer span.End()
+	totals, err := s.Repo.Totals(ctx, gameID)
+	if err != nil {
+		return models.RatingTotals{}, recordError(span, err)
+	}
+	if err := s.Games.SetRatingTotals(ctx, gameID, totals); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.RatingTotals{}, ErrGameNotFound
+		}
+		return models.RatingTotals{}, recordError(span, err)
+	}
+	s.Log.InfoContext(ctx, "oyun puanı yeniden hesaplandı", "game_id", gameID, "reviews", totals.Count)
+	return totals, nil
+}
+
+// applyDelta Rating farkını oyuna uygular. Yorum zaten kaydedildiği için hata isteği başarısız saymaz;
+// loglanır ve ReviewRecomputeRating ile onarılabilir.
+func (s *DefaultReviewService) applyDelta(ctx context.Context, gameID primitive.ObjectID, delta models.RatingTotals) {
+	if delta.IsZero() {
+		return
+	}
+	if err := s.Games.ApplyRatingDelta(ctx, gameID, delta); err != nil {
+		s.Log.ErrorContext(ctx, "oyun puanı güncellenemedi, yeniden hesaplama gerekli", "game_id", gameID, "error", err)
+	}
+}
+
+// ownsGame yorumu yazanın oyuna sahip olup olmadığını döndürür; kütüphane okunamazsa yorum engellenmez, işaretsiz kaydedilir
+func (s *DefaultReviewService) ownsGame(ctx context.Context, userID, gameID primitive.ObjectID) bool {
+	_, err := s.Library.Get(ctx, userID, gameID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		s.Log.WarnContext(ctx, "sahiplik kontrol edilemedi", "user_id", userID, "game_id", gameID, "error", err)
+	}
+	return err == nil
+}
+
+// editedStatus düzenlenen yorumun yeni durumunu ve işaretlerini belirler. Yayındaki yorum yeni metinde sorun varsa kuyruğa alınır;
+// yalnızca filtre yüzünden bekleyen yorum temizlenirse yayına döner. Moderatörün gizlediği veya reddettiği yorum düzenlemeyle geri gelmez.
+func editedStatus(existing models.Review, flags []string) (string, []string) {
+	reported := false
+	for _, f := range existing.Flags {
+		if f == moderation.FlagReports {
+			reported = true
+		}
+	}
+	if reported {
+		flags = appendFlag(flags, moderation.FlagReports)
+	}
+	switch {
+	case existing.Status == models.ReviewPublished && len(flags) > 0:
+		return models.ReviewPending, flags
+	case existing.Status == models.ReviewPending && len(flags) == 0 && existing.ModeratedAt == nil:
+		return models.ReviewPublished, flags
+	}
+	return existing.Status, flags
+}
+
+// appendFlag işareti listede yoksa ekler
+func appendFlag(flags []string, flag string) []string {
+	out := append([]string{}, flags...)
+	for _, f := range out {
+		if f == flag {
+			return out
+		}
+	}
+	return append(out, flag)
+}
+
+// voteDelta oyun faydalı/faydalı değil sayaçlarına etkisini döndürür
+func voteDelta(helpful bool, sign int) (int, int) {
+	if helpful {
+		return sign, 0
+	}
+	return 0, sign
+}
+
+// validateReview puan, öneri ve metin sınırlarını kontrol eder
+func validateReview(req dto.ReviewRequest) error {
+	switch {
+	case req.Score < minReviewScore || req.Score > maxReviewScore:
+		return fmt.Errorf("%w: puan %d ile %d arasında olmalıdır", ErrInvalidReview, minReviewScore, maxReviewScore)
+	case req.Recommended == nil:
+		return fmt.Errorf("%w: recommended alanı gereklidir", ErrInvalidReview)
+	case utf8.RuneCountInString(req.Text) > maxReviewTextRunes:
+		return fmt.Errorf("%w: metin en fazla %d karakter olabilir", ErrInvalidReview, maxReviewTextRunes)
+	case req.PlaytimeMinutes < 0:
+		return fmt.Errorf("%w: oynama süresi negatif olamaz", ErrInvalidReview)
+	}
+	return nil
+}
+
+// NewReviewService yorum servisini oluşturur
+func NewReviewService(repo repository.ReviewRepository, games repository.ProductRepository, library repository.LibraryRepository, filter *moderation.Filter, logger *slog.Logger) ReviewService {
+	return &DefaultReviewService{Repo: repo, Games: games, Library: library, Filter: filter, Log: logger}
+}
@@ -0,0 +1,353 @@
+package services
+
+import (
+	"api-steam/dto"
+	"api-steam/models"
+	"api-steam/repository"
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrInvalidSimilarCuration = errors.New("geçersiz düzenleme: ID'ler mevcut oyunları göstermeli, oyunun kendisini içermemeli ve bir oyun hem sabitlenip hem çıkarılamaz")
+
+// Benzerlik puanı ağırlıkları; toplamları 1'dir
+const (
+	similarGenreWeight     = 0.35
+	similarTagWeight       = 0.30
+	similarFeatureWeight   = 0.15
+	similarDeveloperWeight = 0.10
+	similarPriceWeight     = 0.10
+	minSimilarScore        = 0.1 // Bunun altındaki puanlar saklanmaz
+	similarSaveBatch       = 500 // Hesaplanan listeler bu kadar oyunda bir toplu yazılır
+)
+
+// SimilarService oyunlar arasındaki benzerliği hesaplar, benzer oyun listelerini editör düzenlemeleriyle birlikte döndürür
+type SimilarService interface {
+	SimilarList(ctx context.Context, gameID primitive.ObjectID, limit int) ([]dto.SimilarGame, error)                          //Sabitlenenler önde, çıkarılanlar hariç
+	SimilarCurate(ctx context.Context, gameID primitive.ObjectID, req dto.SimilarCurationRequest) (models.SimilarGames, error) //Sabitleme ve çıkarma listelerini değiştirir
+	SimilarRecompute(ctx context.Context) (int, error)                                                                         //Tüm oyunların benzer oyunlarını yeniden hesaplar
+}
+
+// DefaultSimilarService listeleri SimilarRepository'de tutar; oyunları ProductRepository'den okur
+type DefaultSimilarService struct {
+	Repo  repository.SimilarRepository
+	Games repository.ProductRepository
+	Log   *slog.Logger
+	TopK  int // Oyun başına saklanan ve döndürülen en fazla benzer oyun
+}
+
+// SimilarList oyunun benzer oyunlarını tam oyun bilgileriyle döndürür: önce editörün sabitledikleri, ardından puana göre
+// hesaplananlar. Çıkarılan ve silinmiş oyunlar listelenmez.
+func (s *DefaultSimilarService) SimilarList(ctx context.Context, gameID primitive.ObjectID, limit int) ([]dto.SimilarGame, error) {
+	ctx, span := tracer.Start(ctx, "SimilarService.SimilarList")
+	defer span.End()
+	if limit <= 0 || limit > s.TopK {
+		limit = s.TopK
+	}
+	if _, err := s.Games.GetByID(ctx, gameID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrGameNotFound
+		}
+		return nil, recordError(span, err)
+	}
+	similar, err := s.Repo.Get(ctx, gameID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, recordError(span, err)
+	}
+	excluded := map[primitive.ObjectID]bool{}
+	for _, id := range similar.Excluded {
+		excluded[id] = true
+	}
+	scores := make(map[primitive.ObjectID]models.SimilarScore, len(similar.Computed))
+	for _, sc := range similar.Computed {
+		scores[sc.GameID] = sc
+	}
+	var entries []dto.SimilarGame
+	seen := map[primitive.ObjectID]bool{}
+	var ids []primitive.ObjectID
+	for _, id := range similar.Pinned {
+		if !seen[id] {
+			seen[id] = true
+			entries = append(entries, dto.SimilarGame{Game: models.Game{ID: id}, Score: scores[id].Score, Reasons: scores[id].Reasons, Pinned: true})
+			ids = append(ids, id)
+		}
+	}
+	for _, sc := range similar.Computed {
+		if !seen[sc.GameID] && !excluded[sc.GameID] {
+			seen[sc.GameID] = true
+			entries = append(entries, dto.SimilarGame{Game: models.Game{ID: sc.GameID}, Score: sc.Score, Reasons: sc.Reasons})
+			ids = append(ids, sc.GameID)
+		}
+	}
+	games, err := s.Games.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	byID := make(map[primitive.ObjectID]models.Game, len(games))
+	for _, game := range games {
+		byID[game.ID] = game
+	}
+	result := make([]dto.SimilarGame, 0, limit)
+	for _, entry := range entries {
+		game, ok := byID[entry.Game.ID]
+		if !ok {
+			continue // hesaplamadan sonra silinmiş
+		}
+		if age, _ := game.Rating.MinimumAge(); !contentAllowed(ctx, age) {
+			continue
+		}
+		entry.Game = localize(ctx, game)
+		result = append(result, entry)
+		if len(result) == limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+// SimilarCurate oyunun sabitlenen ve çıkarılan oyun listelerini doğrulayıp kaydeder
+func (s *DefaultSimilarService) SimilarCurate(ctx context.Context, gameID primitive.ObjectID, req dto.SimilarCurationRequest) (models.SimilarGames, error) {
+	ctx, span := tracer.Start(ctx, "SimilarService.SimilarCurate")
+	defer span.End()
+	if _, err := s.Games.GetByID(ctx, gameID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.SimilarGames{}, ErrGameNotFound
+		}
+		return models.SimilarGames{}, recordError(span, err)
+	}
+	pinned, err := parseObjectIDs(req.Pinned)
+	if err != nil || len(pinned) > s.TopK {
+		return models.SimilarGames{}, ErrInvalidSimilarCuration
+	}
+	excluded, err := parseObjectIDs(req.Excluded)
+	if err != nil {
+		return models.SimilarGames{}, ErrInvalidSimilarCuration
+	}
+	all := map[primitive.ObjectID]bool{}
+	for _, id := range append(append([]primitive.ObjectID{}, pinned...), excluded...) {
+		if id == gameID || all[id] {
+			return models.SimilarGames{}, ErrInvalidSimilarCuration
+		}
+		all[id] = true
+	}
+	ids := make([]primitive.ObjectID, 0, len(all))
+	for id := range all {
+		ids = append(ids, id)
+	}
+	games, err := s.Games.GetByIDs(ctx, ids)
+	if err != nil {
+		return models.SimilarGames{}, recordError(span, err)
+	}
+	if len(games) != len(ids) {
+		return models.SimilarGames{}, ErrInvalidSimilarCuration
+	}
+	similar, err := s.Repo.SetCuration(ctx, gameID, pinned, excluded)
+	if err != nil {
+		return models.SimilarGames{}, recordError(span, err)
+	}
+	s.Log.InfoContext(ctx, "benzer oyun listesi düzenlendi", "game_id", gameID, "pinned", len(pinned), "excluded", len(excluded))
+	return similar, nil
+}
+
+// SimilarRecompute her oyun için ortak tür, etiket, özellik, geliştirici ve fiyat aralığından benzerlik puanı hesaplar ve
+// en yüksek TopK oyunu kaydeder. Yalnızca en az bir tür, etiket, özellik veya geliştiriciyi paylaşan oyunlar karşılaştırılır.
+// Hesaplanan oyun sayısını döndürür.
+func (s *DefaultSimilarService) SimilarRecompute(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "SimilarService.SimilarRecompute")
+	defer span.End()
+	start := time.Now()
+	games, err := s.Games.GetAll(ctx)
+	if err != nil {
+		return 0, recordError(span, err)
+	}
+	profiles := make([]similarProfile, len(games))
+	index := map[string][]int{} // özellik -> o özelliğe sahip oyunlar
+	for i, game := range games {
+		profiles[i] = newSimilarProfile(game)
+		for _, token := range profiles[i].tokens() {
+			index[token] = append(index[token], i)
+		}
+	}
+	pending := map[primitive.ObjectID][]models.SimilarScore{}
+	for i := range games {
+		candidates := map[int]bool{}
+		for _, token := range profiles[i].tokens() {
+			for _, j := range index[token] {
+				candidates[j] = true
+			}
+		}
+		scores := []models.SimilarScore{}
+		for j := range candidates {
+			if !similarCandidate(games[i], games[j]) {
+				continue
+			}
+			if score := profiles[i].score(profiles[j]); score.Score >= minSimilarScore {
+				score.GameID = games[j].ID
+				scores = append(scores, score)
+			}
+		}
+		sort.Slice(scores, func(a, b int) bool {
+			if scores[a].Score != scores[b].Score {
+				return scores[a].Score > scores[b].Score
+			}
+			return scores[a].GameID.Hex() < scores[b].GameID.Hex()
+		})
+		if len(scores) > s.TopK {
+			scores = scores[:s.TopK]
+		}
+		pending[games[i].ID] = scores
+		if len(pending) >= similarSaveBatch {
+			if err := s.Repo.SaveComputed(ctx, pending, start); err != nil {
+				return 0, recordError(span, err)
+			}
+			pending = map[primitive.ObjectID][]models.SimilarScore{}
+		}
+	}
+	if err := s.Repo.SaveComputed(ctx, pending, start); err != nil {
+		return 0, recordError(span, err)
+	}
+	stale, err := s.Repo.DeleteComputedBefore(ctx, start)
+	if err != nil {
+		return 0, recordError(span, err)
+	}
+	s.Log.InfoContext(ctx, "benzer oyunlar yeniden hesaplandı", "games", len(games), "stale", stale, "duration", time.Since(start).String())
+	return len(games), nil
+}
+
+// similarCandidate aynı oyunu, paketleri, kaldırılmış oyunları ve aynı ana oyuna bağlı DLC/sürümleri benzer oyun olarak önermez
+func similarCandidate(game, candidate models.Game) bool {
+	if candidate.ID == game.ID || candidate.Kind == models.GameKindBundle || candidate.Status == models.GameStatusRemoved {
+		return false
+	}
+	root := func(g models.Game) primitive.ObjectID {
+		if g.ParentID != nil {
+			return *g.ParentID
+		}
+		return g.ID
+	}
+	return root(game) != root(candidate)
+}
+
+// similarProfile bir oyunun benzerlikte kullanılan, küçük harfe çevrilmiş özellik kümeleridir
+type similarProfile struct {
+	genres, tags, features, developers map[string]bool
+	priceTier                          string
+}
+
+func newSimilarProfile(game models.Game) similarProfile {
+	p := similarProfile{
+		genres:     map[string]bool{},
+		tags:       normalizedSet(game.Tags),
+		features:   normalizedSet(game.Features),
+		developers: map[string]bool{},
+		priceTier:  priceTier(game.Price.Effective()),
+	}
+	for _, g := range game.Genres {
+		p.genres[strings.ToLower(strings.TrimSpace(g.Name))] = true
+	}
+	for _, d := range game.Developers {
+		p.developers[strings.ToLower(strings.TrimSpace(d.Name))] = true
+	}
+	delete(p.genres, "")
+	delete(p.developers, "")
+	return p
+}
+
+// tokens aday oyunları bulmak için kullanılan, türüyle öneklenmiş özelliklerdir; fiyat aralığı tek başına aday göstermez
+func (p similarProfile) tokens() []string {
+	var out []string
+	for prefix, set := range map[string]map[string]bool{"g:": p.genres, "t:": p.tags, "f:": p.features, "d:": p.developers} {
+		for v := range set {
+			out = append(out, prefix+v)
+		}
+	}
+	return out
+}
+
+// score iki profilin ağırlıklı benzerliğini ve katkı veren özellikleri döndürür; kümeler Jaccard benzerliğiyle karşılaştırılır
+func (p similarProfile) score(other similarProfile) models.SimilarScore {
+	var result models.SimilarScore
+	add := func(reason string, weight, similarity float64) {
+		if similarity > 0 {
+			result.Score += weight * similarity
+			result.Reasons = append(result.Reasons, reason)
+		}
+	}
+	add(models.SimilarByGenres, similarGenreWeight, jaccard(p.genres, other.genres))
+	add(models.SimilarByTags, similarTagWeight, jaccard(p.tags, other.tags))
+	add(models.SimilarByFeatures, similarFeatureWeight, jaccard(p.features, other.features))
+	if jaccard(p.developers, other.developers) > 0 {
+		add(models.SimilarByDevelopers, similarDeveloperWeight, 1)
+	}
+	if p.priceTier == other.priceTier {
+		add(models.SimilarByPriceTier, similarPriceWeight, 1)
+	}
+	result.Score = math.Round(result.Score*1000) / 1000
+	return result
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for v := range a {
+		if b[v] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+func normalizedSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		if v = strings.ToLower(strings.TrimSpace(v)); v != "" {
+			set[v] = true
+		}
+	}
+	return set
+}
+
+// priceTier güncel fiyatı kaba bir aralığa yerleştirir; para birimi dikkate alınmaz
+func priceTier(price float64) string {
+	switch {
+	case price <= 0:
+		return "free"
+	case price < 10:
+		return "budget"
+	case price < 30:
+		return "mid"
+	case price < 60:
+		return "premium"
+	}
+	return "deluxe"
+}
+
+// parseObjectIDs hex ID listesini ObjectID'lere çevirir
+func parseObjectIDs(hexes []string) ([]primitive.ObjectID, error) {
+	ids := make([]primitive.ObjectID, 0, len(hexes))
+	for _, hex := range hexes {
+		id, err := primitive.ObjectIDFromHex(hex)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// NewSimilarService benzer oyun servisini oluşturur
+func NewSimilarService(repo repository.SimilarRepository, games repository.ProductRepository, logger *slog.Logger, topK int) SimilarService {
+	if topK <= 0 {
+		topK = 10
+	}
+	return &DefaultSimilarService{Repo: repo, Games: games, Log: logger, TopK: topK}
+}
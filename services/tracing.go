@@ -0,0 +1,19 @@
+package services
+
+import (
+	"api-steam/telemetry"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = telemetry.Tracer("api-steam/services")
+
+// recordError hata nil değilse span'e işler ve hatayı aynen döndürür
+func recordError(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
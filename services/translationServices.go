@@ -0,0 +1,203 @@
+package services
+
+import (
+	"api-steam/dto"
+	"api-steam/models"
+	"api-steam/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrUnsupportedLocale        = errors.New("desteklenmeyen dil")
+	ErrDefaultLocaleTranslation = errors.New("varsayılan dildeki metinler oyunun kendi alanlarıdır; PUT veya PATCH /api/game/:id ile güncellenir")
+	ErrEmptyTranslation         = errors.New("çeviride en az bir alan (title, description, short_description) dolu olmalıdır")
+	ErrTranslationNotFound      = errors.New("oyunun bu dilde çevirisi yok")
+)
+
+// TranslationService oyunların başlık ve açıklamalarının diğer dillerdeki çevirilerini yönetir
+type TranslationService interface {
+	TranslationList(ctx context.Context, gameID primitive.ObjectID) (dto.GameTranslations, error)                                         //Oyunun tüm dillerdeki metinleri ve eksikleri
+	TranslationSet(ctx context.Context, gameID primitive.ObjectID, locale string, req dto.TranslationRequest) (models.Translation, error) //Bir dildeki çeviriyi ekler ya da değiştirir
+	TranslationDelete(ctx context.Context, gameID primitive.ObjectID, locale string) error                                                //Bir dildeki çeviriyi siler
+	TranslationMissing(ctx context.Context, locale string) ([]dto.MissingTranslation, error)                                              //Eksik çeviri raporu
+}
+
+// DefaultTranslationService çevirileri oyun belgesinin translations alanında tutar; değişiklikler denetim kaydına yazılır
+type DefaultTranslationService struct {
+	Games   repository.ProductRepository
+	Audit   AuditService
+	Locales Locales
+	Log     *slog.Logger
+}
+
+// TranslationList oyunun varsayılan dildeki metinlerini, çevirilerini ve desteklenen dillerdeki eksik alanlarını döndürür
+func (s *DefaultTranslationService) TranslationList(ctx context.Context, gameID primitive.ObjectID) (dto.GameTranslations, error) {
+	ctx, span := tracer.Start(ctx, "TranslationService.TranslationList")
+	defer span.End()
+	game, err := s.Games.GetByID(ctx, gameID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return dto.GameTranslations{}, ErrGameNotFound
+	}
+	if err != nil {
+		return dto.GameTranslations{}, recordError(span, err)
+	}
+	result := dto.GameTranslations{
+		GameID:        game.ID.Hex(),
+		DefaultLocale: s.Locales.Default,
+		Locales:       s.Locales.Supported,
+		Default:       game.DefaultTranslation(s.Locales.Default),
+		Translations:  []models.Translation{},
+		Missing:       []dto.MissingTranslation{},
+	}
+	result.Translations = append(result.Translations, game.Translations...)
+	for _, locale := range s.Locales.Translated() {
+		if missing, ok := missingTranslation(game, locale); ok {
+			result.Missing = append(result.Missing, missing)
+		}
+	}
+	return result, nil
+}
+
+// TranslationSet oyunun locale dilindeki çevirisini istekteki alanlarla değiştirir; boş alanlarda varsayılan dildeki
+// metin gösterilir. Varsayılan dil için çeviri tutulmaz.
+func (s *DefaultTranslationService) TranslationSet(ctx context.Context, gameID primitive.ObjectID, locale string, req dto.TranslationRequest) (models.Translation, error) {
+	ctx, span := tracer.Start(ctx, "TranslationService.TranslationSet")
+	defer span.End()
+	locale, err := s.translatedLocale(locale)
+	if err != nil {
+		return models.Translation{}, err
+	}
+	translation := models.Translation{
+		Locale:           locale,
+		Title:            strings.TrimSpace(req.Title),
+		Description:      strings.TrimSpace(req.Description),
+		ShortDescription: strings.TrimSpace(req.ShortDescription),
+	}
+	if translation.Title == "" && translation.Description == "" && translation.ShortDescription == "" {
+		return models.Translation{}, ErrEmptyTranslation
+	}
+	err = s.Games.SetTranslation(ctx, gameID, translation)
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.Translation{}, ErrGameNotFound
+	}
+	if err != nil {
+		return models.Translation{}, recordError(span, err)
+	}
+	s.audit(ctx, "game.translation", gameID, locale)
+	return translation, nil
+}
+
+// TranslationDelete oyunun locale dilindeki çevirisini siler; bu dilde varsayılan dildeki metinler gösterilir
+func (s *DefaultTranslationService) TranslationDelete(ctx context.Context, gameID primitive.ObjectID, locale string) error {
+	ctx, span := tracer.Start(ctx, "TranslationService.TranslationDelete")
+	defer span.End()
+	locale, err := s.translatedLocale(locale)
+	if err != nil {
+		return err
+	}
+	if _, err := s.Games.GetByID(ctx, gameID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrGameNotFound
+		}
+		return recordError(span, err)
+	}
+	err = s.Games.RemoveTranslation(ctx, gameID, locale)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrTranslationNotFound
+	}
+	if err != nil {
+		return recordError(span, err)
+	}
+	s.audit(ctx, "game.translation.delete", gameID, locale)
+	return nil
+}
+
+// TranslationMissing silinmemiş oyunların çevrilmemiş alanlarını başlığa ve dile göre sıralı döndürür. locale boşsa
+// varsayılan dil dışındaki tüm desteklenen diller raporlanır.
+func (s *DefaultTranslationService) TranslationMissing(ctx context.Context, locale string) ([]dto.MissingTranslation, error) {
+	ctx, span := tracer.Start(ctx, "TranslationService.TranslationMissing")
+	defer span.End()
+	locales := s.Locales.Translated()
+	if locale != "" {
+		normalized, err := s.translatedLocale(locale)
+		if err != nil {
+			return nil, err
+		}
+		locales = []string{normalized}
+	}
+	games, err := s.Games.GetAll(ctx)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	sort.SliceStable(games, func(i, j int) bool { return games[i].Title < games[j].Title })
+	report := []dto.MissingTranslation{}
+	for _, game := range games {
+		if game.Status == models.GameStatusRemoved {
+			continue
+		}
+		for _, l := range locales {
+			if missing, ok := missingTranslation(game, l); ok {
+				report = append(report, missing)
+			}
+		}
+	}
+	return report, nil
+}
+
+// translatedLocale dil kodunu normalleştirir; desteklenmeyen diller ve varsayılan dil hata döner
+func (s *DefaultTranslationService) translatedLocale(locale string) (string, error) {
+	locale = normalizeLocale(locale)
+	if !s.Locales.Supports(locale) {
+		return "", fmt.Errorf("%w: %q (desteklenen diller: %s)", ErrUnsupportedLocale, locale, strings.Join(s.Locales.Supported, ", "))
+	}
+	if locale == s.Locales.Default {
+		return "", ErrDefaultLocaleTranslation
+	}
+	return locale, nil
+}
+
+// audit denetim kaydını yazar; işlem zaten gerçekleştiği için hata yalnızca loglanır
+func (s *DefaultTranslationService) audit(ctx context.Context, action string, gameID primitive.ObjectID, locale string) {
+	if err := s.Audit.AuditRecord(ctx, models.AuditEntry{
+		Action:     action,
+		Resource:   "game",
+		ResourceID: gameID.Hex(),
+		Details:    map[string]interface{}{"locale": locale},
+	}); err != nil {
+		s.Log.ErrorContext(ctx, "çeviri işlemi denetim kaydına yazılamadı", "action", action, "game_id", gameID, "error", err)
+	}
+}
+
+// missingTranslation oyunun varsayılan dilde dolu olup locale dilinde çevrilmemiş alanlarını döndürür
+func missingTranslation(game models.Game, locale string) (dto.MissingTranslation, bool) {
+	base := game.DefaultTranslation("")
+	translation, _ := game.TranslationFor(locale)
+	missing := dto.MissingTranslation{GameID: game.ID.Hex(), Title: game.Title, Status: game.Status, Locale: locale}
+	for _, field := range models.TranslatableFields {
+		if base.Field(field) != "" && translation.Field(field) == "" {
+			missing.Fields = append(missing.Fields, field)
+		}
+	}
+	return missing, len(missing.Fields) > 0
+}
+
+// stripTranslations PATCH güncellemelerinden çevirileri çıkarır; çeviriler yalnızca çeviri uç noktalarıyla değişir
+func stripTranslations(updates map[string]interface{}) {
+	for field := range updates {
+		if field == "translations" || strings.HasPrefix(field, "translations.") {
+			delete(updates, field)
+		}
+	}
+}
+
+// NewTranslationService çeviri servisini oluşturur
+func NewTranslationService(games repository.ProductRepository, audit AuditService, locales Locales, logger *slog.Logger) TranslationService {
+	return &DefaultTranslationService{Games: games, Audit: audit, Locales: locales, Log: logger}
+}
@@ -0,0 +1,337 @@
+package services
+
+import (
+	"api-steam/auth"
+	"api-steam/dto"
+	"api-steam/mail"
+	"api-steam/models"
+	"api-steam/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	netmail "net/mail"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrEmailTaken         = errors.New("bu e-posta ile kayıtlı bir kullanıcı var")
+	ErrInvalidEmail       = errors.New("geçersiz e-posta adresi")
+	ErrWeakPassword       = errors.New("şifre en az 8 karakter, en fazla 72 bayt olmalıdır")
+	ErrInvalidCredentials = errors.New("e-posta veya şifre hatalı")
+	ErrInvalidToken       = errors.New("token geçersiz veya süresi dolmuş")
+	ErrInvalidAgeRating   = errors.New("geçersiz yaş derecesi: PEGI 3, 7, 12, 16, 18; ESRB EC, E, E10+, T, M, AO olabilir")
+)
+
+const (
+	// minPasswordLength kabul edilen en kısa şifre uzunluğudur
+	minPasswordLength = 8
+	// maxPasswordLength bcrypt'in kabul ettiği en uzun şifredir (bayt); daha uzun şifrelerde HashPassword hata döner
+	maxPasswordLength = 72
+)
+
+// checkPassword şifre uzunluğunu doğrular; sınırlar dışındaki şifreler ErrWeakPassword döner
+func checkPassword(password string) error {
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return ErrWeakPassword
+	}
+	return nil
+}
+
+// UserService kayıt, giriş, oturum yenileme ve şifre sıfırlama işlemlerini yapar
+type UserService interface {
+	UserRegister(ctx context.Context, req dto.RegisterRequest) (models.User, error)                                                       //Yeni hesap açar
+	UserLogin(ctx context.Context, req dto.LoginRequest) (*dto.TokenPairDTO, error)                                                       //E-posta ve şifre ile giriş
+	UserRefresh(ctx context.Context, refreshToken string) (*dto.TokenPairDTO, error)                                                      //Yenileme token'ını yeni bir çiftle değiştirir
+	UserLogout(ctx context.Context, refreshToken string) error                                                                            //Oturumu kapatır
+	UserForgotPassword(ctx context.Context, email string) error                                                                           //Şifre sıfırlama bağlantısını arka planda gönderir
+	UserWait(ctx context.Context) error                                                                                                   //Arka plandaki gönderimlerin bitmesini bekler
+	UserResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error                                                            //Tek kullanımlık token ile şifreyi değiştirir
+	UserGetByID(ctx context.Context, id primitive.ObjectID) (models.User, error)                                                          //Profil bilgisi
+	UserUpdateContentSettings(ctx context.Context, id primitive.ObjectID, req dto.ContentSettingsRequest) (models.ContentSettings, error) //Yaş derecesi sınırları
+}
+
+// DefaultUserService kullanıcıları UserRepository, token'ları TokenRepository üzerinden yönetir
+type DefaultUserService struct {
+	Users      repository.UserRepository
+	Tokens     repository.TokenRepository
+	Signer     *auth.JWTSigner
+	Mailer     mail.Mailer
+	Log        *slog.Logger
+	RefreshTTL time.Duration
+	ResetTTL   time.Duration
+	AccessTTL  time.Duration
+	BaseURL    string
+	pending    sync.WaitGroup // Arka planda gönderilen şifre sıfırlama e-postaları
+}
+
+// UserRegister yeni bir kullanıcıyı viewer rolüyle kaydeder
+func (s *DefaultUserService) UserRegister(ctx context.Context, req dto.RegisterRequest) (models.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.UserRegister")
+	defer span.End()
+	email, err := normalizeEmail(req.Email)
+	if err != nil {
+		return models.User{}, err
+	}
+	if err := checkPassword(req.Password); err != nil {
+		return models.User{}, err
+	}
+	hash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		return models.User{}, recordError(span, err)
+	}
+	user, err := s.Users.Insert(ctx, models.User{
+		Email:        email,
+		DisplayName:  strings.TrimSpace(req.DisplayName),
+		PasswordHash: hash,
+		Roles:        []string{auth.RoleViewer},
+	})
+	if errors.Is(err, repository.ErrDuplicate) {
+		return models.User{}, ErrEmailTaken
+	}
+	if err != nil {
+		return models.User{}, recordError(span, err)
+	}
+	return user, nil
+}
+
+// UserLogin şifreyi doğrular ve yeni bir erişim/yenileme token çifti üretir
+func (s *DefaultUserService) UserLogin(ctx context.Context, req dto.LoginRequest) (*dto.TokenPairDTO, error) {
+	ctx, span := tracer.Start(ctx, "UserService.UserLogin")
+	defer span.End()
+	email, _ := normalizeEmail(req.Email)
+	user, err := s.Users.GetByEmail(ctx, email)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, recordError(span, err)
+	}
+	// Kullanıcı yoksa da şifre karşılaştırması yapılır, böylece yanıt süresi e-postanın kayıtlı olup olmadığını belli etmez
+	if !auth.CheckPassword(user.PasswordHash, req.Password) {
+		s.Log.InfoContext(ctx, "başarısız giriş denemesi", "email", email)
+		return nil, ErrInvalidCredentials
+	}
+	_ = s.Users.TouchLogin(ctx, user.ID)
+	pair, err := s.issueTokens(ctx, user)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	return pair, nil
+}
+
+// UserRefresh yenileme token'ını tek kullanımlık olarak tüketip yeni bir çift üretir.
+// Daha önce kullanılmış bir token tekrar gelirse token çalınmış sayılır ve kullanıcının tüm oturumları kapatılır.
+func (s *DefaultUserService) UserRefresh(ctx context.Context, refreshToken string) (*dto.TokenPairDTO, error) {
+	ctx, span := tracer.Start(ctx, "UserService.UserRefresh")
+	defer span.End()
+	stored, err := s.Tokens.GetRefreshByHash(ctx, auth.HashSecret(refreshToken))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	if time.Now().After(stored.ExpiresAt) {
+		return nil, ErrInvalidToken
+	}
+	revoked, err := s.Tokens.RevokeRefresh(ctx, stored.ID)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	if !revoked {
+		s.Log.WarnContext(ctx, "kullanılmış yenileme token'ı tekrar gönderildi, tüm oturumlar kapatılıyor", "user_id", stored.UserID)
+		_ = s.Tokens.RevokeAllRefresh(ctx, stored.UserID)
+		return nil, ErrInvalidToken
+	}
+	user, err := s.Users.GetByID(ctx, stored.UserID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	pair, err := s.issueTokens(ctx, user)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	return pair, nil
+}
+
+// UserLogout yenileme token'ını iptal eder; bilinmeyen token'lar sessizce yok sayılır
+func (s *DefaultUserService) UserLogout(ctx context.Context, refreshToken string) error {
+	ctx, span := tracer.Start(ctx, "UserService.UserLogout")
+	defer span.End()
+	stored, err := s.Tokens.GetRefreshByHash(ctx, auth.HashSecret(refreshToken))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return recordError(span, err)
+	}
+	_, err = s.Tokens.RevokeRefresh(ctx, stored.ID)
+	return recordError(span, err)
+}
+
+// UserForgotPassword kayıtlı bir e-posta için tek kullanımlık sıfırlama bağlantısı gönderir.
+// E-postanın kayıtlı olup olmadığı çağırana belli edilmez: kullanıcı araması, token kaydı ve gönderim arka planda yapılır,
+// böylece yanıt süresi hesabın varlığına göre değişmez. Hatalar yalnızca loglanır.
+func (s *DefaultUserService) UserForgotPassword(ctx context.Context, email string) error {
+	ctx, span := tracer.Start(ctx, "UserService.UserForgotPassword")
+	defer span.End()
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil
+	}
+	ctx = context.WithoutCancel(ctx) // trace ve request_id korunur, isteğin bitmesi gönderimi durdurmaz
+	s.pending.Add(1)
+	go func() {
+		defer s.pending.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				s.Log.ErrorContext(ctx, "şifre sıfırlama e-postası gönderilirken panik", "panic", r)
+			}
+		}()
+		if err := s.sendPasswordReset(ctx, email); err != nil {
+			s.Log.ErrorContext(ctx, "şifre sıfırlama isteği işlenemedi", "error", err)
+		}
+	}()
+	return nil
+}
+
+// sendPasswordReset e-posta kayıtlıysa sıfırlama token'ını kaydeder ve bağlantıyı gönderir
+func (s *DefaultUserService) sendPasswordReset(ctx context.Context, email string) error {
+	ctx, span := tracer.Start(ctx, "UserService.sendPasswordReset")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	user, err := s.Users.GetByEmail(ctx, email)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return recordError(span, err)
+	}
+	token, hash, err := auth.NewOpaqueToken()
+	if err != nil {
+		return recordError(span, err)
+	}
+	if err := s.Tokens.InsertReset(ctx, models.PasswordResetToken{UserID: user.ID, Hash: hash, ExpiresAt: time.Now().Add(s.ResetTTL)}); err != nil {
+		return recordError(span, err)
+	}
+	link := fmt.Sprintf("%s/reset-password?token=%s", strings.TrimRight(s.BaseURL, "/"), token)
+	err = s.Mailer.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "Şifre sıfırlama",
+		Body:    fmt.Sprintf("Şifrenizi sıfırlamak için bağlantıyı kullanın (%s geçerlidir):\n\n%s\n\nBu isteği siz yapmadıysanız bu e-postayı yok sayabilirsiniz.", s.ResetTTL, link),
+	})
+	if err != nil {
+		s.Log.ErrorContext(ctx, "şifre sıfırlama e-postası gönderilemedi", "user_id", user.ID, "error", err)
+		return recordError(span, err)
+	}
+	return nil
+}
+
+// UserWait arka planda gönderilen şifre sıfırlama e-postalarının bitmesini ctx süresi dolana kadar bekler
+func (s *DefaultUserService) UserWait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.pending.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// UserResetPassword token'ı tüketip şifreyi değiştirir ve kullanıcının tüm oturumlarını kapatır
+func (s *DefaultUserService) UserResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error {
+	ctx, span := tracer.Start(ctx, "UserService.UserResetPassword")
+	defer span.End()
+	if err := checkPassword(req.Password); err != nil {
+		return err
+	}
+	token, err := s.Tokens.ConsumeReset(ctx, auth.HashSecret(req.Token))
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrInvalidToken
+	}
+	if err != nil {
+		return recordError(span, err)
+	}
+	hash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		return recordError(span, err)
+	}
+	if err := s.Users.UpdatePassword(ctx, token.UserID, hash); err != nil {
+		return recordError(span, err)
+	}
+	s.Log.InfoContext(ctx, "şifre sıfırlandı", "user_id", token.UserID)
+	return recordError(span, s.Tokens.RevokeAllRefresh(ctx, token.UserID))
+}
+
+// UserGetByID kullanıcının profilini getirir
+func (s *DefaultUserService) UserGetByID(ctx context.Context, id primitive.ObjectID) (models.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.UserGetByID")
+	defer span.End()
+	user, err := s.Users.GetByID(ctx, id)
+	if err != nil {
+		return models.User{}, recordError(span, err)
+	}
+	return user, nil
+}
+
+// UserUpdateContentSettings kullanıcının PEGI ve ESRB sınırlarını doğrulayıp kaydeder
+func (s *DefaultUserService) UserUpdateContentSettings(ctx context.Context, id primitive.ObjectID, req dto.ContentSettingsRequest) (models.ContentSettings, error) {
+	ctx, span := tracer.Start(ctx, "UserService.UserUpdateContentSettings")
+	defer span.End()
+	settings, err := ParseContentSettings(req.MaxPEGI, req.MaxESRB)
+	if err != nil {
+		return models.ContentSettings{}, err
+	}
+	if err := s.Users.UpdateContentSettings(ctx, id, settings); err != nil {
+		return models.ContentSettings{}, recordError(span, err)
+	}
+	return settings, nil
+}
+
+// issueTokens kullanıcı için erişim token'ı imzalar ve yeni bir yenileme token'ı kaydeder
+func (s *DefaultUserService) issueTokens(ctx context.Context, user models.User) (*dto.TokenPairDTO, error) {
+	access, _, err := s.Signer.Sign(auth.Principal{Subject: user.ID.Hex(), Kind: auth.KindUser, Name: user.DisplayName, Roles: user.Roles})
+	if err != nil {
+		return nil, err
+	}
+	refresh, hash, err := auth.NewOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Tokens.InsertRefresh(ctx, models.RefreshToken{UserID: user.ID, Hash: hash, ExpiresAt: time.Now().Add(s.RefreshTTL)}); err != nil {
+		return nil, err
+	}
+	return &dto.TokenPairDTO{AccessToken: access, RefreshToken: refresh, TokenType: "Bearer", ExpiresIn: int64(s.AccessTTL.Seconds())}, nil
+}
+
+// normalizeEmail e-postayı doğrular ve küçük harfe çevirir
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	addr, err := netmail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return "", ErrInvalidEmail
+	}
+	return email, nil
+}
+
+// NewUserService kullanıcı servisini oluşturur
+func NewUserService(users repository.UserRepository, tokens repository.TokenRepository, signer *auth.JWTSigner, mailer mail.Mailer, logger *slog.Logger, accessTTL, refreshTTL, resetTTL time.Duration, baseURL string) UserService {
+	return &DefaultUserService{
+		Users:      users,
+		Tokens:     tokens,
+		Signer:     signer,
+		Mailer:     mailer,
+		Log:        logger,
+		AccessTTL:  accessTTL,
+		RefreshTTL: refreshTTL,
+		ResetTTL:   resetTTL,
+		BaseURL:    baseURL,
+	}
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"api-steam/dto"
+	"api-steam/mail"
+	"api-steam/models"
+	"api-steam/repository"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// resetUsers yalnızca GetByEmail'i karşılar; release kapanana kadar bekleyerek yavaş bir veritabanını taklit eder
+type resetUsers struct {
+	repository.UserRepository
+	users   map[string]models.User
+	err     error
+	release chan struct{}
+}
+
+func (r *resetUsers) GetByEmail(ctx context.Context, email string) (models.User, error) {
+	<-r.release
+	if r.err != nil {
+		return models.User{}, r.err
+	}
+	user, ok := r.users[email]
+	if !ok {
+		return models.User{}, repository.ErrNotFound
+	}
+	return user, nil
+}
+
+type resetTokens struct {
+	repository.TokenRepository
+	mu     sync.Mutex
+	tokens []models.PasswordResetToken
+}
+
+func (r *resetTokens) InsertReset(_ context.Context, token models.PasswordResetToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens = append(r.tokens, token)
+	return nil
+}
+
+type recordingMailer struct {
+	mu   sync.Mutex
+	sent []mail.Message
+}
+
+func (m *recordingMailer) Send(_ context.Context, msg mail.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func TestUserForgotPassword(t *testing.T) {
+	known := models.User{ID: primitive.NewObjectID(), Email: "oyuncu@example.com"}
+	tests := []struct {
+		name       string
+		email      string
+		repoErr    error
+		wantMail   bool
+		wantTokens int
+	}{
+		{"kayıtlı", " Oyuncu@Example.com ", nil, true, 1},
+		{"kayıtsız", "yok@example.com", nil, false, 0},
+		{"geçersiz", "e-posta değil", nil, false, 0},
+		{"veritabanı hatası", "oyuncu@example.com", errors.New("bağlantı koptu"), false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &resetUsers{users: map[string]models.User{known.Email: known}, err: tt.repoErr, release: make(chan struct{})}
+			tokens, mailer := &resetTokens{}, &recordingMailer{}
+			s := &DefaultUserService{Users: users, Tokens: tokens, Mailer: mailer, Log: slog.New(slog.NewTextHandler(io.Discard, nil)),
+				ResetTTL: time.Hour, BaseURL: "https://store.example.com/"}
+
+			// Yanıt, kullanıcı araması bitmeden döner; süre hesabın varlığına bağlı değildir
+			if err := s.UserForgotPassword(context.Background(), tt.email); err != nil {
+				t.Fatalf("beklenmeyen hata: %v", err)
+			}
+			close(users.release)
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			if err := s.UserWait(ctx); err != nil {
+				t.Fatalf("arka plandaki gönderim bitmedi: %v", err)
+			}
+
+			if len(tokens.tokens) != tt.wantTokens {
+				t.Errorf("kaydedilen token = %d, beklenen %d", len(tokens.tokens), tt.wantTokens)
+			}
+			if got := len(mailer.sent) == 1; got != tt.wantMail {
+				t.Fatalf("gönderilen e-posta = %d, gönderim bekleniyor mu: %v", len(mailer.sent), tt.wantMail)
+			}
+			if tt.wantMail {
+				msg := mailer.sent[0]
+				if msg.To != known.Email || !strings.Contains(msg.Body, "https://store.example.com/reset-password?token=") {
+					t.Errorf("e-posta = %+v", msg)
+				}
+				if tokens.tokens[0].UserID != known.ID || !tokens.tokens[0].ExpiresAt.After(time.Now()) {
+					t.Errorf("token = %+v", tokens.tokens[0])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckPassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		wantErr  error
+	}{
+		{"çok kısa", "1234567", ErrWeakPassword},
+		{"en kısa", "12345678", nil},
+		{"en uzun", strings.Repeat("a", 72), nil},
+		{"bcrypt sınırını aşan", strings.Repeat("a", 73), ErrWeakPassword},
+		{"72 baytlık çok baytlı", strings.Repeat("ş", 36), nil},
+		{"72 baytı aşan çok baytlı", strings.Repeat("ş", 37), ErrWeakPassword},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkPassword(tt.password); !errors.Is(err, tt.wantErr) {
+				t.Errorf("hata = %v, beklenen %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLongPasswordRejectedBeforeHashing(t *testing.T) {
+	// Depolar verilmez: uzun şifre bcrypt'e ve veritabanına ulaşmadan 400'e eşlenen hatayla reddedilmeli
+	s := &DefaultUserService{}
+	password := strings.Repeat("a", 73)
+	if _, err := s.UserRegister(context.Background(), dto.RegisterRequest{Email: "oyuncu@example.com", Password: password}); !errors.Is(err, ErrWeakPassword) {
+		t.Errorf("kayıt hatası = %v, beklenen ErrWeakPassword", err)
+	}
+	if err := s.UserResetPassword(context.Background(), dto.ResetPasswordRequest{Token: "x", Password: password}); !errors.Is(err, ErrWeakPassword) {
+		t.Errorf("sıfırlama hatası = %v, beklenen ErrWeakPassword", err)
+	}
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"api-steam/dto"
+	"api-steam/models"
+	"api-steam/repository"
+	"context"
+	"errors"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrGameNotFound         = errors.New("oyun bulunamadı")
+	ErrAlreadyWishlisted    = errors.New("oyun zaten istek listesinde")
+	ErrWishlistItemNotFound = errors.New("oyun istek listesinde değil")
+)
+
+// WishlistService kullanıcıların istek listelerini yönetir
+type WishlistService interface {
+	WishlistList(ctx context.Context, userID primitive.ObjectID) ([]models.WishlistItem, error)                                             //Kullanıcının listesi
+	WishlistAdd(ctx context.Context, userID primitive.ObjectID, req dto.WishlistAddRequest) (models.WishlistItem, error)                    //Listeye oyun ekler
+	WishlistUpdate(ctx context.Context, userID, gameID primitive.ObjectID, req dto.WishlistPreferencesRequest) (models.WishlistItem, error) //Bildirim tercihlerini değiştirir
+	WishlistRemove(ctx context.Context, userID, gameID primitive.ObjectID) error                                                            //Listeden çıkarır
+}
+
+// DefaultWishlistService istek listelerini WishlistRepository üzerinden, oyunların varlığını ProductRepository üzerinden kontrol eder
+type DefaultWishlistService struct {
+	Repo  repository.WishlistRepository
+	Games repository.ProductRepository
+	Log   *slog.Logger
+}
+
+// WishlistList kullanıcının istek listesini döndürür
+func (s *DefaultWishlistService) WishlistList(ctx context.Context, userID primitive.ObjectID) ([]models.WishlistItem, error) {
+	ctx, span := tracer.Start(ctx, "WishlistService.WishlistList")
+	defer span.End()
+	items, err := s.Repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	return items, nil
+}
+
+// WishlistAdd katalogda var olan bir oyunu kullanıcının listesine ekler
+func (s *DefaultWishlistService) WishlistAdd(ctx context.Context, userID primitive.ObjectID, req dto.WishlistAddRequest) (models.WishlistItem, error) {
+	ctx, span := tracer.Start(ctx, "WishlistService.WishlistAdd")
+	defer span.End()
+	gameID, err := primitive.ObjectIDFromHex(req.GameID)
+	if err != nil {
+		return models.WishlistItem{}, ErrGameNotFound
+	}
+	if _, err := s.Games.GetByID(ctx, gameID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.WishlistItem{}, ErrGameNotFound
+		}
+		return models.WishlistItem{}, recordError(span, err)
+	}
+	item := models.WishlistItem{UserID: userID, GameID: gameID, NotifyOnSale: true, NotifyOnRelease: true}
+	if req.NotifyOnSale != nil {
+		item.NotifyOnSale = *req.NotifyOnSale
+	}
+	if req.NotifyOnRelease != nil {
+		item.NotifyOnRelease = *req.NotifyOnRelease
+	}
+	item, err = s.Repo.Add(ctx, item)
+	if errors.Is(err, repository.ErrDuplicate) {
+		return models.WishlistItem{}, ErrAlreadyWishlisted
+	}
+	if err != nil {
+		return models.WishlistItem{}, recordError(span, err)
+	}
+	return item, nil
+}
+
+// WishlistUpdate listedeki oyunun bildirim tercihlerini değiştirir
+func (s *DefaultWishlistService) WishlistUpdate(ctx context.Context, userID, gameID primitive.ObjectID, req dto.WishlistPreferencesRequest) (models.WishlistItem, error) {
+	ctx, span := tracer.Start(ctx, "WishlistService.WishlistUpdate")
+	defer span.End()
+	item, err := s.Repo.UpdatePreferences(ctx, userID, gameID, req.NotifyOnSale, req.NotifyOnRelease)
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.WishlistItem{}, ErrWishlistItemNotFound
+	}
+	if err != nil {
+		return models.WishlistItem{}, recordError(span, err)
+	}
+	return item, nil
+}
+
+// WishlistRemove oyunu kullanıcının listesinden çıkarır
+func (s *DefaultWishlistService) WishlistRemove(ctx context.Context, userID, gameID primitive.ObjectID) error {
+	ctx, span := tracer.Start(ctx, "WishlistService.WishlistRemove")
+	defer span.End()
+	err := s.Repo.Remove(ctx, userID, gameID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrWishlistItemNotFound
+	}
+	return recordError(span, err)
+}
+
+// NewWishlistService istek listesi servisini oluşturur
+func NewWishlistService(repo repository.WishlistRepository, games repository.ProductRepository, logger *slog.Logger) WishlistService {
+	return &DefaultWishlistService{Repo: repo, Games: games, Log: logger}
+}
@@ -0,0 +1,70 @@
+package telemetry
+
+import (
+	"api-steam/configs"
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName trace'lerde görünen servis adıdır
+const ServiceName = "gamer-hub-api"
+
+// Setup global TracerProvider'ı ve W3C traceparent yayılımını kurar.
+// Exporter OTEL_TRACES_EXPORTER ile seçilir: "otlp" (varsayılan, OTEL_EXPORTER_OTLP_* değişkenlerini okur), "stdout" veya "none".
+// Dönen fonksiyon kapanışta bekleyen span'leri gönderip provider'ı kapatır.
+func Setup(ctx context.Context) (func(context.Context) error, error) {
+	// Gelen isteklerdeki traceparent/baggage başlıklarını oku, giden isteklere yaz
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch kind := configs.EnvTracesExporter(); kind {
+	case "none":
+		return func(context.Context) error { return nil }, nil
+	case "stdout", "console":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+	case "otlp":
+		exporter, err = otlptracehttp.New(ctx)
+	default:
+		return nil, fmt.Errorf("bilinmeyen trace exporter: %q", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(ServiceName),
+		semconv.ServiceVersion(configs.Version),
+	))
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Tracer verilen paket adıyla global provider'dan bir tracer döndürür
+func Tracer(name string) trace.Tracer {
+	return otel.Tracer(name)
+}
+
+// EndSpan hata varsa span'e işler ve span'i kapatır
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
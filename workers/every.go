@@ -0,0 +1,35 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Every fn'i hemen bir kez, sonra her interval'de çalıştıran bir iş döndürür; ctx iptal edilince durur. interval pozitif
+// olmalıdır (time.NewTicker aksi halde panik verir); yapılandırmadan gelen aralıklar configs içinde doğrulanır
+func Every(interval time.Duration, fn func(ctx context.Context)) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			tick(ctx, fn)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+// tick fn'i bir kez çalıştırır; fn paniklerse panik loglanır ve iş bir sonraki aralıkta yeniden denenir, böylece tek bir
+// işteki hata bütün API sürecini durdurmaz
+func tick(ctx context.Context, fn func(ctx context.Context)) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Default().ErrorContext(ctx, "zamanlanmış iş panikledi", "panic", r)
+		}
+	}()
+	fn(ctx)
+}
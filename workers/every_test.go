@@ -0,0 +1,45 @@
+package workers
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestEveryRecoversFromPanic(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls := make(chan int, 10)
+	count := 0
+	job := Every(time.Millisecond, func(context.Context) {
+		count++
+		select {
+		case calls <- count:
+		default: // test beklemeyi bıraktıysa iş tıkanmaz
+		}
+		if count == 1 {
+			panic("ilk çalıştırma patladı")
+		}
+	})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		job(ctx)
+	}()
+	for want := 1; want <= 2; want++ {
+		select {
+		case got := <-calls:
+			if got != want {
+				t.Fatalf("çalıştırma = %d, beklenen %d", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%d. çalıştırma gelmedi; panik işi durdurdu", want)
+		}
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("iş ctx iptal edilince durmadı")
+	}
+}
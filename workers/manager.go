@@ -0,0 +1,92 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// Manager arka planda çalışan işleri (zamanlanmış görevler, kuyruk tüketicileri vb.) başlatır ve kapanışta durdurur
+type Manager struct {
+	Log     *slog.Logger
+	mu      sync.Mutex
+	jobs    []job
+	running map[string]bool
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+type job struct {
+	name string
+	run  func(ctx context.Context)
+}
+
+// NewManager boş bir iş yöneticisi oluşturur
+func NewManager(logger *slog.Logger) *Manager {
+	return &Manager{Log: logger, running: map[string]bool{}}
+}
+
+// Add yöneticiye bir iş ekler; run fonksiyonu ctx iptal edilene kadar çalışmalı ve sonra dönmelidir
+func (m *Manager) Add(name string, run func(ctx context.Context)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs = append(m.jobs, job{name: name, run: run})
+}
+
+// Start eklenen tüm işleri kendi goroutine'lerinde başlatır
+func (m *Manager) Start(parent context.Context) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ctx, cancel := context.WithCancel(parent)
+	m.cancel = cancel
+	for _, j := range m.jobs {
+		m.running[j.name] = true
+		m.wg.Add(1)
+		go func(j job) {
+			defer m.wg.Done()
+			defer m.markStopped(j.name)
+			m.Log.Info("iş başlatıldı", "worker", j.name)
+			j.run(ctx)
+			m.Log.Info("iş durdu", "worker", j.name)
+		}(j)
+	}
+}
+
+// Stop tüm işlere durma sinyali gönderir ve ctx süresi dolana kadar bitmelerini bekler
+func (m *Manager) Stop(ctx context.Context) error {
+	m.mu.Lock()
+	cancel := m.cancel
+	m.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Running işlerin adlarını çalışıp çalışmadıklarıyla birlikte döndürür
+func (m *Manager) Running() map[string]bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make(map[string]bool, len(m.jobs))
+	for _, j := range m.jobs {
+		out[j.name] = m.running[j.name]
+	}
+	return out
+}
+
+func (m *Manager) markStopped(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.running[name] = false
+}